@@ -167,11 +167,7 @@ const libsRegexp = /(?:^|[\\/])internal[\\/]bundled[\\/]libs[\\/]/;
 async function generateLibs(out) {
     await fs.promises.mkdir(out, { recursive: true });
 
-    const libs = await fs.promises.readdir(libsDir);
-
-    await Promise.all(libs.map(async lib => {
-        fs.promises.copyFile(path.join(libsDir, lib), path.join(out, lib));
-    }));
+    await fs.promises.cp(libsDir, out, { recursive: true });
 }
 
 export const lib = task({
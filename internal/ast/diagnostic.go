@@ -7,6 +7,7 @@ import (
 
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"golang.org/x/text/language"
 )
 
 // Diagnostic
@@ -22,6 +23,11 @@ type Diagnostic struct {
 	reportsUnnecessary bool
 	reportsDeprecated  bool
 	skippedOnNoEmit    bool
+
+	// messageTemplate and messageArgs are the message and arguments the diagnostic was created from, if any, so
+	// that its message can be localized.
+	messageTemplate *diagnostics.Message
+	messageArgs     []any
 }
 
 func (d *Diagnostic) File() *SourceFile                 { return d.file }
@@ -38,6 +44,15 @@ func (d *Diagnostic) ReportsUnnecessary() bool          { return d.reportsUnnece
 func (d *Diagnostic) ReportsDeprecated() bool           { return d.reportsDeprecated }
 func (d *Diagnostic) SkippedOnNoEmit() bool             { return d.skippedOnNoEmit }
 
+// Localize returns the message of the diagnostic in a locale. The message is in English if it has no translation
+// or the diagnostic was not created from a message, e.g. when it was read from a build info file.
+func (d *Diagnostic) Localize(locale language.Tag) string {
+	if d.messageTemplate == nil || locale == language.Und {
+		return d.message
+	}
+	return d.messageTemplate.Localize(locale, d.messageArgs...)
+}
+
 func (d *Diagnostic) SetFile(file *SourceFile)                  { d.file = file }
 func (d *Diagnostic) SetLocation(loc core.TextRange)            { d.loc = loc }
 func (d *Diagnostic) SetCategory(category diagnostics.Category) { d.category = category }
@@ -107,6 +122,8 @@ func NewDiagnostic(file *SourceFile, loc core.TextRange, message *diagnostics.Me
 		message:            message.Format(args...),
 		reportsUnnecessary: message.ReportsUnnecessary(),
 		reportsDeprecated:  message.ReportsDeprecated(),
+		messageTemplate:    message,
+		messageArgs:        args,
 	}
 }
 
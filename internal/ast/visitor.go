@@ -203,6 +203,9 @@ func (v *NodeVisitor) visitEmbeddedStatement(node *Node) *Node {
 		return v.Hooks.VisitEmbeddedStatement(node, v)
 	}
 	if v.Hooks.VisitNode != nil {
+		if node == nil {
+			return nil
+		}
 		return v.liftToBlock(v.Hooks.VisitNode(node, v))
 	}
 	return v.VisitEmbeddedStatement(node)
@@ -37,7 +37,12 @@ func TestEmbeddedLibs(t *testing.T) {
 		if err != nil {
 			return err
 		}
-		if !d.IsDir() {
+		if d.IsDir() {
+			// Skip the translations of the locales.
+			if path != bundled.LibPath() {
+				return vfs.SkipDir
+			}
+		} else {
 			files = append(files, tspath.GetBaseFileName(path))
 		}
 		return nil
@@ -46,3 +51,15 @@ func TestEmbeddedLibs(t *testing.T) {
 
 	assert.DeepEqual(t, files, bundled.LibNames)
 }
+
+func TestEmbeddedLocales(t *testing.T) {
+	t.Parallel()
+
+	fs := bundled.WrapFS(osvfs.FS())
+
+	for _, locale := range bundled.LocaleNames {
+		localePath := tspath.CombinePaths(bundled.LibPath(), locale)
+		assert.Assert(t, fs.DirectoryExists(localePath), localePath)
+		assert.Assert(t, fs.FileExists(tspath.CombinePaths(localePath, "diagnosticMessages.generated.json")), localePath)
+	}
+}
@@ -59,7 +59,8 @@ func (vfs *wrappedFS) ReadFile(path string) (contents string, ok bool) {
 
 func (vfs *wrappedFS) DirectoryExists(path string) bool {
 	if rest, ok := splitPath(path); ok {
-		return rest == "libs"
+		_, isLocale := localeEntries[rest]
+		return rest == "libs" || isLocale
 	}
 	return vfs.fs.DirectoryExists(path)
 }
@@ -70,6 +71,11 @@ func (vfs *wrappedFS) GetAccessibleEntries(path string) (result vfs.Entries) {
 			result.Directories = []string{"libs"}
 		} else if rest == "libs" {
 			result.Files = LibNames
+			result.Directories = LocaleNames
+		} else if entries, ok := localeEntries[rest]; ok {
+			for _, entry := range entries {
+				result.Files = append(result.Files, entry.Name())
+			}
 		}
 		return result
 	}
@@ -82,12 +88,12 @@ var rootEntries = []fs.DirEntry{
 
 func (vfs *wrappedFS) Stat(path string) vfs.FileInfo {
 	if rest, ok := splitPath(path); ok {
-		if rest == "" || rest == "libs" {
+		if _, isLocale := localeEntries[rest]; rest == "" || rest == "libs" || isLocale {
 			return &fileInfo{name: rest, mode: fs.ModeDir}
 		}
-		if lib, ok := embeddedContents[rest]; ok {
-			libName, _ := strings.CutPrefix(rest, "libs/")
-			return &fileInfo{name: libName, size: int64(len(lib))}
+		if contents, ok := embeddedContents[rest]; ok {
+			name := rest[strings.LastIndexByte(rest, '/')+1:]
+			return &fileInfo{name: name, size: int64(len(contents))}
 		}
 		return nil
 	}
@@ -115,7 +121,7 @@ func (vfs *wrappedFS) walkDir(rest string, walkFn vfs.WalkDirFunc) error {
 	case "libs":
 		entries = libsEntries
 	default:
-		return nil
+		entries = localeEntries[rest]
 	}
 
 	for _, entry := range entries {
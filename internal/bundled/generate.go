@@ -4,8 +4,12 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"go/format"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
@@ -21,6 +25,7 @@ import (
 
 var (
 	libInputDir     = filepath.Join(repo.TypeScriptSubmodulePath, "src", "lib")
+	localeInputDir  = filepath.Join(repo.TypeScriptSubmodulePath, "src", "loc", "lcl")
 	copyrightNotice = filepath.Join(repo.TypeScriptSubmodulePath, "scripts", "CopyrightNotice.txt")
 )
 
@@ -28,9 +33,11 @@ func main() {
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
 	libs := readLibs()
+	locales := readLocales()
 	generateLibs(libs)
-	generateLibList(libs)
-	generateEmbedded(libs)
+	generateLocales(locales)
+	generateLibList(libs, locales)
+	generateEmbedded(libs, locales)
 }
 
 type lib struct {
@@ -38,6 +45,13 @@ type lib struct {
 	sources []string // sources relative to src/lib dir
 }
 
+type locale struct {
+	name     string            // directory of the locale in the libs dir
+	messages map[string]string // translated messages by diagnostic key
+}
+
+const localeMessagesFileName = "diagnosticMessages.generated.json"
+
 func generateLibs(libs []lib) {
 	const outputDir = "libs"
 
@@ -73,7 +87,29 @@ func generateLibs(libs []lib) {
 	}
 }
 
-func generateLibList(libs []lib) {
+func generateLocales(locales []locale) {
+	for _, locale := range locales {
+		outputDir := filepath.Join("libs", locale.name)
+		if err := os.MkdirAll(outputDir, 0o755); err != nil {
+			log.Fatalf("failed to create %s directory: %v", outputDir, err)
+		}
+
+		var output bytes.Buffer
+		encoder := json.NewEncoder(&output)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "    ")
+		if err := encoder.Encode(locale.messages); err != nil {
+			log.Fatalf("failed to encode messages of %s: %v", locale.name, err)
+		}
+
+		outputPath := filepath.Join(outputDir, localeMessagesFileName)
+		if err := os.WriteFile(outputPath, output.Bytes(), 0o644); err != nil {
+			log.Fatalf("failed to write %s: %v", outputPath, err)
+		}
+	}
+}
+
+func generateLibList(libs []lib, locales []locale) {
 	var code bytes.Buffer
 	code.WriteString("// Code generated by generate.go; DO NOT EDIT.\n\n")
 	code.WriteString("package bundled\n\n")
@@ -84,16 +120,28 @@ func generateLibList(libs []lib) {
 	for _, lib := range libs {
 		code.WriteString("\t\"" + lib.target + "\",\n")
 	}
+	code.WriteString("}\n\n")
+
+	code.WriteString("// LocaleNames is the list of all locales with bundled translations of the diagnostic messages, sorted by name.\n")
+	code.WriteString("// The translations of a locale are in the " + localeMessagesFileName + " file of its directory in the libs directory.\n")
+	code.WriteString("var LocaleNames = []string{\n")
+	for _, locale := range locales {
+		code.WriteString("\t\"" + locale.name + "\",\n")
+	}
 	code.WriteString("}\n")
 
 	writeCode("libs_generated.go", code.Bytes())
 }
 
-func generateEmbedded(libs []lib) {
+func generateEmbedded(libs []lib, locales []locale) {
 	libVarNames := make([]string, len(libs))
 	for i, lib := range libs {
 		libVarNames[i] = "libs_" + strings.ReplaceAll(lib.target, ".", "_")
 	}
+	localeVarNames := make([]string, len(locales))
+	for i, locale := range locales {
+		localeVarNames[i] = "libs_" + strings.ReplaceAll(locale.name, "-", "_") + "_messages"
+	}
 
 	var code bytes.Buffer
 	code.WriteString("//go:build !noembed\n\n")
@@ -110,6 +158,11 @@ func generateEmbedded(libs []lib) {
 		code.WriteString("//go:embed libs/" + lib.target + "\n")
 		code.WriteString("" + varName + " string\n")
 	}
+	for i, locale := range locales {
+		varName := localeVarNames[i]
+		code.WriteString("//go:embed libs/" + locale.name + "/" + localeMessagesFileName + "\n")
+		code.WriteString("" + varName + " string\n")
+	}
 	code.WriteString(")\n\n")
 
 	code.WriteString("var embeddedContents = map[string]string{\n")
@@ -117,6 +170,10 @@ func generateEmbedded(libs []lib) {
 		varName := libVarNames[i]
 		code.WriteString("\t\"libs/" + lib.target + "\": " + varName + ",\n")
 	}
+	for i, locale := range locales {
+		varName := localeVarNames[i]
+		code.WriteString("\t\"libs/" + locale.name + "/" + localeMessagesFileName + "\": " + varName + ",\n")
+	}
 	code.WriteString("}\n\n")
 
 	code.WriteString("var libsEntries = []fs.DirEntry{\n")
@@ -124,6 +181,16 @@ func generateEmbedded(libs []lib) {
 		varName := libVarNames[i]
 		fmt.Fprintf(&code, "\t&fileInfo{name: %q, size: int64(len(%s))},\n", lib.target, varName)
 	}
+	for _, locale := range locales {
+		fmt.Fprintf(&code, "\t&fileInfo{name: %q, mode: fs.ModeDir},\n", locale.name)
+	}
+	code.WriteString("}\n\n")
+
+	code.WriteString("var localeEntries = map[string][]fs.DirEntry{\n")
+	for i, locale := range locales {
+		varName := localeVarNames[i]
+		fmt.Fprintf(&code, "\t%q: {&fileInfo{name: %q, size: int64(len(%s))}},\n", "libs/"+locale.name, localeMessagesFileName, varName)
+	}
 	code.WriteString("}\n")
 
 	writeCode("embed_generated.go", code.Bytes())
@@ -204,6 +271,100 @@ func readLibs() []lib {
 	return libs
 }
 
+// readLocales reads the translations of the diagnostic messages from the localization files of the TypeScript
+// repo, one directory per language, and names each locale as the TypeScript package does.
+func readLocales() []locale {
+	dirs, err := os.ReadDir(localeInputDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Without translations, the generated tables would silently be empty.
+		fmt.Fprintf(os.Stderr, "The localization files of the TypeScript submodule are missing: %s does not exist.\n", localeInputDir)
+		fmt.Fprintln(os.Stderr, "Run `git submodule update --init --recursive` and generate again.")
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatalf("failed to read %s: %v", localeInputDir, err)
+	}
+
+	var locales []locale
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+		lclPath := filepath.Join(localeInputDir, dir.Name(), "diagnosticMessages", localeMessagesFileName+".lcl")
+		b, err := os.ReadFile(lclPath)
+		if err != nil {
+			log.Fatalf("failed to read %s: %v", lclPath, err)
+		}
+		locales = append(locales, parseLocale(lclPath, b))
+	}
+
+	slices.SortFunc(locales, func(a locale, b locale) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return locales
+}
+
+type lcxItem struct {
+	ItemID string    `xml:"ItemId,attr"`
+	Str    *lcxStr   `xml:"Str"`
+	Items  []lcxItem `xml:"Item"`
+}
+
+type lcxStr struct {
+	Val string `xml:"Val"`
+	Tgt *struct {
+		Val string `xml:"Val"`
+	} `xml:"Tgt"`
+}
+
+// The ids and translations in localization files escape brackets.
+var lcxUnescaper = strings.NewReplacer("]5D;", "]", "]5B;", "[")
+
+func parseLocale(lclPath string, b []byte) locale {
+	var lcx struct {
+		TgtCul string    `xml:"TgtCul,attr"`
+		Items  []lcxItem `xml:"Item"`
+	}
+	if err := xml.Unmarshal(b, &lcx); err != nil {
+		log.Fatalf("failed to parse %s: %v", lclPath, err)
+	}
+	if lcx.TgtCul == "" {
+		log.Fatalf("missing target culture in %s", lclPath)
+	}
+
+	messages := make(map[string]string)
+	var visit func(items []lcxItem)
+	visit = func(items []lcxItem) {
+		for _, item := range items {
+			if item.Str != nil {
+				val := item.Str.Val
+				if item.Str.Tgt != nil {
+					val = item.Str.Tgt.Val
+				}
+				key := lcxUnescaper.Replace(strings.TrimPrefix(item.ItemID, ";"))
+				messages[key] = lcxUnescaper.Replace(val)
+			}
+			visit(item.Items)
+		}
+	}
+	visit(lcx.Items)
+
+	return locale{name: localeName(lcx.TgtCul), messages: messages}
+}
+
+// localeName returns the directory of a target culture. Only the Chinese and Brazilian Portuguese translations
+// are named after their territory; the others are named after their language.
+func localeName(culture string) string {
+	culture = strings.ToLower(culture)
+	switch culture {
+	case "zh-cn", "zh-tw", "pt-br":
+		return culture
+	}
+	language, _, _ := strings.Cut(culture, "-")
+	return language
+}
+
 func readCopyright() []byte {
 	b, err := os.ReadFile(copyrightNotice)
 	if err != nil {
@@ -106,3 +106,7 @@ var LibNames = []string{
 	"lib.webworker.importscripts.d.ts",
 	"lib.webworker.iterable.d.ts",
 }
+
+// LocaleNames is the list of all locales with bundled translations of the diagnostic messages, sorted by name.
+// The translations of a locale are in the diagnosticMessages.generated.json file of its directory in the libs directory.
+var LocaleNames = []string{}
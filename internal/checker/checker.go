@@ -25,6 +25,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/modulespecifiers"
 	"github.com/microsoft/typescript-go/internal/scanner"
 	"github.com/microsoft/typescript-go/internal/stringutil"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
@@ -543,6 +544,7 @@ type Program interface {
 	GetProjectReferenceFromOutputDts(path tspath.Path) *tsoptions.SourceOutputAndProjectReference
 	GetRedirectForResolution(file ast.HasFileName) *tsoptions.ParsedCommandLine
 	CommonSourceDirectory() string
+	Tracer() *tracing.Tracer
 }
 
 type Host interface {
@@ -851,6 +853,8 @@ type Checker struct {
 	_jsxFactoryEntity                           *ast.Node
 	skipDirectInferenceNodes                    collections.Set[*ast.Node]
 	ctx                                         context.Context
+	tracer                                      *tracing.Thread
+	tracedTypes                                 []*Type
 	packagesMap                                 map[string]bool
 	activeMappers                               []*TypeMapper
 	activeTypeMappersCaches                     []map[string]*Type
@@ -864,6 +868,7 @@ func NewChecker(program Program) *Checker {
 	c := &Checker{}
 	c.id = nextCheckerID.Add(1)
 	c.program = program
+	c.tracer = program.Tracer().NewCheckerThread(c.describeTracedTypes)
 	c.compilerOptions = program.Options()
 	c.files = program.SourceFiles()
 	c.fileIndexMap = createFileIndexMap(c.files)
@@ -2100,6 +2105,10 @@ func (c *Checker) checkSourceFile(ctx context.Context, sourceFile *ast.SourceFil
 	c.checkNotCanceled()
 	links := c.sourceFileLinks.Get(sourceFile)
 	if !links.typeChecked {
+		if c.tracer != nil {
+			span := c.tracer.Begin(tracing.PhaseCheck, "checkSourceFile", tracing.Args{"path": sourceFile.FileName(), "checker": c.tracer.Checker})
+			defer span.End()
+		}
 		c.ctx = ctx
 		// Grammar checking
 		c.checkGrammarSourceFile(sourceFile)
@@ -24144,6 +24153,9 @@ func (c *Checker) newType(flags TypeFlags, objectFlags ObjectFlags, data TypeDat
 	t.id = TypeId(c.TypeCount)
 	t.checker = c
 	t.data = data
+	if c.tracer != nil {
+		c.tracedTypes = append(c.tracedTypes, t)
+	}
 	return t
 }
 
@@ -98,6 +98,10 @@ func (c *Checker) GetDeclaredTypeOfSymbol(symbol *ast.Symbol) *Type {
 	return c.getDeclaredTypeOfSymbol(symbol)
 }
 
+func (c *Checker) GetBaseTypes(t *Type) []*Type {
+	return c.getBaseTypes(t)
+}
+
 func (c *Checker) GetTypeOfSymbol(symbol *ast.Symbol) *Type {
 	return c.getTypeOfSymbol(symbol)
 }
@@ -150,6 +154,14 @@ func (c *Checker) GetExpandedParameters(signature *Signature, skipUnionExpanding
 	return c.getExpandedParameters(signature, skipUnionExpanding)
 }
 
+func (c *Checker) GetSignatureFromDeclaration(declaration *ast.Node) *Signature {
+	return c.getSignatureFromDeclaration(declaration)
+}
+
+func HasContextSensitiveParameters(node *ast.Node) bool {
+	return hasContextSensitiveParameters(node)
+}
+
 func (c *Checker) GetResolvedSignature(node *ast.Node) *Signature {
 	return c.getResolvedSignature(node, nil, CheckModeNormal)
 }
@@ -170,3 +182,27 @@ func (c *Checker) GetIndexSignaturesAtLocation(node *ast.Node) []*ast.Node {
 func (c *Checker) GetResolvedSymbol(node *ast.Node) *ast.Symbol {
 	return c.getResolvedSymbol(node)
 }
+
+func (c *Checker) GetBaseTypeOfLiteralType(t *Type) *Type {
+	return c.getBaseTypeOfLiteralType(t)
+}
+
+func (c *Checker) IsReachableFlowNode(flow *ast.FlowNode) bool {
+	return c.isReachableFlowNode(flow)
+}
+
+func (c *Checker) GetNumberType() *Type {
+	return c.numberType
+}
+
+func (c *Checker) GetWidenedType(t *Type) *Type {
+	return c.getWidenedType(t)
+}
+
+func (c *Checker) GetIndexInfoOfType(t *Type, keyType *Type) *IndexInfo {
+	return c.getIndexInfoOfType(t, keyType)
+}
+
+func (c *Checker) CreateArrayType(elementType *Type) *Type {
+	return c.createArrayType(elementType)
+}
@@ -77,6 +77,12 @@ func (b *NodeBuilder) exitContextCheck() {
 	}
 }
 
+// SymbolOfIdentifier returns the symbol that an identifier of an entity name created by the node builder refers
+// to, or nil if the node builder did not create the identifier for a symbol.
+func (b *NodeBuilder) SymbolOfIdentifier(identifier *ast.Node) *ast.Symbol {
+	return b.impl.identifierSymbols[identifier]
+}
+
 // IndexInfoToIndexSignatureDeclaration implements NodeBuilderInterface.
 func (b *NodeBuilder) IndexInfoToIndexSignatureDeclaration(info *IndexInfo, enclosingDeclaration *ast.Node, flags nodebuilder.Flags, internalFlags nodebuilder.InternalFlags, tracker nodebuilder.SymbolTracker) *ast.Node {
 	b.enterContext(enclosingDeclaration, flags, internalFlags, tracker)
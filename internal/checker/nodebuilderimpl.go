@@ -95,6 +95,8 @@ type nodeBuilderImpl struct {
 
 	// state
 	ctx *NodeBuilderContext
+	// identifierSymbols are the symbols that the identifiers of created entity names refer to
+	identifierSymbols map[*ast.Node]*ast.Symbol
 
 	// reusable visitor
 	cloneBindingNameVisitor *ast.NodeVisitor
@@ -486,7 +488,7 @@ func (b *nodeBuilderImpl) createEntityNameFromSymbolChain(chain []*ast.Symbol, i
 	b.e.AddEmitFlags(identifier, printer.EFNoAsciiEscaping)
 	// !!! TODO: smuggle type arguments out
 	// if (typeParameterNodes) setIdentifierTypeArguments(identifier, factory.createNodeArray<TypeNode | TypeParameterDeclaration>(typeParameterNodes));
-	// identifier.symbol = symbol;
+	b.setIdentifierSymbol(identifier, symbol)
 	// expression = identifier;
 	if index > 0 {
 		return b.f.NewQualifiedName(
@@ -705,7 +707,7 @@ func (b *nodeBuilderImpl) createAccessFromSymbolChain(chain []*ast.Symbol, index
 	b.e.AddEmitFlags(identifier, printer.EFNoAsciiEscaping)
 	// !!! TODO: smuggle type arguments out
 	// if (typeParameterNodes) setIdentifierTypeArguments(identifier, factory.createNodeArray<TypeNode | TypeParameterDeclaration>(typeParameterNodes));
-	// identifier.symbol = symbol;
+	b.setIdentifierSymbol(identifier, symbol)
 
 	if index > stopper {
 		lhs := b.createAccessFromSymbolChain(chain, index-1, stopper, overrideTypeArguments)
@@ -718,6 +720,13 @@ func (b *nodeBuilderImpl) createAccessFromSymbolChain(chain []*ast.Symbol, index
 	return identifier
 }
 
+func (b *nodeBuilderImpl) setIdentifierSymbol(identifier *ast.Node, symbol *ast.Symbol) {
+	if b.identifierSymbols == nil {
+		b.identifierSymbols = make(map[*ast.Node]*ast.Symbol)
+	}
+	b.identifierSymbols[identifier] = symbol
+}
+
 func (b *nodeBuilderImpl) symbolToExpression(symbol *ast.Symbol, mask ast.SymbolFlags) *ast.Expression {
 	chain := b.lookupSymbolChain(symbol, mask, false)
 	return b.createExpressionFromSymbolChain(chain, len(chain)-1)
@@ -744,7 +753,7 @@ func (b *nodeBuilderImpl) createExpressionFromSymbolChain(chain []*ast.Symbol, i
 		b.e.AddEmitFlags(identifier, printer.EFNoAsciiEscaping)
 		// !!! TODO: smuggle type arguments out
 		// if (typeParameterNodes) setIdentifierTypeArguments(identifier, factory.createNodeArray<TypeNode | TypeParameterDeclaration>(typeParameterNodes));
-		// identifier.symbol = symbol;
+		b.setIdentifierSymbol(identifier, symbol)
 		if index > 0 {
 			return b.f.NewPropertyAccessExpression(b.createExpressionFromSymbolChain(chain, index-1), nil, identifier, ast.NodeFlagsNone)
 		}
@@ -768,7 +777,7 @@ func (b *nodeBuilderImpl) createExpressionFromSymbolChain(chain []*ast.Symbol, i
 		b.e.AddEmitFlags(expression, printer.EFNoAsciiEscaping)
 		// !!! TODO: smuggle type arguments out
 		// if (typeParameterNodes) setIdentifierTypeArguments(identifier, factory.createNodeArray<TypeNode | TypeParameterDeclaration>(typeParameterNodes));
-		// identifier.symbol = symbol;
+		b.setIdentifierSymbol(expression, symbol)
 		// expression = identifier;
 	}
 	return b.f.NewElementAccessExpression(b.createExpressionFromSymbolChain(chain, index-1), nil, expression, ast.NodeFlagsNone)
@@ -2892,7 +2901,10 @@ func (b *nodeBuilderImpl) typeToTypeNode(t *Type) *ast.TypeNode {
 	}
 	if t.flags&TypeFlagsStringLiteral != 0 {
 		b.ctx.approximateLength += len(t.AsLiteralType().value.(string)) + 2
-		lit := b.f.NewStringLiteral(t.AsLiteralType().value.(string) /*, b.flags&nodebuilder.FlagsUseSingleQuotesForStringLiteralType != 0*/)
+		lit := b.f.NewStringLiteral(t.AsLiteralType().value.(string))
+		if b.ctx.flags&nodebuilder.FlagsUseSingleQuotesForStringLiteralType != 0 {
+			lit.AsStringLiteral().TokenFlags |= ast.TokenFlagsSingleQuote
+		}
 		b.e.AddEmitFlags(lit, printer.EFNoAsciiEscaping)
 		return b.f.NewLiteralTypeNode(lit)
 	}
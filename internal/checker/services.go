@@ -809,3 +809,73 @@ func (c *Checker) getTypeOfAssignmentPattern(expr *ast.Node) *Type {
 	elementType := core.OrElse(c.checkIteratedTypeOrElementType(IterationUseDestructuring, typeOfArrayLiteral, c.undefinedType, expr.Parent), c.errorType)
 	return c.checkArrayLiteralDestructuringElementAssignment(node, typeOfArrayLiteral, slices.Index(node.AsArrayLiteralExpression().Elements.Nodes, expr), elementType, CheckModeNormal)
 }
+
+type ParameterIdentifierInfo struct {
+	Parameter       *ast.Node // Identifier naming the parameter or the labeled tuple element
+	ParameterName   string
+	IsRestParameter bool
+}
+
+// GetParameterIdentifierInfoAtPosition returns the name of the parameter that an argument at the given position
+// binds to, including labeled elements of rest tuple parameters.
+func (c *Checker) GetParameterIdentifierInfoAtPosition(signature *Signature, pos int) *ParameterIdentifierInfo {
+	paramCount := len(signature.parameters)
+	if signatureHasRestParameter(signature) {
+		paramCount--
+	}
+	if pos < paramCount {
+		param := signature.parameters[pos]
+		if paramIdent := getParameterDeclarationIdentifier(param); paramIdent != nil {
+			return &ParameterIdentifierInfo{Parameter: paramIdent, ParameterName: param.Name}
+		}
+		return nil
+	}
+	if paramCount >= len(signature.parameters) {
+		return nil
+	}
+	restParameter := signature.parameters[paramCount]
+	paramIdent := getParameterDeclarationIdentifier(restParameter)
+	if paramIdent == nil {
+		return nil
+	}
+	restType := c.getTypeOfSymbol(restParameter)
+	if isTupleType(restType) {
+		elementInfos := restType.TargetTupleType().elementInfos
+		index := pos - paramCount
+		if index >= len(elementInfos) {
+			return nil
+		}
+		associatedName := elementInfos[index].labeledDeclaration
+		if associatedName == nil || !ast.IsIdentifier(associatedName.Name()) {
+			return nil
+		}
+		isRestTupleElement := ast.IsNamedTupleMember(associatedName) && associatedName.AsNamedTupleMember().DotDotDotToken != nil ||
+			ast.IsParameter(associatedName) && associatedName.AsParameterDeclaration().DotDotDotToken != nil
+		return &ParameterIdentifierInfo{Parameter: associatedName.Name(), ParameterName: associatedName.Name().Text(), IsRestParameter: isRestTupleElement}
+	}
+	if pos == paramCount {
+		return &ParameterIdentifierInfo{Parameter: paramIdent, ParameterName: restParameter.Name, IsRestParameter: true}
+	}
+	return nil
+}
+
+func getParameterDeclarationIdentifier(symbol *ast.Symbol) *ast.Node {
+	if symbol.ValueDeclaration != nil && ast.IsParameter(symbol.ValueDeclaration) && ast.IsIdentifier(symbol.ValueDeclaration.Name()) {
+		return symbol.ValueDeclaration.Name()
+	}
+	return nil
+}
+
+// GetJsxNamespace returns the name of the namespace JSX elements at the location are created with, e.g. `React`.
+func (c *Checker) GetJsxNamespace(location *ast.Node) string {
+	return c.getJsxNamespace(location)
+}
+
+// GetJsxFragmentFactory returns the first identifier of the factory JSX fragments at the location are created
+// with, or "" if there is none.
+func (c *Checker) GetJsxFragmentFactory(location *ast.Node) string {
+	if entity := c.getJsxFragmentFactoryEntity(location); entity != nil {
+		return ast.GetFirstIdentifier(entity).Text()
+	}
+	return ""
+}
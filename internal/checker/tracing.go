@@ -0,0 +1,133 @@
+package checker
+
+import (
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tracing"
+)
+
+var typeFlagNames = []struct {
+	flag TypeFlags
+	name string
+}{
+	{TypeFlagsAny, "Any"},
+	{TypeFlagsUnknown, "Unknown"},
+	{TypeFlagsUndefined, "Undefined"},
+	{TypeFlagsNull, "Null"},
+	{TypeFlagsVoid, "Void"},
+	{TypeFlagsString, "String"},
+	{TypeFlagsNumber, "Number"},
+	{TypeFlagsBigInt, "BigInt"},
+	{TypeFlagsBoolean, "Boolean"},
+	{TypeFlagsESSymbol, "ESSymbol"},
+	{TypeFlagsStringLiteral, "StringLiteral"},
+	{TypeFlagsNumberLiteral, "NumberLiteral"},
+	{TypeFlagsBigIntLiteral, "BigIntLiteral"},
+	{TypeFlagsBooleanLiteral, "BooleanLiteral"},
+	{TypeFlagsUniqueESSymbol, "UniqueESSymbol"},
+	{TypeFlagsEnumLiteral, "EnumLiteral"},
+	{TypeFlagsEnum, "Enum"},
+	{TypeFlagsNonPrimitive, "NonPrimitive"},
+	{TypeFlagsNever, "Never"},
+	{TypeFlagsTypeParameter, "TypeParameter"},
+	{TypeFlagsObject, "Object"},
+	{TypeFlagsIndex, "Index"},
+	{TypeFlagsTemplateLiteral, "TemplateLiteral"},
+	{TypeFlagsStringMapping, "StringMapping"},
+	{TypeFlagsSubstitution, "Substitution"},
+	{TypeFlagsIndexedAccess, "IndexedAccess"},
+	{TypeFlagsConditional, "Conditional"},
+	{TypeFlagsUnion, "Union"},
+	{TypeFlagsIntersection, "Intersection"},
+}
+
+func formatTypeFlags(flags TypeFlags) []string {
+	names := []string{}
+	for _, entry := range typeFlagNames {
+		if flags&entry.flag != 0 {
+			names = append(names, entry.name)
+		}
+	}
+	return names
+}
+
+func typeIds(types []*Type) []uint32 {
+	ids := make([]uint32, len(types))
+	for i, t := range types {
+		ids[i] = uint32(t.id)
+	}
+	return ids
+}
+
+// describeTracedTypes describes the types the checker created while tracing, for types.json.
+func (c *Checker) describeTracedTypes() []*tracing.TypeDescriptor {
+	// Describing a type can create more types, which are not described.
+	types := c.tracedTypes[:len(c.tracedTypes):len(c.tracedTypes)]
+	descriptors := make([]*tracing.TypeDescriptor, 0, len(types))
+	for _, t := range types {
+		descriptors = append(descriptors, c.describeType(t))
+	}
+	return descriptors
+}
+
+func (c *Checker) describeType(t *Type) *tracing.TypeDescriptor {
+	descriptor := &tracing.TypeDescriptor{
+		ID:    uint32(t.id),
+		Flags: formatTypeFlags(t.flags),
+	}
+	if intrinsic, ok := t.data.(*IntrinsicType); ok {
+		descriptor.IntrinsicName = intrinsic.intrinsicName
+	}
+	symbol := t.symbol
+	if t.alias != nil {
+		symbol = t.alias.symbol
+		descriptor.AliasTypeArguments = typeIds(t.alias.typeArguments)
+	}
+	if symbol != nil {
+		descriptor.SymbolName = ast.SymbolName(symbol)
+		if name, ok := strings.CutPrefix(descriptor.SymbolName, ast.InternalSymbolNamePrefix); ok {
+			// Internal names are written as in TypeScript, e.g. `__type`, as the prefix is not valid UTF-8.
+			descriptor.SymbolName = "__" + name
+		}
+		if len(symbol.Declarations) > 0 {
+			descriptor.FirstDeclaration = describeDeclaration(symbol.Declarations[0])
+		}
+	}
+	descriptor.IsTuple = isTupleType(t)
+	switch {
+	case t.flags&TypeFlagsUnion != 0:
+		descriptor.UnionTypes = typeIds(t.Types())
+	case t.flags&TypeFlagsIntersection != 0:
+		descriptor.IntersectionTypes = typeIds(t.Types())
+	case t.flags&TypeFlagsIndex != 0:
+		descriptor.KeyofType = uint32(t.AsIndexType().target.id)
+	case t.flags&TypeFlagsIndexedAccess != 0:
+		descriptor.IndexedAccessObjectType = uint32(t.AsIndexedAccessType().objectType.id)
+		descriptor.IndexedAccessIndexType = uint32(t.AsIndexedAccessType().indexType.id)
+	case t.flags&TypeFlagsConditional != 0:
+		descriptor.ConditionalCheckType = uint32(t.AsConditionalType().checkType.id)
+		descriptor.ConditionalExtendsType = uint32(t.AsConditionalType().extendsType.id)
+	case t.flags&TypeFlagsSubstitution != 0:
+		descriptor.SubstitutionBaseType = uint32(t.AsSubstitutionType().baseType.id)
+	}
+	if t.objectFlags&ObjectFlagsAnonymous != 0 || t.flags&TypeFlagsLiteral != 0 {
+		descriptor.Display = c.TypeToString(t)
+	}
+	return descriptor
+}
+
+func describeDeclaration(declaration *ast.Node) *tracing.DeclarationDescriptor {
+	file := ast.GetSourceFileOfNode(declaration)
+	if file == nil {
+		return nil
+	}
+	startLine, startCharacter := scanner.GetECMALineAndCharacterOfPosition(file, declaration.Pos())
+	endLine, endCharacter := scanner.GetECMALineAndCharacterOfPosition(file, declaration.End())
+	return &tracing.DeclarationDescriptor{
+		Path:  file.FileName(),
+		Start: tracing.Position{Line: startLine + 1, Character: startCharacter + 1},
+		End:   tracing.Position{Line: endLine + 1, Character: endCharacter + 1},
+	}
+}
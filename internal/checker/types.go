@@ -1167,6 +1167,10 @@ func (s *Signature) Parameters() []*ast.Symbol {
 	return s.parameters
 }
 
+func (s *Signature) MinArgumentCount() int {
+	return int(s.minArgumentCount)
+}
+
 func (s *Signature) HasRestParameter() bool {
 	return s.flags&SignatureFlagsHasRestParameter != 0
 }
@@ -1192,6 +1196,8 @@ type TypePredicate struct {
 	t              *Type
 }
 
+func (t *TypePredicate) Type() *Type { return t.t }
+
 // IndexInfo
 
 type IndexInfo struct {
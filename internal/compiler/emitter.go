@@ -11,6 +11,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/sourcemap"
 	"github.com/microsoft/typescript-go/internal/stringutil"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/transformers"
 	"github.com/microsoft/typescript-go/internal/transformers/declarations"
 	"github.com/microsoft/typescript-go/internal/transformers/estransforms"
@@ -40,12 +41,20 @@ type emitter struct {
 	sourceFile         *ast.SourceFile
 	emitResult         EmitResult
 	writeFile          func(fileName string, text string, writeByteOrderMark bool, data *WriteFileData) error
+	tracer             *tracing.Tracer
 }
 
 func (e *emitter) emit() {
-	// !!! tracing
-	e.emitJSFile(e.sourceFile, e.paths.JsFilePath(), e.paths.SourceMapFilePath())
-	e.emitDeclarationFile(e.sourceFile, e.paths.DeclarationFilePath(), e.paths.DeclarationMapPath())
+	if jsFilePath := e.paths.JsFilePath(); jsFilePath != "" {
+		span := e.tracer.Begin(tracing.PhaseEmit, "emitJsFileOrBundle", tracing.Args{"jsFilePath": jsFilePath})
+		e.emitJSFile(e.sourceFile, jsFilePath, e.paths.SourceMapFilePath())
+		span.End()
+	}
+	if declarationFilePath := e.paths.DeclarationFilePath(); declarationFilePath != "" {
+		span := e.tracer.Begin(tracing.PhaseEmit, "emitDeclarationFileOrBundle", tracing.Args{"declarationFilePath": declarationFilePath})
+		e.emitDeclarationFile(e.sourceFile, declarationFilePath, e.paths.DeclarationMapPath())
+		span.End()
+	}
 	e.emitResult.Diagnostics = e.emitterDiagnostics.GetDiagnostics()
 }
 
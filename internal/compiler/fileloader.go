@@ -11,6 +11,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/module"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
@@ -419,6 +420,8 @@ func (p *fileLoader) loadSourceFileMetaData(fileName string) ast.SourceFileMetaD
 func (p *fileLoader) parseSourceFile(t *parseTask) *ast.SourceFile {
 	path := p.toPath(t.normalizedFilePath)
 	options := p.projectReferenceFileMapper.getCompilerOptionsForFile(t)
+	span := p.opts.Tracer.Begin(tracing.PhaseParse, "createSourceFile", tracing.Args{"path": t.normalizedFilePath})
+	defer span.End()
 	sourceFile := p.opts.Host.GetSourceFile(ast.SourceFileParseOptions{
 		FileName:                       t.normalizedFilePath,
 		Path:                           path,
@@ -454,6 +457,8 @@ func (p *fileLoader) resolveTypeReferenceDirectives(t *parseTask) {
 		return
 	}
 	meta := t.metadata
+	span := p.opts.Tracer.BeginSampled(tracing.PhaseProgram, "resolveTypeReferenceDirectiveNamesWorker", tracing.Args{"containingFileName": file.FileName()})
+	defer span.End()
 
 	typeResolutionsInFile := make(module.ModeAwareCache[*module.ResolvedTypeReferenceDirective], len(file.TypeReferenceDirectives))
 	var typeResolutionsTrace []string
@@ -533,6 +538,8 @@ func (p *fileLoader) resolveImportsAndModuleAugmentations(t *parseTask) {
 	}
 
 	if len(moduleNames) != 0 {
+		span := p.opts.Tracer.BeginSampled(tracing.PhaseProgram, "resolveModuleNamesWorker", tracing.Args{"containingFileName": file.FileName()})
+		defer span.End()
 		resolutionsInFile := make(module.ModeAwareCache[*module.ResolvedModule], len(moduleNames))
 		var resolutionsTrace []string
 
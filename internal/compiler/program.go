@@ -23,6 +23,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/printer"
 	"github.com/microsoft/typescript-go/internal/scanner"
 	"github.com/microsoft/typescript-go/internal/sourcemap"
+	"github.com/microsoft/typescript-go/internal/tracing"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
@@ -36,6 +37,8 @@ type ProgramOptions struct {
 	TypingsLocation             string
 	ProjectName                 string
 	JSDocParsingMode            ast.JSDocParsingMode
+	// Tracer records the parsing, binding, checking and emitting of the files for --generateTrace, if non-nil.
+	Tracer *tracing.Tracer
 }
 
 func (p *ProgramOptions) canUseProjectReferenceSource() bool {
@@ -208,7 +211,9 @@ func (p *Program) GetSourceFileFromReference(origin *ast.SourceFile, ref *ast.Fi
 func NewProgram(opts ProgramOptions) *Program {
 	p := &Program{opts: opts}
 	p.initCheckerPool()
+	span := opts.Tracer.Begin(tracing.PhaseProgram, "createProgram", tracing.Args{"configFilePath": opts.Config.CompilerOptions().ConfigFilePath, "rootDir": opts.Host.GetCurrentDirectory()})
 	p.processedFiles = processAllProgramFiles(p.opts, p.SingleThreaded())
+	span.End()
 	p.verifyCompilerOptions()
 	return p
 }
@@ -284,6 +289,7 @@ func (p *Program) SourceFiles() []*ast.SourceFile            { return p.files }
 func (p *Program) Options() *core.CompilerOptions            { return p.opts.Config.CompilerOptions() }
 func (p *Program) CommandLine() *tsoptions.ParsedCommandLine { return p.opts.Config }
 func (p *Program) Host() CompilerHost                        { return p.opts.Host }
+func (p *Program) Tracer() *tracing.Tracer                   { return p.opts.Tracer }
 func (p *Program) GetConfigFileParsingDiagnostics() []*ast.Diagnostic {
 	return slices.Clip(p.opts.Config.GetConfigFileParsingDiagnostics())
 }
@@ -337,13 +343,21 @@ func (p *Program) BindSourceFiles() {
 	for _, file := range p.files {
 		if !file.IsBound() {
 			wg.Queue(func() {
-				binder.BindSourceFile(file)
+				p.bindSourceFile(file)
 			})
 		}
 	}
 	wg.RunAndWait()
 }
 
+func (p *Program) bindSourceFile(file *ast.SourceFile) {
+	if !file.IsBound() {
+		span := p.opts.Tracer.Begin(tracing.PhaseBind, "bindSourceFile", tracing.Args{"path": file.FileName()})
+		binder.BindSourceFile(file)
+		span.End()
+	}
+}
+
 func (p *Program) CheckSourceFiles(ctx context.Context, files []*ast.SourceFile) {
 	wg := core.NewWorkGroup(p.SingleThreaded())
 	checkers, done := p.checkerPool.GetAllCheckers(ctx)
@@ -1202,7 +1216,7 @@ func compactAndMergeRelatedInfos(diagnostics []*ast.Diagnostic) []*ast.Diagnosti
 func (p *Program) getDiagnosticsHelper(ctx context.Context, sourceFile *ast.SourceFile, ensureBound bool, ensureChecked bool, getDiagnostics func(context.Context, *ast.SourceFile) []*ast.Diagnostic) []*ast.Diagnostic {
 	if sourceFile != nil {
 		if ensureBound {
-			binder.BindSourceFile(sourceFile)
+			p.bindSourceFile(sourceFile)
 		}
 		return SortAndDeduplicateDiagnostics(getDiagnostics(ctx, sourceFile))
 	}
@@ -1384,6 +1398,7 @@ func (p *Program) Emit(ctx context.Context, options EmitOptions) *EmitResult {
 			sourceFile: sourceFile,
 			emitOnly:   options.EmitOnly,
 			writeFile:  options.WriteFile,
+			tracer:     p.opts.Tracer,
 		}
 		emitters = append(emitters, emitter)
 		wg.Queue(func() {
@@ -38,6 +38,10 @@ type Message struct {
 	reportsUnnecessary           bool
 	elidedInCompatibilityPyramid bool
 	reportsDeprecated            bool
+
+	// template and args are set on messages created by FormatMessage so that they can be localized.
+	template string
+	args     []any
 }
 
 func (m *Message) Code() int32                        { return m.code }
@@ -59,5 +63,7 @@ func (m *Message) Format(args ...any) string {
 func FormatMessage(m *Message, args ...any) *Message {
 	result := *m
 	result.text = stringutil.Format(m.text, args)
+	result.template = m.text
+	result.args = args
 	return &result
 }
@@ -4253,3 +4253,7 @@ var Do_not_print_diagnostics = &Message{code: 100000, category: CategoryMessage,
 var Run_in_single_threaded_mode = &Message{code: 100001, category: CategoryMessage, key: "Run_in_single_threaded_mode_100001", text: "Run in single threaded mode."}
 
 var Generate_pprof_CPU_Slashmemory_profiles_to_the_given_directory = &Message{code: 100002, category: CategoryMessage, key: "Generate_pprof_CPU_Slashmemory_profiles_to_the_given_directory_100002", text: "Generate pprof CPU/memory profiles to the given directory."}
+
+var No_translations_of_diagnostic_messages_were_found_for_locale_0 = &Message{code: 100003, category: CategoryWarning, key: "No_translations_of_diagnostic_messages_were_found_for_locale_0_100003", text: "No translations of diagnostic messages were found for locale '{0}'."}
+
+var Could_not_start_CPU_profiling_Colon_0 = &Message{code: 100004, category: CategoryError, key: "Could_not_start_CPU_profiling_Colon_0_100004", text: "Could not start CPU profiling: {0}."}
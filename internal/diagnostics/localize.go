@@ -0,0 +1,36 @@
+package diagnostics
+
+import (
+	"sync"
+
+	"github.com/microsoft/typescript-go/internal/stringutil"
+	"golang.org/x/text/language"
+)
+
+// localizedMessages maps a locale to its translated message texts, keyed by message key.
+var localizedMessages sync.Map // map[language.Tag]map[string]string
+
+// SetLocalizedMessages sets the translated texts of the messages for a locale, keyed by message key as in the
+// diagnosticMessages.generated.json files of the TypeScript package.
+func SetLocalizedMessages(locale language.Tag, messages map[string]string) {
+	localizedMessages.Store(locale, messages)
+}
+
+// Localize returns the text of a message in a locale, or in English if there is no translation for it.
+func (m *Message) Localize(locale language.Tag, args ...any) string {
+	text := m.text
+	if m.args != nil {
+		// The message was formatted with FormatMessage, so the translation is formatted with the same arguments.
+		text = m.template
+		args = m.args
+	}
+	if messages, ok := localizedMessages.Load(locale); ok {
+		if translated, ok := messages.(map[string]string)[m.key]; ok {
+			text = translated
+		}
+	}
+	if len(args) != 0 {
+		text = stringutil.Format(text, args)
+	}
+	return text
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/microsoft/typescript-go/internal/execute/tsc"
 	"github.com/microsoft/typescript-go/internal/format"
 	"github.com/microsoft/typescript-go/internal/jsonutil"
+	"github.com/microsoft/typescript-go/internal/localization"
 	"github.com/microsoft/typescript-go/internal/parser"
 	"github.com/microsoft/typescript-go/internal/pprof"
 	"github.com/microsoft/typescript-go/internal/tsoptions"
@@ -60,12 +61,51 @@ func fmtMain(sys tsc.System, input, output string) tsc.ExitStatus {
 	return tsc.ExitStatusSuccess
 }
 
-func tscBuildCompilation(sys tsc.System, buildCommand *tsoptions.ParsedBuildCommandLine, testing tsc.CommandLineTesting) tsc.CommandLineResult {
+// setLanguage loads the translated messages of a locale. It reports the warnings about the locale, like missing
+// translations, and returns the errors.
+func setLanguage(sys tsc.System, locale string, reportDiagnostic tsc.DiagnosticReporter) []*ast.Diagnostic {
+	var errors []*ast.Diagnostic
+	for _, diagnostic := range localization.SetLanguage(locale, sys.FS(), sys.DefaultLibraryPath()) {
+		if diagnostic.Category() == diagnostics.CategoryError {
+			errors = append(errors, diagnostic)
+		} else {
+			reportDiagnostic(diagnostic)
+		}
+	}
+	return errors
+}
+
+// beginCPUProfiling starts recording the CPU profile of --generateCpuProfile, and returns a function that stops
+// recording and writes the profile. Failures to record or write the profile are reported, and turn a successful
+// result into one with diagnostics, so the function is meant to be deferred by a function with a named result.
+func beginCPUProfiling(sys tsc.System, cpuProfile string, reportDiagnostic tsc.DiagnosticReporter) (stop func(result *tsc.CommandLineResult)) {
+	reportFailure := func(result *tsc.CommandLineResult, diagnostic *ast.Diagnostic) {
+		reportDiagnostic(diagnostic)
+		if result.Status == tsc.ExitStatusSuccess {
+			result.Status = tsc.ExitStatusDiagnosticsPresent_OutputsGenerated
+		}
+	}
+	filePath := tspath.GetNormalizedAbsolutePath(cpuProfile, sys.GetCurrentDirectory())
+	profileSession, err := pprof.BeginCPUProfiling(sys.FS(), filePath, sys.Writer())
+	if err != nil {
+		diagnostic := ast.NewCompilerDiagnostic(diagnostics.Could_not_start_CPU_profiling_Colon_0, err.Error())
+		return func(result *tsc.CommandLineResult) {
+			reportFailure(result, diagnostic)
+		}
+	}
+	return func(result *tsc.CommandLineResult) {
+		if err := profileSession.Stop(); err != nil {
+			reportFailure(result, ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, filePath, err.Error()))
+		}
+	}
+}
+
+func tscBuildCompilation(sys tsc.System, buildCommand *tsoptions.ParsedBuildCommandLine, testing tsc.CommandLineTesting) (result tsc.CommandLineResult) {
 	reportDiagnostic := tsc.CreateDiagnosticReporter(sys, sys.Writer(), buildCommand.CompilerOptions)
 
-	// if (buildOptions.locale) {
-	//     validateLocaleAndSetLanguage(buildOptions.locale, sys, errors);
-	// }
+	if locale := buildCommand.CompilerOptions.Locale; locale != "" {
+		buildCommand.Errors = append(buildCommand.Errors, setLanguage(sys, locale, reportDiagnostic)...)
+	}
 
 	if len(buildCommand.Errors) > 0 {
 		for _, err := range buildCommand.Errors {
@@ -74,10 +114,20 @@ func tscBuildCompilation(sys tsc.System, buildCommand *tsoptions.ParsedBuildComm
 		return tsc.CommandLineResult{Status: tsc.ExitStatusDiagnosticsPresent_OutputsSkipped}
 	}
 
+	if buildCommand.CompilerOptions.Watch.IsTrue() && buildCommand.CompilerOptions.GenerateCpuProfile != "" {
+		// The profile is written when the command returns, which is before the files are watched.
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "watch", "generateCpuProfile"))
+		return tsc.CommandLineResult{Status: tsc.ExitStatusDiagnosticsPresent_OutputsSkipped}
+	}
+
 	if pprofDir := buildCommand.CompilerOptions.PprofDir; pprofDir != "" {
 		// !!! stderr?
 		profileSession := pprof.BeginProfiling(pprofDir, sys.Writer())
 		defer profileSession.Stop()
+	} else if cpuProfile := buildCommand.CompilerOptions.GenerateCpuProfile; cpuProfile != "" {
+		// Only one CPU profile can be recorded at a time, so --pprofDir wins.
+		stopCPUProfiling := beginCPUProfiling(sys, cpuProfile, reportDiagnostic)
+		defer func() { stopCPUProfiling(&result) }()
 	}
 
 	if buildCommand.CompilerOptions.Help.IsTrue() {
@@ -94,10 +144,12 @@ func tscBuildCompilation(sys tsc.System, buildCommand *tsoptions.ParsedBuildComm
 	return orchestrator.Start()
 }
 
-func tscCompilation(sys tsc.System, commandLine *tsoptions.ParsedCommandLine, testing tsc.CommandLineTesting) tsc.CommandLineResult {
+func tscCompilation(sys tsc.System, commandLine *tsoptions.ParsedCommandLine, testing tsc.CommandLineTesting) (result tsc.CommandLineResult) {
 	configFileName := ""
 	reportDiagnostic := tsc.CreateDiagnosticReporter(sys, sys.Writer(), commandLine.CompilerOptions())
-	// if commandLine.Options().Locale != nil
+	if locale := commandLine.CompilerOptions().Locale; locale != "" {
+		commandLine.Errors = append(commandLine.Errors, setLanguage(sys, locale, reportDiagnostic)...)
+	}
 
 	if len(commandLine.Errors) > 0 {
 		for _, e := range commandLine.Errors {
@@ -106,14 +158,25 @@ func tscCompilation(sys tsc.System, commandLine *tsoptions.ParsedCommandLine, te
 		return tsc.CommandLineResult{Status: tsc.ExitStatusDiagnosticsPresent_OutputsSkipped}
 	}
 
+	if commandLine.CompilerOptions().Watch.IsTrue() && commandLine.CompilerOptions().GenerateCpuProfile != "" {
+		// The profile is written when the command returns, which is before the files are watched.
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "watch", "generateCpuProfile"))
+		return tsc.CommandLineResult{Status: tsc.ExitStatusDiagnosticsPresent_OutputsSkipped}
+	}
+
 	if pprofDir := commandLine.CompilerOptions().PprofDir; pprofDir != "" {
 		// !!! stderr?
 		profileSession := pprof.BeginProfiling(pprofDir, sys.Writer())
 		defer profileSession.Stop()
+	} else if cpuProfile := commandLine.CompilerOptions().GenerateCpuProfile; cpuProfile != "" {
+		// Only one CPU profile can be recorded at a time, so --pprofDir wins.
+		stopCPUProfiling := beginCPUProfiling(sys, cpuProfile, reportDiagnostic)
+		defer func() { stopCPUProfiling(&result) }()
 	}
 
 	if commandLine.CompilerOptions().Init.IsTrue() {
-		return tsc.CommandLineResult{Status: tsc.ExitStatusNotImplemented}
+		tsc.WriteConfigFile(sys, reportDiagnostic, commandLine.CompilerOptions(), commandLine.FileNames())
+		return tsc.CommandLineResult{Status: tsc.ExitStatusSuccess}
 	}
 
 	if commandLine.CompilerOptions().Version.IsTrue() {
@@ -189,7 +252,7 @@ func tscCompilation(sys tsc.System, commandLine *tsoptions.ParsedCommandLine, te
 
 	reportErrorSummary := tsc.CreateReportErrorSummary(sys, configForCompilation.CompilerOptions())
 	if compilerOptionsFromCommandLine.ShowConfig.IsTrue() {
-		showConfig(sys, configForCompilation.CompilerOptions())
+		showConfig(sys, configForCompilation, configFileName)
 		return tsc.CommandLineResult{Status: tsc.ExitStatusSuccess}
 	}
 	if configForCompilation.CompilerOptions().Watch.IsTrue() {
@@ -245,16 +308,18 @@ func performIncrementalCompilation(
 	compileTimes *tsc.CompileTimes,
 	testing tsc.CommandLineTesting,
 ) tsc.CommandLineResult {
+	tracer := tsc.StartTracing(sys, config.CompilerOptions())
 	host := compiler.NewCachedFSCompilerHost(sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath(), extendedConfigCache, getTraceFromSys(sys, testing))
 	buildInfoReadStart := sys.Now()
 	oldProgram := incremental.ReadBuildInfoProgram(config, incremental.NewBuildInfoReader(host), host)
 	compileTimes.BuildInfoReadTime = sys.Now().Sub(buildInfoReadStart)
-	// todo: cache, statistics, tracing
+	// todo: cache, statistics
 	parseStart := sys.Now()
 	program := compiler.NewProgram(compiler.ProgramOptions{
 		Config:           config,
 		Host:             host,
 		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+		Tracer:           tracer,
 	})
 	compileTimes.ParseTime = sys.Now().Sub(parseStart)
 	changesComputeStart := sys.Now()
@@ -271,6 +336,7 @@ func performIncrementalCompilation(
 		CompileTimes:       compileTimes,
 		Testing:            testing,
 	})
+	tsc.StopTracing(sys, tracer, config.CompilerOptions(), reportDiagnostic)
 	if testing != nil {
 		testing.OnProgram(incrementalProgram)
 	}
@@ -288,13 +354,15 @@ func performCompilation(
 	compileTimes *tsc.CompileTimes,
 	testing tsc.CommandLineTesting,
 ) tsc.CommandLineResult {
+	tracer := tsc.StartTracing(sys, config.CompilerOptions())
 	host := compiler.NewCachedFSCompilerHost(sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath(), extendedConfigCache, getTraceFromSys(sys, testing))
-	// todo: cache, statistics, tracing
+	// todo: cache, statistics
 	parseStart := sys.Now()
 	program := compiler.NewProgram(compiler.ProgramOptions{
 		Config:           config,
 		Host:             host,
 		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+		Tracer:           tracer,
 	})
 	compileTimes.ParseTime = sys.Now().Sub(parseStart)
 	result, _ := tsc.EmitAndReportStatistics(tsc.EmitInput{
@@ -308,12 +376,21 @@ func performCompilation(
 		CompileTimes:       compileTimes,
 		Testing:            testing,
 	})
+	tsc.StopTracing(sys, tracer, config.CompilerOptions(), reportDiagnostic)
 	return tsc.CommandLineResult{
 		Status: result.Status,
 	}
 }
 
-func showConfig(sys tsc.System, config *core.CompilerOptions) {
-	// !!!
-	_ = jsonutil.MarshalIndentWrite(sys.Writer(), config, "", "    ")
+func showConfig(sys tsc.System, config *tsoptions.ParsedCommandLine, configFileName string) {
+	if configFileName == "" {
+		// Files given on the command line are shown as if in a tsconfig.json in the current directory.
+		configFileName = tspath.CombinePaths(sys.GetCurrentDirectory(), "tsconfig.json")
+	}
+	tsConfig := tsoptions.ConvertToTSConfig(config, configFileName, tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: sys.FS().UseCaseSensitiveFileNames(),
+		CurrentDirectory:          sys.GetCurrentDirectory(),
+	})
+	output, _ := jsonutil.MarshalIndent(tsConfig, "", "    ")
+	fmt.Fprintln(sys.Writer(), string(output))
 }
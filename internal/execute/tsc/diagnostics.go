@@ -8,17 +8,22 @@ import (
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnosticwriter"
+	"github.com/microsoft/typescript-go/internal/localization"
 	"github.com/microsoft/typescript-go/internal/tspath"
 )
 
-func getFormatOptsOfSys(sys System) *diagnosticwriter.FormattingOptions {
-	return &diagnosticwriter.FormattingOptions{
+func getFormatOptsOfSys(sys System, options *core.CompilerOptions) *diagnosticwriter.FormattingOptions {
+	formatOpts := &diagnosticwriter.FormattingOptions{
 		NewLine: "\n",
 		ComparePathsOptions: tspath.ComparePathsOptions{
 			CurrentDirectory:          sys.GetCurrentDirectory(),
 			UseCaseSensitiveFileNames: sys.FS().UseCaseSensitiveFileNames(),
 		},
 	}
+	if options != nil {
+		formatOpts.Locale = localization.ParseLocale(options.Locale)
+	}
+	return formatOpts
 }
 
 type DiagnosticReporter = func(*ast.Diagnostic)
@@ -29,7 +34,7 @@ func CreateDiagnosticReporter(sys System, w io.Writer, options *core.CompilerOpt
 	if options.Quiet.IsTrue() {
 		return QuietDiagnosticReporter
 	}
-	formatOpts := getFormatOptsOfSys(sys)
+	formatOpts := getFormatOptsOfSys(sys, options)
 	if shouldBePretty(sys, options) {
 		return func(diagnostic *ast.Diagnostic) {
 			diagnosticwriter.FormatDiagnosticWithColorAndContext(w, diagnostic, formatOpts)
@@ -125,7 +130,7 @@ func QuietDiagnosticsReporter(diagnostics []*ast.Diagnostic) {}
 
 func CreateReportErrorSummary(sys System, options *core.CompilerOptions) DiagnosticsReporter {
 	if shouldBePretty(sys, options) {
-		formatOpts := getFormatOptsOfSys(sys)
+		formatOpts := getFormatOptsOfSys(sys, options)
 		return func(diagnostics []*ast.Diagnostic) {
 			diagnosticwriter.WriteErrorSummaryText(sys.Writer(), diagnostics, formatOpts)
 		}
@@ -138,7 +143,7 @@ func CreateBuilderStatusReporter(sys System, w io.Writer, options *core.Compiler
 		return QuietDiagnosticReporter
 	}
 
-	formatOpts := getFormatOptsOfSys(sys)
+	formatOpts := getFormatOptsOfSys(sys, options)
 	writeStatus := core.IfElse(shouldBePretty(sys, options), diagnosticwriter.FormatDiagnosticsStatusWithColorAndTime, diagnosticwriter.FormatDiagnosticsStatusAndTime)
 	return func(diagnostic *ast.Diagnostic) {
 		if testing != nil {
@@ -151,7 +156,7 @@ func CreateBuilderStatusReporter(sys System, w io.Writer, options *core.Compiler
 }
 
 func CreateWatchStatusReporter(sys System, options *core.CompilerOptions, testing CommandLineTesting) DiagnosticReporter {
-	formatOpts := getFormatOptsOfSys(sys)
+	formatOpts := getFormatOptsOfSys(sys, options)
 	writeStatus := core.IfElse(shouldBePretty(sys, options), diagnosticwriter.FormatDiagnosticsStatusWithColorAndTime, diagnosticwriter.FormatDiagnosticsStatusAndTime)
 	return func(diagnostic *ast.Diagnostic) {
 		writer := sys.Writer()
@@ -0,0 +1,28 @@
+package tsc
+
+import (
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/tracing"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// StartTracing starts recording a trace of the compilation if --generateTrace is given, and returns nil otherwise.
+func StartTracing(sys System, options *core.CompilerOptions) *tracing.Tracer {
+	if options.GenerateTrace == "" {
+		return nil
+	}
+	return tracing.NewTracer(sys.Now)
+}
+
+// StopTracing writes the trace of the compilation to the directory given with --generateTrace.
+func StopTracing(sys System, tracer *tracing.Tracer, options *core.CompilerOptions, reportDiagnostic DiagnosticReporter) {
+	if tracer == nil {
+		return
+	}
+	traceDir := tspath.GetNormalizedAbsolutePath(options.GenerateTrace, sys.GetCurrentDirectory())
+	if err := tracer.Write(sys.FS(), traceDir); err != nil {
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, traceDir, err.Error()))
+	}
+}
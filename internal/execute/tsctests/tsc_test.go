@@ -151,6 +151,156 @@ func TestTscCommandline(t *testing.T) {
 			},
 			commandLineArgs: []string{"-p", "."},
 		},
+		{
+			subScenario: "showConfig",
+			files: FileMap{
+				"/home/src/workspaces/project/src/index.ts": `export const a = 1;`,
+				"/home/src/workspaces/project/src/util.ts":  `export const b = 2;`,
+				"/home/src/workspaces/project/lib/tsconfig.json": stringtestutil.Dedent(`
+				{
+					"compilerOptions": {
+						"composite": true
+					}
+				}`),
+				"/home/src/workspaces/project/lib/index.ts": `export const c = 3;`,
+				"/home/src/workspaces/project/tsconfig.json": stringtestutil.Dedent(`
+				{
+					"compilerOptions": {
+						"target": "es2022",
+						"module": "nodenext",
+						"lib": ["es2022", "dom"],
+						"strict": true,
+						"outDir": "./dist",
+						"rootDirs": ["./src", "./generated"],
+						"paths": {
+							"@lib/*": ["./lib/*"]
+						}
+					},
+					"include": ["src"],
+					"exclude": ["src/**/*.test.ts"],
+					"references": [{ "path": "./lib" }],
+					"compileOnSave": true
+				}`),
+			},
+			commandLineArgs: []string{"--showConfig"},
+		},
+		{
+			subScenario: "showConfig with files on command line",
+			files: FileMap{
+				"/home/src/workspaces/project/first.ts": `export const a = 1;`,
+			},
+			commandLineArgs: []string{"--showConfig", "--target", "es2015", "--outDir", "out", "--watchFile", "usefsevents", "first.ts"},
+		},
+		{
+			subScenario: "showConfig with default and implied options",
+			files: FileMap{
+				"/home/src/workspaces/project/tsconfig.json": stringtestutil.Dedent(`
+				{
+					"compilerOptions": {
+						"strict": false,
+						"noEmit": false,
+						"strictNullChecks": true,
+						"composite": true,
+						"module": "nodenext"
+					},
+					"files": ["index.ts"]
+				}`),
+				"/home/src/workspaces/project/index.ts": `export const a = 1;`,
+			},
+			commandLineArgs: []string{"--showConfig"},
+		},
+		{
+			subScenario:     "init",
+			commandLineArgs: []string{"--init"},
+		},
+		{
+			subScenario:     "init with options and files",
+			commandLineArgs: []string{"--init", "--target", "es2020", "--lib", "es2020,dom", "--outDir", "dist", "--noEmit", "--declaration", "--pretty", "false", "first.ts", "second.ts"},
+		},
+		{
+			subScenario:     "init with options that are not written",
+			commandLineArgs: []string{"--init", "--ignoreDeprecations", "6.0"},
+		},
+		{
+			subScenario: "init when tsconfig exists",
+			files: FileMap{
+				"/home/src/workspaces/project/tsconfig.json": `{}`,
+			},
+			commandLineArgs: []string{"--init"},
+		},
+		{
+			subScenario: "locale",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": `const a: number = "hello";`,
+				"/home/src/tslibs/TS/Lib/ja/diagnosticMessages.generated.json": stringtestutil.Dedent(`
+				{
+					"Type_0_is_not_assignable_to_type_1_2322": "型 '{0}' を型 '{1}' に割り当てることはできません。",
+					"Found_1_error_in_0_6259": "{0} に 1 件のエラーが見つかりました"
+				}`),
+			},
+			commandLineArgs: []string{"--locale", "ja-jp", "--pretty", "index.ts"},
+		},
+		{
+			subScenario: "locale without translations",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": `const a: number = "hello";`,
+			},
+			commandLineArgs: []string{"--locale", "fr", "index.ts"},
+		},
+		{
+			subScenario: "locale in English without translations",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": `const a: number = "hello";`,
+			},
+			commandLineArgs: []string{"--locale", "en-gb", "index.ts"},
+		},
+		{
+			subScenario: "locale with corrupted translations",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts":                        `const a: number = 1;`,
+				"/home/src/tslibs/TS/Lib/de/diagnosticMessages.generated.json": `{ "Type_0_is_not_assignable_to_type_1_2322": `,
+			},
+			commandLineArgs: []string{"--locale", "de", "index.ts"},
+		},
+		{
+			subScenario: "generateTrace",
+			files: FileMap{
+				"/home/src/workspaces/project/a.ts": `export const a: { x: number } = { x: 1 };`,
+				"/home/src/workspaces/project/b.ts": stringtestutil.Dedent(`
+				import { a } from "./a";
+				export const b: { x: number; y?: string } = a;`),
+			},
+			commandLineArgs: []string{"--generateTrace", "trace", "--singleThreaded", "b.ts"},
+		},
+		{
+			subScenario: "generateCpuProfile",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": `const a: number = 1;`,
+			},
+			commandLineArgs: []string{"--generateCpuProfile", "profiles/tsc.cpuprofile", "index.ts"},
+		},
+		{
+			subScenario: "generateCpuProfile with watch",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": `const a: number = 1;`,
+			},
+			commandLineArgs: []string{"--generateCpuProfile", "profiles/tsc.cpuprofile", "--watch", "index.ts"},
+		},
+		{
+			subScenario: "generateCpuProfile when the profile cannot be written",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": `const a: number = 1;`,
+				"/home/src/workspaces/project/profiles": `not a directory`,
+			},
+			commandLineArgs: []string{"--generateCpuProfile", "profiles/tsc.cpuprofile", "index.ts"},
+		},
+		{
+			subScenario: "invalid locale",
+			files: FileMap{
+				"/home/src/workspaces/project/index.ts": `const a: number = 1;`,
+			},
+			commandLineArgs: []string{"--locale", "12-34", "index.ts"},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -36,7 +36,7 @@ func WithFormatCodeSettings(ctx context.Context, options *FormatCodeSettings, ne
 }
 
 func GetFormatCodeSettingsFromContext(ctx context.Context) *FormatCodeSettings {
-	opt := ctx.Value(formatOptionsKey).(*FormatCodeSettings)
+	opt, _ := ctx.Value(formatOptionsKey).(*FormatCodeSettings)
 	return opt
 }
 
@@ -45,7 +45,7 @@ func GetNewLineOrDefaultFromContext(ctx context.Context) string { // TODO: Move
 	if opt != nil && len(opt.NewLineCharacter) > 0 {
 		return opt.NewLineCharacter
 	}
-	host := ctx.Value(formatNewlineKey).(string)
+	host, _ := ctx.Value(formatNewlineKey).(string)
 	if len(host) > 0 {
 		return host
 	}
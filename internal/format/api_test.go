@@ -112,3 +112,26 @@ func BenchmarkFormat(b *testing.B) {
 		}
 	})
 }
+
+func TestFormatIndentation(t *testing.T) {
+	t.Parallel()
+
+	ctx := format.WithFormatCodeSettings(t.Context(), format.GetDefaultFormatCodeSettings("\n"), "\n")
+	text := "function f(x: boolean) {\nreturn 1;\nif (x) {\nfoo();\n}\n}"
+	sourceFile := parser.ParseSourceFile(ast.SourceFileParseOptions{
+		FileName: "/a.ts",
+		Path:     "/a.ts",
+	}, text, core.ScriptKindTS)
+
+	t.Run("format document", func(t *testing.T) {
+		t.Parallel()
+		edits := format.FormatDocument(ctx, sourceFile)
+		assert.Equal(t, applyBulkEdits(text, edits), "function f(x: boolean) {\n    return 1;\n    if (x) {\n        foo();\n    }\n}")
+	})
+
+	t.Run("format node given indentation", func(t *testing.T) {
+		t.Parallel()
+		edits := format.FormatNodeGivenIndentation(ctx, sourceFile.Statements.Nodes[0], sourceFile, sourceFile.LanguageVariant, 4, 4)
+		assert.Equal(t, applyBulkEdits(text, edits), "function f(x: boolean) {\n        return 1;\n        if (x) {\n            foo();\n        }\n    }")
+	})
+}
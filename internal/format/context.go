@@ -85,6 +85,44 @@ func GetDefaultFormatCodeSettings(newLineCharacter string) *FormatCodeSettings {
 	}
 }
 
+// Parse reads formatting settings from the `format` object of the `typescript` or `javascript` configuration
+// section of an editor, in the shape used by the VS Code settings. Settings that are absent or have an
+// unexpected type leave the current value unchanged. The indentation settings are not part of that
+// section; they come with each formatting request instead.
+func (settings *FormatCodeSettings) Parse(config map[string]any) {
+	for name, value := range map[string]*core.Tristate{
+		"insertSpaceAfterCommaDelimiter":                              &settings.InsertSpaceAfterCommaDelimiter,
+		"insertSpaceAfterSemicolonInForStatements":                    &settings.InsertSpaceAfterSemicolonInForStatements,
+		"insertSpaceBeforeAndAfterBinaryOperators":                    &settings.InsertSpaceBeforeAndAfterBinaryOperators,
+		"insertSpaceAfterConstructor":                                 &settings.InsertSpaceAfterConstructor,
+		"insertSpaceAfterKeywordsInControlFlowStatements":             &settings.InsertSpaceAfterKeywordsInControlFlowStatements,
+		"insertSpaceAfterFunctionKeywordForAnonymousFunctions":        &settings.InsertSpaceAfterFunctionKeywordForAnonymousFunctions,
+		"insertSpaceAfterOpeningAndBeforeClosingNonemptyParenthesis":  &settings.InsertSpaceAfterOpeningAndBeforeClosingNonemptyParenthesis,
+		"insertSpaceAfterOpeningAndBeforeClosingNonemptyBrackets":     &settings.InsertSpaceAfterOpeningAndBeforeClosingNonemptyBrackets,
+		"insertSpaceAfterOpeningAndBeforeClosingNonemptyBraces":       &settings.InsertSpaceAfterOpeningAndBeforeClosingNonemptyBraces,
+		"insertSpaceAfterOpeningAndBeforeClosingEmptyBraces":          &settings.InsertSpaceAfterOpeningAndBeforeClosingEmptyBraces,
+		"insertSpaceAfterOpeningAndBeforeClosingTemplateStringBraces": &settings.InsertSpaceAfterOpeningAndBeforeClosingTemplateStringBraces,
+		"insertSpaceAfterOpeningAndBeforeClosingJsxExpressionBraces":  &settings.InsertSpaceAfterOpeningAndBeforeClosingJsxExpressionBraces,
+		"insertSpaceAfterTypeAssertion":                               &settings.InsertSpaceAfterTypeAssertion,
+		"insertSpaceBeforeFunctionParenthesis":                        &settings.InsertSpaceBeforeFunctionParenthesis,
+		"placeOpenBraceOnNewLineForFunctions":                         &settings.PlaceOpenBraceOnNewLineForFunctions,
+		"placeOpenBraceOnNewLineForControlBlocks":                     &settings.PlaceOpenBraceOnNewLineForControlBlocks,
+		"insertSpaceBeforeTypeAnnotation":                             &settings.InsertSpaceBeforeTypeAnnotation,
+		"indentMultiLineObjectLiteralBeginningOnBlankLine":            &settings.IndentMultiLineObjectLiteralBeginningOnBlankLine,
+		"indentSwitchCase": &settings.IndentSwitchCase,
+	} {
+		if b, ok := config[name].(bool); ok {
+			*value = core.BoolToTristate(b)
+		}
+	}
+	if semicolons, ok := config["semicolons"].(string); ok {
+		switch SemicolonPreference(semicolons) {
+		case SemicolonPreferenceIgnore, SemicolonPreferenceInsert, SemicolonPreferenceRemove:
+			settings.Semicolons = SemicolonPreference(semicolons)
+		}
+	}
+}
+
 type formattingContext struct {
 	currentTokenSpan   TextRangeWithKind
 	nextTokenSpan      TextRangeWithKind
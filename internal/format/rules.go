@@ -9,12 +9,14 @@ import (
 func getAllRules() []ruleSpec {
 	allTokens := make([]ast.Kind, 0, ast.KindLastToken-ast.KindFirstToken+1)
 	for token := ast.KindFirstToken; token <= ast.KindLastToken; token++ {
-		allTokens = append(allTokens, token)
+		if token != ast.KindEndOfFile {
+			allTokens = append(allTokens, token)
+		}
 	}
 
 	anyTokenExcept := func(tokens ...ast.Kind) tokenRange {
-		newTokens := make([]ast.Kind, 0, ast.KindLastToken-ast.KindFirstToken+1)
-		for token := ast.KindFirstToken; token <= ast.KindLastToken; token++ {
+		newTokens := make([]ast.Kind, 0, len(allTokens))
+		for _, token := range allTokens {
 			if slices.Contains(tokens, token) {
 				continue
 			}
@@ -46,6 +46,13 @@ type FourslashTest struct {
 	lastKnownMarkerName  *string
 	activeFilename       string
 	selectionEnd         *lsproto.Position
+
+	// Preferences the client reports when the server asks for its configuration of TypeScript and
+	// JavaScript files
+	userPreferences           *ls.UserPreferences
+	javaScriptUserPreferences *ls.UserPreferences
+	// Result IDs of the last workspace diagnostics request, sent with the next one
+	workspaceDiagnosticResultIds map[lsproto.DocumentUri]string
 }
 
 type scriptInfo struct {
@@ -192,7 +199,7 @@ func NewFourslash(t *testing.T, capabilities *lsproto.ClientCapabilities, conten
 	// !!! temporary; remove when we have `handleDidChangeConfiguration`/implicit project config support
 	// !!! replace with a proper request *after initialize*
 	f.server.SetCompilerOptionsForInferredProjects(t.Context(), compilerOptions)
-	f.initialize(t, capabilities)
+	f.initialize(t, capabilities, compilerOptions.Locale)
 	for _, file := range testData.Files {
 		f.openFile(t, file.fileName)
 	}
@@ -216,14 +223,20 @@ func (f *FourslashTest) nextID() int32 {
 	return id
 }
 
-func (f *FourslashTest) initialize(t *testing.T, capabilities *lsproto.ClientCapabilities) {
+// initialize initializes the server for a client in the given locale, which is the `@locale` of the test, or
+// "en-US" if the test does not set one.
+func (f *FourslashTest) initialize(t *testing.T, capabilities *lsproto.ClientCapabilities, locale string) {
+	if locale == "" {
+		locale = "en-US"
+	}
 	params := &lsproto.InitializeParams{
-		Locale: ptrTo("en-US"),
+		Locale: &locale,
 	}
 	params.Capabilities = getCapabilitiesWithDefaults(capabilities)
 	// !!! check for errors?
 	sendRequest(t, f, lsproto.InitializeInfo, params)
 	sendNotification(t, f, lsproto.InitializedInfo, &lsproto.InitializedParams{})
+	f.handleConfigurationRequest(t)
 }
 
 var (
@@ -250,6 +263,12 @@ func getCapabilitiesWithDefaults(capabilities *lsproto.ClientCapabilities) *lspr
 	capabilitiesWithDefaults.General = &lsproto.GeneralClientCapabilities{
 		PositionEncodings: &[]lsproto.PositionEncodingKind{lsproto.PositionEncodingKindUTF8},
 	}
+	var workspaceCapabilities lsproto.WorkspaceClientCapabilities
+	if capabilitiesWithDefaults.Workspace != nil {
+		workspaceCapabilities = *capabilitiesWithDefaults.Workspace
+	}
+	workspaceCapabilities.Configuration = ptrTrue
+	capabilitiesWithDefaults.Workspace = &workspaceCapabilities
 	if capabilitiesWithDefaults.TextDocument == nil {
 		capabilitiesWithDefaults.TextDocument = &lsproto.TextDocumentClientCapabilities{}
 	}
@@ -268,6 +287,11 @@ func sendRequest[Params, Resp any](t *testing.T, f *FourslashTest, info lsproto.
 	)
 	f.writeMsg(t, req.Message())
 	resp := f.readMsg(t)
+	// The server may ask the client to do something before responding, e.g. to apply an edit.
+	for resp != nil && resp.Kind == lsproto.MessageKindRequest {
+		f.handleServerRequest(t, resp.AsRequest())
+		resp = f.readMsg(t)
+	}
 	if resp == nil {
 		return nil, *new(Resp), false
 	}
@@ -275,6 +299,125 @@ func sendRequest[Params, Resp any](t *testing.T, f *FourslashTest, info lsproto.
 	return resp, result, ok
 }
 
+func (f *FourslashTest) handleServerRequest(t *testing.T, req *lsproto.RequestMessage) {
+	var result any
+	switch req.Method {
+	case lsproto.MethodWorkspaceApplyEdit:
+		f.applyWorkspaceEdit(t, req.Params.(*lsproto.ApplyWorkspaceEditParams).Edit)
+		result = &lsproto.ApplyWorkspaceEditResult{Applied: true}
+	case lsproto.MethodWorkspaceConfiguration:
+		items := req.Params.(*lsproto.ConfigurationParams).Items
+		settings := make([]any, len(items))
+		for i, item := range items {
+			preferences := f.userPreferences
+			if item.Section != nil && *item.Section == "javascript" {
+				preferences = f.javaScriptUserPreferences
+			}
+			if preferences != nil {
+				settings[i] = toSettings(preferences)
+			}
+		}
+		result = settings
+	}
+	f.writeMsg(t, (&lsproto.ResponseMessage{ID: req.ID, Result: result}).Message())
+}
+
+// toSettings converts preferences into the `typescript` or `javascript` configuration section a client would
+// send, in the shape read by UserPreferences.Parse. Unknown tristates are left out, so the server's defaults apply.
+func toSettings(preferences *ls.UserPreferences) map[string]any {
+	putTristate := func(settings map[string]any, name string, value core.Tristate) {
+		if !value.IsUnknown() {
+			settings[name] = value.IsTrue()
+		}
+	}
+	toAnys := func(values []string) []any {
+		return core.Map(values, func(value string) any { return value })
+	}
+
+	organizeImports := map[string]any{
+		"caseSensitivity":  core.IfElse(preferences.OrganizeImportsIgnoreCase.IsUnknown(), "auto", core.IfElse(preferences.OrganizeImportsIgnoreCase.IsTrue(), "caseInsensitive", "caseSensitive")),
+		"typeOrder":        []string{"last", "inline", "first"}[preferences.OrganizeImportsTypeOrder],
+		"unicodeCollation": core.IfElse(preferences.OrganizeImportsCollation == ls.OrganizeImportsCollationUnicode, "unicode", "ordinal"),
+		"locale":           preferences.OrganizeImportsLocale,
+		"numericCollation": preferences.OrganizeImportsNumericCollation,
+		"accentCollation":  preferences.OrganizeImportsAccentCollation == ls.OrganizeImportsAccentCollationTrue,
+		"caseFirst":        []string{"default", "lower", "upper"}[preferences.OrganizeImportsCaseFirst],
+	}
+	preferenceSettings := map[string]any{
+		"quoteStyle":                        string(preferences.QuotePreference),
+		"importModuleSpecifier":             string(preferences.ImportModuleSpecifierPreference),
+		"importModuleSpecifierEnding":       string(preferences.ImportModuleSpecifierEnding),
+		"includePackageJsonAutoImports":     string(preferences.IncludePackageJsonAutoImports),
+		"jsxAttributeCompletionStyle":       string(preferences.JsxAttributeCompletionStyle),
+		"autoImportFileExcludePatterns":     toAnys(preferences.AutoImportFileExcludePatterns),
+		"autoImportSpecifierExcludeRegexes": toAnys(preferences.AutoImportSpecifierExcludeRegexes),
+		"preferTypeOnlyAutoImports":         preferences.PreferTypeOnlyAutoImports,
+		"organizeImports":                   organizeImports,
+	}
+	putTristate(preferenceSettings, "useAliasesForRenames", preferences.UseAliasesForRename)
+
+	suggest := map[string]any{}
+	putTristate(suggest, "autoImports", preferences.IncludeCompletionsForModuleExports)
+	putTristate(suggest, "includeCompletionsForImportStatements", preferences.IncludeCompletionsForImportStatements)
+	putTristate(suggest, "includeAutomaticOptionalChainCompletions", preferences.IncludeAutomaticOptionalChainCompletions)
+	classMemberSnippets := map[string]any{}
+	putTristate(classMemberSnippets, "enabled", preferences.IncludeCompletionsWithClassMemberSnippets)
+	suggest["classMemberSnippets"] = classMemberSnippets
+	objectLiteralMethodSnippets := map[string]any{}
+	putTristate(objectLiteralMethodSnippets, "enabled", preferences.IncludeCompletionsWithObjectLiteralMethodSnippets)
+	suggest["objectLiteralMethodSnippets"] = objectLiteralMethodSnippets
+
+	parameterNamesEnabled := string(preferences.IncludeInlayParameterNameHints)
+	if parameterNamesEnabled == "" {
+		parameterNamesEnabled = "none"
+	}
+	inlayHints := map[string]any{
+		"parameterNames": map[string]any{
+			"enabled":                         parameterNamesEnabled,
+			"suppressWhenArgumentMatchesName": !preferences.IncludeInlayParameterNameHintsWhenArgumentMatchesName,
+		},
+		"parameterTypes": map[string]any{"enabled": preferences.IncludeInlayFunctionParameterTypeHints},
+		"variableTypes": map[string]any{
+			"enabled":                     preferences.IncludeInlayVariableTypeHints,
+			"suppressWhenTypeMatchesName": !preferences.IncludeInlayVariableTypeHintsWhenTypeMatchesName,
+		},
+		"propertyDeclarationTypes": map[string]any{"enabled": preferences.IncludeInlayPropertyDeclarationTypeHints},
+		"functionLikeReturnTypes":  map[string]any{"enabled": preferences.IncludeInlayFunctionLikeReturnTypeHints},
+		"enumMemberValues":         map[string]any{"enabled": preferences.IncludeInlayEnumMemberValueHints},
+	}
+
+	return map[string]any{
+		"preferences":      preferenceSettings,
+		"suggest":          suggest,
+		"inlayHints":       inlayHints,
+		"workspaceSymbols": map[string]any{"excludeLibrarySymbols": preferences.ExcludeLibrarySymbolsInNavTo},
+	}
+}
+
+// handleConfigurationRequest answers the request for the client's configuration that the server sends
+// after initialization and after each configuration change.
+func (f *FourslashTest) handleConfigurationRequest(t *testing.T) {
+	msg := f.readMsg(t)
+	if msg == nil || msg.Kind != lsproto.MessageKindRequest || msg.AsRequest().Method != lsproto.MethodWorkspaceConfiguration {
+		t.Fatalf("Expected a workspace/configuration request, got %v", msg)
+	}
+	f.handleServerRequest(t, msg.AsRequest())
+}
+
+// Configure changes the user preferences reported by the client, as if the user edited their settings.
+// Passing nil restores the default preferences.
+func (f *FourslashTest) Configure(t *testing.T, preferences *ls.UserPreferences) {
+	f.ConfigureLanguages(t, preferences, preferences)
+}
+
+// ConfigureLanguages changes the user preferences reported by the client for TypeScript and JavaScript files.
+func (f *FourslashTest) ConfigureLanguages(t *testing.T, typeScriptPreferences *ls.UserPreferences, javaScriptPreferences *ls.UserPreferences) {
+	f.userPreferences = typeScriptPreferences
+	f.javaScriptUserPreferences = javaScriptPreferences
+	sendNotification(t, f, lsproto.WorkspaceDidChangeConfigurationInfo, &lsproto.DidChangeConfigurationParams{})
+	f.handleConfigurationRequest(t)
+}
+
 func sendNotification[Params any](t *testing.T, f *FourslashTest, info lsproto.NotificationInfo[Params], params Params) {
 	notification := lsproto.NewNotificationMessage(
 		info.Method,
@@ -446,6 +589,16 @@ func (f *FourslashTest) openFile(t *testing.T, filename string) {
 	})
 }
 
+// CloseFile tells the server the editor closed the file.
+func (f *FourslashTest) CloseFile(t *testing.T, filename string) {
+	filename = tspath.GetNormalizedAbsolutePath(filename, rootDir)
+	sendNotification(t, f, lsproto.TextDocumentDidCloseInfo, &lsproto.DidCloseTextDocumentParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(filename),
+		},
+	})
+}
+
 func getLanguageKind(filename string) lsproto.LanguageKind {
 	if tspath.FileExtensionIsOneOf(
 		filename,
@@ -852,6 +1005,43 @@ func (f *FourslashTest) VerifyBaselineGoToDefinition(
 	}
 }
 
+// VerifyGoToSourceDefinition requests the source definition at the current caret position and checks that the
+// results start at the markers with the given names.
+func (f *FourslashTest) VerifyGoToSourceDefinition(t *testing.T, expectedMarkerNames ...string) {
+	params := &lsproto.TextDocumentPositionParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Position: f.currentCaretPosition,
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.CustomTextDocumentSourceDefinitionInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for source definition request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected source definition response type: %T", resMsg.AsResponse().Result)
+	}
+
+	formatLocation := func(fileName string, position lsproto.Position) string {
+		return fmt.Sprintf("%s(%d,%d)", fileName, position.Line+1, position.Character+1)
+	}
+	actual := []string{}
+	if result.Locations != nil {
+		for _, location := range *result.Locations {
+			actual = append(actual, formatLocation(location.Uri.FileName(), location.Range.Start))
+		}
+	}
+	expected := []string{}
+	for _, markerName := range expectedMarkerNames {
+		marker, ok := f.testData.MarkerPositions[markerName]
+		if !ok {
+			t.Fatalf("Marker '%s' not found", markerName)
+		}
+		expected = append(expected, formatLocation(marker.FileName(), marker.LSPosition))
+	}
+	assertDeepEqual(t, actual, expected, "unexpected source definitions")
+}
+
 func (f *FourslashTest) VerifyBaselineHover(t *testing.T) {
 	markersAndItems := core.MapFiltered(f.Markers(), func(marker *Marker) (markerAndItem[*lsproto.Hover], bool) {
 		if marker.Name == nil {
@@ -1742,3 +1932,695 @@ func (f *FourslashTest) verifyBaselines(t *testing.T) {
 		baseline.Run(t, getBaselineFileName(t, command), content.String(), getBaselineOptions(command))
 	}
 }
+
+type VerifyCodeFixOptions struct {
+	Description    string
+	NewFileContent string
+}
+
+// VerifyCodeFix applies the quick fix with the given description to the active file and checks the resulting text.
+func (f *FourslashTest) VerifyCodeFix(t *testing.T, options VerifyCodeFixOptions) {
+	actions := f.getQuickFixes(t)
+	for _, action := range actions {
+		if action.Title == options.Description {
+			f.applyWorkspaceEdit(t, action.Edit)
+			assert.Equal(t, f.getScriptInfo(f.activeFilename).content, options.NewFileContent, "unexpected file content after applying code fix %q", options.Description)
+			return
+		}
+	}
+	t.Fatalf("No code fix with description %q; available fixes: %v", options.Description, core.Map(actions, func(action *lsproto.CodeAction) string { return action.Title }))
+}
+
+// VerifyCodeFixAvailable checks that exactly the quick fixes with the given descriptions are offered for the active file.
+func (f *FourslashTest) VerifyCodeFixAvailable(t *testing.T, descriptions []string) {
+	actual := core.Map(f.getQuickFixes(t), func(action *lsproto.CodeAction) string { return action.Title })
+	assertDeepEqual(t, actual, descriptions, "unexpected code fixes")
+}
+
+type VerifyRefactorOptions struct {
+	Kind           lsproto.CodeActionKind
+	Description    string
+	NewFileContent string
+}
+
+// VerifyRefactor applies the refactoring with the given kind and description for the current selection
+// and checks the resulting text of the active file.
+func (f *FourslashTest) VerifyRefactor(t *testing.T, options VerifyRefactorOptions) {
+	actions := f.getRefactors(t, options.Kind)
+	for _, action := range actions {
+		if action.Title == options.Description {
+			f.applyWorkspaceEdit(t, action.Edit)
+			assert.Equal(t, f.getScriptInfo(f.activeFilename).content, options.NewFileContent, "unexpected file content after applying refactor %q", options.Description)
+			return
+		}
+	}
+	t.Fatalf("No refactor with description %q; available refactors: %v", options.Description, core.Map(actions, func(action *lsproto.CodeAction) string { return action.Title }))
+}
+
+// VerifyRefactorsAvailable checks that exactly the refactorings of the given kind with the given descriptions
+// are offered for the current selection.
+func (f *FourslashTest) VerifyRefactorsAvailable(t *testing.T, kind lsproto.CodeActionKind, descriptions []string) {
+	actual := core.Map(f.getRefactors(t, kind), func(action *lsproto.CodeAction) string { return action.Title })
+	assertDeepEqual(t, orEmpty(actual), orEmpty(descriptions), "unexpected refactors")
+}
+
+// VerifySourceAction applies the source action of the given kind to the active file and checks its
+// resulting text.
+func (f *FourslashTest) VerifySourceAction(t *testing.T, kind lsproto.CodeActionKind, preferences *ls.UserPreferences, expectedContent string) {
+	f.Configure(t, preferences)
+	defer f.Configure(t, nil)
+
+	actions := f.getCodeActions(t, lsproto.Range{}, []lsproto.CodeActionKind{kind}, nil /*diagnostics*/)
+	if len(actions) != 1 {
+		t.Fatalf("Expected a single %s action, got %v", kind, core.Map(actions, func(action *lsproto.CodeAction) string { return action.Title }))
+	}
+	f.applyWorkspaceEdit(t, actions[0].Edit)
+	assert.Equal(t, f.getScriptInfo(f.activeFilename).content, expectedContent, "unexpected content after %s", kind)
+}
+
+type VerifyMoveToFileOptions struct {
+	// File to move the selected statements to. If empty, they are moved to a new file named by the language service.
+	TargetFile string
+	// Expected content of each changed or created file after the move
+	NewFileContents map[string]string
+}
+
+// VerifyMoveToFile moves the statements in the current selection to another file and checks the resulting
+// text of the given files.
+func (f *FourslashTest) VerifyMoveToFile(t *testing.T, options VerifyMoveToFileOptions) {
+	kind := lsproto.CodeActionKind("refactor.move.file")
+	if options.TargetFile == "" {
+		kind = "refactor.move.newFile"
+	}
+	actions := f.getRefactors(t, kind)
+	if len(actions) != 1 || actions[0].Command == nil || actions[0].Command.Arguments == nil {
+		t.Fatalf("Expected a single %s command, got %v", kind, core.Map(actions, func(action *lsproto.CodeAction) string { return action.Title }))
+	}
+	command := actions[0].Command
+	arguments := *(*command.Arguments)[0].(*ls.MoveToFileArguments)
+	if options.TargetFile != "" {
+		arguments.TargetFile = ls.FileNameToDocumentURI(options.TargetFile)
+	}
+	resMsg, _, _ := sendRequest(t, f, lsproto.WorkspaceExecuteCommandInfo, &lsproto.ExecuteCommandParams{
+		Command:   command.Command,
+		Arguments: &[]any{&arguments},
+	})
+	if resMsg == nil {
+		t.Fatal("Nil response received for execute command request")
+	}
+	if resErr := resMsg.AsResponse().Error; resErr != nil {
+		t.Fatalf("Error executing command %s: %s", command.Command, resErr.String())
+	}
+	for fileName, expected := range options.NewFileContents {
+		script := f.getScriptInfo(fileName)
+		if script == nil {
+			t.Fatalf("File %s was not created", fileName)
+		}
+		assert.Equal(t, script.content, expected, "unexpected content of %s after moving statements", fileName)
+	}
+}
+
+// VerifyWillRenameFile applies the edits the server asks for before a file or directory is renamed and checks
+// the resulting text of the given files.
+func (f *FourslashTest) VerifyWillRenameFile(t *testing.T, oldName string, newName string, preferences *ls.UserPreferences, expected map[string]string) {
+	f.Configure(t, preferences)
+	defer f.Configure(t, nil)
+
+	params := &lsproto.RenameFilesParams{
+		Files: []*lsproto.FileRename{{
+			OldUri: string(ls.FileNameToDocumentURI(oldName)),
+			NewUri: string(ls.FileNameToDocumentURI(newName)),
+		}},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.WorkspaceWillRenameFilesInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for will rename files request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected will rename files response type: %T", resMsg.AsResponse().Result)
+	}
+	f.applyWorkspaceEdit(t, result.WorkspaceEdit)
+	for fileName, content := range expected {
+		assert.Equal(t, f.getScriptInfo(fileName).content, content, "unexpected content of %s after renaming %s to %s", fileName, oldName, newName)
+	}
+}
+
+func (f *FourslashTest) getRefactors(t *testing.T, kind lsproto.CodeActionKind) []*lsproto.CodeAction {
+	script := f.getScriptInfo(f.activeFilename)
+	return f.getCodeActions(t, f.converters.ToLSPRange(script, f.getSelection()), []lsproto.CodeActionKind{kind}, nil)
+}
+
+func (f *FourslashTest) getQuickFixes(t *testing.T) []*lsproto.CodeAction {
+	script := f.getScriptInfo(f.activeFilename)
+	return f.getCodeActions(t, f.converters.ToLSPRange(script, core.NewTextRange(0, len(script.content))), []lsproto.CodeActionKind{lsproto.CodeActionKindQuickFix}, f.getDiagnostics(t))
+}
+
+func (f *FourslashTest) getDiagnostics(t *testing.T) []*lsproto.Diagnostic {
+	params := &lsproto.DocumentDiagnosticParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentDiagnosticInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for diagnostics request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected diagnostics response type: %T", resMsg.AsResponse().Result)
+	}
+	if result.FullDocumentDiagnosticReport == nil {
+		return nil
+	}
+	return result.FullDocumentDiagnosticReport.Items
+}
+
+// VerifyWorkspaceDiagnostics requests the diagnostics of all files in the workspace, sending the result IDs of
+// the previous request, and checks the messages reported for each file. The files in unchanged must be
+// reported as unchanged since the previous request; every other reported file must be in expected.
+func (f *FourslashTest) VerifyWorkspaceDiagnostics(t *testing.T, expected map[string][]string, unchanged []string) {
+	params := &lsproto.WorkspaceDiagnosticParams{}
+	for uri, resultId := range f.workspaceDiagnosticResultIds {
+		params.PreviousResultIds = append(params.PreviousResultIds, lsproto.PreviousResultId{Uri: uri, Value: resultId})
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.WorkspaceDiagnosticInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for workspace diagnostics request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected workspace diagnostics response type: %T", resMsg.AsResponse().Result)
+	}
+
+	actual := make(map[string][]string)
+	actualUnchanged := []string{}
+	resultIds := make(map[lsproto.DocumentUri]string)
+	for _, item := range result.Items {
+		switch {
+		case item.FullDocumentDiagnosticReport != nil:
+			report := item.FullDocumentDiagnosticReport
+			messages := []string{}
+			for _, diagnostic := range report.Items {
+				messages = append(messages, diagnostic.Message)
+			}
+			actual[report.Uri.FileName()] = messages
+			if report.ResultId != nil {
+				resultIds[report.Uri] = *report.ResultId
+			}
+		case item.UnchangedDocumentDiagnosticReport != nil:
+			report := item.UnchangedDocumentDiagnosticReport
+			actualUnchanged = append(actualUnchanged, report.Uri.FileName())
+			resultIds[report.Uri] = report.ResultId
+		}
+	}
+	f.workspaceDiagnosticResultIds = resultIds
+
+	normalizedExpected := make(map[string][]string, len(expected))
+	for fileName, messages := range expected {
+		normalizedExpected[tspath.GetNormalizedAbsolutePath(fileName, rootDir)] = append([]string{}, messages...)
+	}
+	normalizedUnchanged := []string{}
+	for _, fileName := range unchanged {
+		normalizedUnchanged = append(normalizedUnchanged, tspath.GetNormalizedAbsolutePath(fileName, rootDir))
+	}
+	slices.Sort(normalizedUnchanged)
+	slices.Sort(actualUnchanged)
+	assert.DeepEqual(t, actual, normalizedExpected)
+	assert.DeepEqual(t, actualUnchanged, normalizedUnchanged)
+}
+
+func (f *FourslashTest) getCodeActions(t *testing.T, lspRange lsproto.Range, only []lsproto.CodeActionKind, diagnostics []*lsproto.Diagnostic) []*lsproto.CodeAction {
+	params := &lsproto.CodeActionParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Range: lspRange,
+		Context: &lsproto.CodeActionContext{
+			Diagnostics: diagnostics,
+			Only:        &only,
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentCodeActionInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for code action request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected code action response type: %T", resMsg.AsResponse().Result)
+	}
+	if result.CommandOrCodeActionArray == nil {
+		return nil
+	}
+	return core.MapNonNil(*result.CommandOrCodeActionArray, func(action lsproto.CommandOrCodeAction) *lsproto.CodeAction { return action.CodeAction })
+}
+
+func (f *FourslashTest) applyWorkspaceEdit(t *testing.T, edit *lsproto.WorkspaceEdit) {
+	if edit == nil {
+		return
+	}
+	if edit.Changes != nil {
+		for uri, edits := range *edit.Changes {
+			f.applyTextEdits(t, uri.FileName(), edits)
+		}
+	}
+	if edit.DocumentChanges != nil {
+		for _, change := range *edit.DocumentChanges {
+			switch {
+			case change.CreateFile != nil:
+				f.createFile(t, change.CreateFile.Uri.FileName())
+			case change.TextDocumentEdit != nil:
+				edits := core.Map(change.TextDocumentEdit.Edits, func(edit lsproto.TextEditOrAnnotatedTextEditOrSnippetTextEdit) *lsproto.TextEdit {
+					if edit.TextEdit == nil {
+						t.Fatalf("Unsupported text edit in workspace edit: %v", edit)
+					}
+					return edit.TextEdit
+				})
+				f.applyTextEdits(t, change.TextDocumentEdit.TextDocument.Uri.FileName(), edits)
+			default:
+				t.Fatalf("Unsupported document change in workspace edit: %v", change)
+			}
+		}
+	}
+}
+
+// createFile adds an empty file to the test and opens it, without making it the active file.
+func (f *FourslashTest) createFile(t *testing.T, fileName string) {
+	if f.getScriptInfo(fileName) != nil {
+		t.Fatalf("File %s already exists", fileName)
+	}
+	f.scriptInfos[fileName] = newScriptInfo(fileName, "")
+	if err := f.vfs.WriteFile(fileName, "", false); err != nil {
+		t.Fatalf("Failed to write file %s: %v", fileName, err)
+	}
+	activeFilename := f.activeFilename
+	f.openFile(t, fileName)
+	f.activeFilename = activeFilename
+}
+
+// applyTextEdits applies edits in reverse document order so that earlier positions stay valid.
+// Edits at the same position keep their relative order in the resulting text.
+func (f *FourslashTest) applyTextEdits(t *testing.T, fileName string, edits []*lsproto.TextEdit) {
+	script := f.getScriptInfo(fileName)
+	if script == nil {
+		t.Fatalf("Script info for file %s not found", fileName)
+	}
+	sorted := slices.Clone(edits)
+	slices.Reverse(sorted)
+	slices.SortStableFunc(sorted, func(a, b *lsproto.TextEdit) int {
+		return ls.ComparePositions(b.Range.Start, a.Range.Start)
+	})
+	for _, edit := range sorted {
+		textRange := f.converters.FromLSPRange(script, edit.Range)
+		f.editScriptAndUpdateMarkers(t, fileName, textRange.Pos(), textRange.End(), edit.NewText)
+	}
+}
+
+// VerifyInlayHints checks the inlay hints for the whole active file. Each expected entry maps the name of
+// the marker at the hint's position to the hint's label.
+func (f *FourslashTest) VerifyInlayHints(t *testing.T, preferences *ls.UserPreferences, expected map[string]string) {
+	f.Configure(t, preferences)
+	defer f.Configure(t, nil)
+
+	script := f.getScriptInfo(f.activeFilename)
+	params := &lsproto.InlayHintParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Range: f.converters.ToLSPRange(script, core.NewTextRange(0, len(script.content))),
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentInlayHintInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for inlay hint request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected inlay hint response type: %T", resMsg.AsResponse().Result)
+	}
+
+	actual := map[string]string{}
+	if result.InlayHints != nil {
+		for _, hint := range *result.InlayHints {
+			markerName := fmt.Sprintf("%d:%d", hint.Position.Line, hint.Position.Character)
+			for _, marker := range f.testData.Markers {
+				if marker.fileName == f.activeFilename && marker.Name != nil && marker.LSPosition == hint.Position {
+					markerName = *marker.Name
+					break
+				}
+			}
+			if hint.Label.String == nil {
+				t.Fatalf("Expected inlay hint at %s to have a string label", markerName)
+			}
+			actual[markerName] = *hint.Label.String
+		}
+	}
+	assertDeepEqual(t, actual, expected, "unexpected inlay hints")
+}
+
+// SemanticToken is a decoded semantic token. Type is the token type followed by its modifiers,
+// each separated by a dot, e.g. "class.declaration".
+type SemanticToken struct {
+	Type string
+	Text string
+}
+
+// VerifySemanticTokens checks the semantic tokens for the whole active file.
+func (f *FourslashTest) VerifySemanticTokens(t *testing.T, expected []SemanticToken) {
+	params := &lsproto.SemanticTokensParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentSemanticTokensFullInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for semantic tokens request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected semantic tokens response type: %T", resMsg.AsResponse().Result)
+	}
+	if result.SemanticTokens == nil {
+		t.Fatal("Expected semantic tokens, got nil")
+	}
+
+	script := f.getScriptInfo(f.activeFilename)
+	data := result.SemanticTokens.Data
+	actual := []SemanticToken{}
+	var line, character uint32
+	for i := 0; i+4 < len(data); i += 5 {
+		if data[i] != 0 {
+			character = 0
+		}
+		line += data[i]
+		character += data[i+1]
+		start := lsproto.Position{Line: line, Character: character}
+		end := lsproto.Position{Line: line, Character: character + data[i+2]}
+		textRange := f.converters.FromLSPRange(script, lsproto.Range{Start: start, End: end})
+
+		tokenType := ls.SemanticTokensLegend.TokenTypes[data[i+3]]
+		for bit, modifier := range ls.SemanticTokensLegend.TokenModifiers {
+			if data[i+4]&(1<<bit) != 0 {
+				tokenType += "." + modifier
+			}
+		}
+		actual = append(actual, SemanticToken{Type: tokenType, Text: script.content[textRange.Pos():textRange.End()]})
+	}
+	assertDeepEqual(t, actual, expected, "unexpected semantic tokens")
+}
+
+// CallHierarchyCall describes an incoming or outgoing call: the name of the item on the other
+// end of the call and the source text of each call range.
+type CallHierarchyCall struct {
+	Name       string
+	FromRanges []string
+}
+
+type VerifyCallHierarchyOptions struct {
+	Name     string
+	Kind     lsproto.SymbolKind
+	Incoming []CallHierarchyCall
+	Outgoing []CallHierarchyCall
+}
+
+// VerifyCallHierarchy prepares a call hierarchy at the current position and checks the single
+// resulting item along with its incoming and outgoing calls.
+func (f *FourslashTest) VerifyCallHierarchy(t *testing.T, expected *VerifyCallHierarchyOptions) {
+	params := &lsproto.CallHierarchyPrepareParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Position: f.currentCaretPosition,
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentPrepareCallHierarchyInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for prepare call hierarchy request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected prepare call hierarchy response type: %T", resMsg.AsResponse().Result)
+	}
+	if result.CallHierarchyItems == nil || len(*result.CallHierarchyItems) != 1 {
+		t.Fatalf("Expected a single call hierarchy item, got %v", result.CallHierarchyItems)
+	}
+	item := (*result.CallHierarchyItems)[0]
+	assert.Equal(t, item.Name, expected.Name)
+	assert.Equal(t, item.Kind, expected.Kind)
+
+	incomingMsg, incoming, incomingOk := sendRequest(t, f, lsproto.CallHierarchyIncomingCallsInfo, &lsproto.CallHierarchyIncomingCallsParams{Item: item})
+	if incomingMsg == nil || !incomingOk || incoming.CallHierarchyIncomingCalls == nil {
+		t.Fatal("Expected incoming calls")
+	}
+	actualIncoming := []CallHierarchyCall{}
+	for _, call := range *incoming.CallHierarchyIncomingCalls {
+		actualIncoming = append(actualIncoming, f.toCallHierarchyCall(t, call.From.Name, call.From.Uri, call.FromRanges))
+	}
+	assertDeepEqual(t, actualIncoming, orEmpty(expected.Incoming), "unexpected incoming calls")
+
+	outgoingMsg, outgoing, outgoingOk := sendRequest(t, f, lsproto.CallHierarchyOutgoingCallsInfo, &lsproto.CallHierarchyOutgoingCallsParams{Item: item})
+	if outgoingMsg == nil || !outgoingOk || outgoing.CallHierarchyOutgoingCalls == nil {
+		t.Fatal("Expected outgoing calls")
+	}
+	actualOutgoing := []CallHierarchyCall{}
+	for _, call := range *outgoing.CallHierarchyOutgoingCalls {
+		actualOutgoing = append(actualOutgoing, f.toCallHierarchyCall(t, call.To.Name, item.Uri, call.FromRanges))
+	}
+	assertDeepEqual(t, actualOutgoing, orEmpty(expected.Outgoing), "unexpected outgoing calls")
+}
+
+// orEmpty returns an empty slice in place of nil, so that optional expectations compare equal to empty results.
+func orEmpty[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+func (f *FourslashTest) toCallHierarchyCall(t *testing.T, name string, uri lsproto.DocumentUri, fromRanges []lsproto.Range) CallHierarchyCall {
+	script := f.getScriptInfo(uri.FileName())
+	if script == nil {
+		t.Fatalf("Script info for file %s not found", uri.FileName())
+	}
+	call := CallHierarchyCall{Name: name}
+	for _, fromRange := range fromRanges {
+		textRange := f.converters.FromLSPRange(script, fromRange)
+		call.FromRanges = append(call.FromRanges, script.content[textRange.Pos():textRange.End()])
+	}
+	return call
+}
+
+type VerifyTypeHierarchyOptions struct {
+	Name       string
+	Kind       lsproto.SymbolKind
+	Supertypes []string
+	Subtypes   []string
+}
+
+// VerifyTypeHierarchy prepares a type hierarchy at the current position and checks the names of
+// the single resulting item and of its direct supertypes and subtypes.
+func (f *FourslashTest) VerifyTypeHierarchy(t *testing.T, expected *VerifyTypeHierarchyOptions) {
+	params := &lsproto.TypeHierarchyPrepareParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Position: f.currentCaretPosition,
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentPrepareTypeHierarchyInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for prepare type hierarchy request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected prepare type hierarchy response type: %T", resMsg.AsResponse().Result)
+	}
+	if result.TypeHierarchyItems == nil || len(*result.TypeHierarchyItems) != 1 {
+		t.Fatalf("Expected a single type hierarchy item, got %v", result.TypeHierarchyItems)
+	}
+	item := (*result.TypeHierarchyItems)[0]
+	assert.Equal(t, item.Name, expected.Name)
+	assert.Equal(t, item.Kind, expected.Kind)
+
+	itemNames := func(result lsproto.TypeHierarchyItemsOrNull) []string {
+		names := []string{}
+		if result.TypeHierarchyItems != nil {
+			for _, item := range *result.TypeHierarchyItems {
+				names = append(names, item.Name)
+			}
+		}
+		return names
+	}
+	_, supertypes, supertypesOk := sendRequest(t, f, lsproto.TypeHierarchySupertypesInfo, &lsproto.TypeHierarchySupertypesParams{Item: item})
+	if !supertypesOk {
+		t.Fatal("Expected supertypes")
+	}
+	assertDeepEqual(t, itemNames(supertypes), orEmpty(expected.Supertypes), "unexpected supertypes")
+	_, subtypes, subtypesOk := sendRequest(t, f, lsproto.TypeHierarchySubtypesInfo, &lsproto.TypeHierarchySubtypesParams{Item: item})
+	if !subtypesOk {
+		t.Fatal("Expected subtypes")
+	}
+	assertDeepEqual(t, itemNames(subtypes), orEmpty(expected.Subtypes), "unexpected subtypes")
+}
+
+type FoldingRange struct {
+	StartLine uint32
+	EndLine   uint32
+	Kind      lsproto.FoldingRangeKind
+}
+
+func (f *FourslashTest) VerifyFoldingRanges(t *testing.T, expected []FoldingRange) {
+	params := &lsproto.FoldingRangeParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentFoldingRangeInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for folding range request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected folding range response type: %T", resMsg.AsResponse().Result)
+	}
+
+	actual := []FoldingRange{}
+	if result.FoldingRanges != nil {
+		for _, foldingRange := range *result.FoldingRanges {
+			actualRange := FoldingRange{StartLine: foldingRange.StartLine, EndLine: foldingRange.EndLine}
+			if foldingRange.Kind != nil {
+				actualRange.Kind = *foldingRange.Kind
+			}
+			actual = append(actual, actualRange)
+		}
+	}
+	assertDeepEqual(t, actual, orEmpty(expected), "unexpected folding ranges")
+}
+
+// VerifySelectionRanges checks the text of each selection range at the caret, from the innermost outwards.
+// VerifyCodeLenses requests the code lenses of the active file, resolves each of them and checks their text and
+// titles, formatted as "<text>: <title>".
+func (f *FourslashTest) VerifyCodeLenses(t *testing.T, expected []string) {
+	params := &lsproto.CodeLensParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentCodeLensInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for code lens request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected code lens response type: %T", resMsg.AsResponse().Result)
+	}
+
+	script := f.getScriptInfo(f.activeFilename)
+	actual := []string{}
+	if result.CodeLenss != nil {
+		for _, codeLens := range *result.CodeLenss {
+			resMsg, resolved, resultOk := sendRequest(t, f, lsproto.CodeLensResolveInfo, codeLens)
+			if resMsg == nil {
+				t.Fatal("Nil response received for code lens resolve request")
+			}
+			if !resultOk || resolved.Command == nil {
+				t.Fatalf("Unexpected code lens resolve response: %v", resMsg.AsResponse().Result)
+			}
+			textRange := f.converters.FromLSPRange(script, resolved.Range)
+			actual = append(actual, fmt.Sprintf("%s: %s", script.content[textRange.Pos():textRange.End()], resolved.Command.Title))
+		}
+	}
+	assertDeepEqual(t, actual, expected, "unexpected code lenses")
+}
+
+// VerifyDocumentLinks requests the document links of the active file and checks their text and targets,
+// formatted as "<text> -> <target file name>".
+func (f *FourslashTest) VerifyDocumentLinks(t *testing.T, expected []string) {
+	params := &lsproto.DocumentLinkParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentDocumentLinkInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for document link request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected document link response type: %T", resMsg.AsResponse().Result)
+	}
+
+	script := f.getScriptInfo(f.activeFilename)
+	actual := []string{}
+	if result.DocumentLinks != nil {
+		for _, link := range *result.DocumentLinks {
+			textRange := f.converters.FromLSPRange(script, link.Range)
+			actual = append(actual, fmt.Sprintf("%s -> %s", script.content[textRange.Pos():textRange.End()], lsproto.DocumentUri(*link.Target).FileName()))
+		}
+	}
+	assertDeepEqual(t, actual, expected, "unexpected document links")
+}
+
+// VerifyLinkedEditingRange checks the linked editing ranges at the caret. No ranges are expected if expected is
+// empty.
+func (f *FourslashTest) VerifyLinkedEditingRange(t *testing.T, expected []*RangeMarker) {
+	params := &lsproto.LinkedEditingRangeParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Position: f.currentCaretPosition,
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentLinkedEditingRangeInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for linked editing range request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected linked editing range response type: %T", resMsg.AsResponse().Result)
+	}
+
+	var actual []lsproto.Range
+	if result.LinkedEditingRanges != nil {
+		actual = result.LinkedEditingRanges.Ranges
+	}
+	var expectedRanges []lsproto.Range
+	for _, rangeMarker := range expected {
+		expectedRanges = append(expectedRanges, rangeMarker.LSRange)
+	}
+	assertDeepEqual(t, actual, expectedRanges, "unexpected linked editing ranges")
+}
+
+// VerifyJsxClosingTag checks the closing tag to insert at the caret. No closing tag is expected if expected is "".
+func (f *FourslashTest) VerifyJsxClosingTag(t *testing.T, expected string) {
+	params := &lsproto.TextDocumentPositionParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Position: f.currentCaretPosition,
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.CustomTextDocumentJsxClosingTagInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for JSX closing tag request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected JSX closing tag response type: %T", resMsg.AsResponse().Result)
+	}
+
+	var actual string
+	if result != nil {
+		actual = result.NewText
+	}
+	assertDeepEqual(t, actual, expected, "unexpected JSX closing tag")
+}
+
+func (f *FourslashTest) VerifySelectionRanges(t *testing.T, expected []string) {
+	params := &lsproto.SelectionRangeParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(f.activeFilename),
+		},
+		Positions: []lsproto.Position{f.currentCaretPosition},
+	}
+	resMsg, result, resultOk := sendRequest(t, f, lsproto.TextDocumentSelectionRangeInfo, params)
+	if resMsg == nil {
+		t.Fatal("Nil response received for selection range request")
+	}
+	if !resultOk {
+		t.Fatalf("Unexpected selection range response type: %T", resMsg.AsResponse().Result)
+	}
+	if result.SelectionRanges == nil || len(*result.SelectionRanges) != 1 {
+		t.Fatalf("Expected a single selection range, got %v", result.SelectionRanges)
+	}
+
+	script := f.getScriptInfo(f.activeFilename)
+	actual := []string{}
+	for selectionRange := (*result.SelectionRanges)[0]; selectionRange != nil; selectionRange = selectionRange.Parent {
+		textRange := f.converters.FromLSPRange(script, selectionRange.Range)
+		actual = append(actual, script.content[textRange.Pos():textRange.End()])
+	}
+	assertDeepEqual(t, actual, expected, "unexpected selection ranges")
+}
@@ -0,0 +1,66 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestCallHierarchy(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export function /*foo*/foo() {
+    bar();
+}
+
+function /*bar*/bar() {
+    baz();
+    quxx();
+    baz();
+}
+
+function baz() {
+}
+
+const quxx = () => {
+};
+
+class C {
+    method() {
+        bar();
+    }
+}
+
+// @Filename: /b.ts
+import { foo } from "./a";
+foo();`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "foo")
+	f.VerifyCallHierarchy(t, &fourslash.VerifyCallHierarchyOptions{
+		Name: "foo",
+		Kind: lsproto.SymbolKindFunction,
+		Incoming: []fourslash.CallHierarchyCall{
+			{Name: "b.ts", FromRanges: []string{"foo"}},
+		},
+		Outgoing: []fourslash.CallHierarchyCall{
+			{Name: "bar", FromRanges: []string{"bar"}},
+		},
+	})
+	f.GoToMarker(t, "bar")
+	f.VerifyCallHierarchy(t, &fourslash.VerifyCallHierarchyOptions{
+		Name: "bar",
+		Kind: lsproto.SymbolKindFunction,
+		Incoming: []fourslash.CallHierarchyCall{
+			{Name: "foo", FromRanges: []string{"bar"}},
+			{Name: "method", FromRanges: []string{"bar"}},
+		},
+		Outgoing: []fourslash.CallHierarchyCall{
+			{Name: "baz", FromRanges: []string{"baz", "baz"}},
+			{Name: "quxx", FromRanges: []string{"quxx"}},
+		},
+	})
+}
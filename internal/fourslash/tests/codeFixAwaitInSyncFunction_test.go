@@ -0,0 +1,28 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestCodeFixAwaitInSyncFunction(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `export {};
+function f(): number {
+    await Promise.resolve();
+    return 1;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Add async modifier to containing function",
+		NewFileContent: `export {};
+async function f(): Promise<number> {
+    await Promise.resolve();
+    return 1;
+}`,
+	})
+}
@@ -0,0 +1,145 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestCodeFixImplementInterface(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `interface I {
+    x: number;
+    readonly y?: string;
+    f(a: string, b?: number): void;
+    g<T>(value: T): Promise<T>;
+    [key: string]: unknown;
+}
+class C implements I {
+    constructor() {}
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Implement interface 'I'",
+		NewFileContent: `interface I {
+    x: number;
+    readonly y?: string;
+    f(a: string, b?: number): void;
+    g<T>(value: T): Promise<T>;
+    [key: string]: unknown;
+}
+class C implements I {
+    constructor() {}
+    [key: string]: unknown;
+    x: number;
+    y?: string;
+    f(a: string, b?: number): void {
+        throw new Error("Method not implemented.");
+    }
+    g<T>(value: T): Promise<T> {
+        throw new Error("Method not implemented.");
+    }
+}`,
+	})
+}
+
+func TestCodeFixImplementInterfaceSingleQuotes(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+import { x } from './x';
+interface I {
+    kind: 'a' | 'b';
+    m(): void;
+}
+class C implements I {}
+// @Filename: /x.ts
+export const x = 0;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.ts")
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Implement interface 'I'",
+		NewFileContent: `import { x } from './x';
+interface I {
+    kind: 'a' | 'b';
+    m(): void;
+}
+class C implements I {
+    kind: 'a' | 'b';
+    m(): void {
+        throw new Error('Method not implemented.');
+    }
+}`,
+	})
+}
+
+func TestCodeFixImplementAbstractMembers(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `abstract class A {
+    abstract a: string;
+    protected abstract b(x: number): number;
+    abstract get c(): boolean;
+    private d() {}
+    e() {}
+}
+class B extends A {
+    existing = 1;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Implement inherited abstract class",
+		NewFileContent: `abstract class A {
+    abstract a: string;
+    protected abstract b(x: number): number;
+    abstract get c(): boolean;
+    private d() {}
+    e() {}
+}
+class B extends A {
+    a: string;
+    protected b(x: number): number {
+        throw new Error("Method not implemented.");
+    }
+    get c(): boolean {
+        throw new Error("Method not implemented.");
+    }
+    existing = 1;
+}`,
+	})
+}
+
+func TestCodeFixImplementInterfaceImportsTypes(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /foo.ts
+export interface Foo {}
+// @Filename: /a.ts
+import { Foo } from "./foo";
+export interface I {
+    make(): Foo;
+    bar: typeof import("./foo");
+}
+// @Filename: /b.ts
+import { I } from "./a";
+class C implements I {}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/b.ts")
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Implement interface 'I'",
+		NewFileContent: `import { I } from "./a";
+import { Foo } from "./foo";
+class C implements I {
+    make(): Foo {
+        throw new Error("Method not implemented.");
+    }
+    bar: typeof import("./foo");
+}`,
+	})
+}
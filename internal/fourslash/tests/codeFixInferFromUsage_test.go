@@ -0,0 +1,180 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestCodeFixInferFromUsage(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @noImplicitAny: true
+function f(a, b, c) {
+    return a * 2 + b.length;
+}
+f(1, "x", true);
+f(2, "y", false);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Infer parameter types from usage",
+		NewFileContent: `function f(a: number, b: string, c: boolean) {
+    return a * 2 + b.length;
+}
+f(1, "x", true);
+f(2, "y", false);`,
+	})
+}
+
+func TestCodeFixInferFromUsageArrowFunction(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @noImplicitAny: true
+interface Point { x: number; y: number }
+declare function draw(p: Point): void;
+const g = p => draw(p);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Infer parameter types from usage",
+		NewFileContent: `interface Point { x: number; y: number }
+declare function draw(p: Point): void;
+const g = (p: Point) => draw(p);`,
+	})
+}
+
+func TestRefactorInferReturnType(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @strict: true
+function /*a*/f(x: number) {
+    if (x > 0) {
+        return { value: x };
+    }
+    return undefined;
+}
+const g = (/*b*/s: string) => s.length > 0;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "a")
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.rewrite.function.returnType",
+		Description: "Infer function return type",
+		NewFileContent: `function f(x: number): { value: number; } | undefined {
+    if (x > 0) {
+        return { value: x };
+    }
+    return undefined;
+}
+const g = (s: string) => s.length > 0;`,
+	})
+	f.GoToMarker(t, "b")
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.rewrite.function.returnType",
+		Description: "Infer function return type",
+		NewFileContent: `function f(x: number): { value: number; } | undefined {
+    if (x > 0) {
+        return { value: x };
+    }
+    return undefined;
+}
+const g = (s: string): boolean => s.length > 0;`,
+	})
+}
+
+func TestRefactorInferReturnTypeUnavailable(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function f(): number {
+    return /*a*/1;
+}
+function g() {
+    /*b*/return 1;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "a")
+	f.VerifyRefactorsAvailable(t, "refactor.rewrite.function.returnType", nil)
+	f.GoToMarker(t, "b")
+	f.VerifyRefactorsAvailable(t, "refactor.rewrite.function.returnType", nil)
+}
+
+func TestCodeFixInferFromUsageJavaScript(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @allowJs: true
+// @checkJs: true
+// @noImplicitAny: true
+// @Filename: /a.js
+/**
+ * Doubles a value.
+ */
+function f(a, b) {
+    return a * 2 + b.length;
+}
+f(1, "x");`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.js")
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Infer parameter types from usage",
+		NewFileContent: `/**
+ * Doubles a value.
+ * @param {number} a
+ * @param {string} b
+ */
+function f(a, b) {
+    return a * 2 + b.length;
+}
+f(1, "x");`,
+	})
+}
+
+func TestCodeFixInferFromUsageNotInFixAll(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @noImplicitAny: true
+function f(a) {
+    return a * 2;
+}
+f(1);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	// Inferred types should be reviewed, so they are not added when all issues are fixed, e.g. on save.
+	f.VerifySourceAction(t, lsproto.CodeActionKindSourceFixAll, nil /*preferences*/, `function f(a) {
+    return a * 2;
+}
+f(1);`)
+}
+
+func TestRefactorInferReturnTypeImportsType(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /c.ts
+export interface Foo { value: number }
+// @Filename: /b.ts
+import { Foo } from "./c";
+export function make(): Foo {
+    return { value: 1 };
+}
+// @Filename: /a.ts
+import { make } from "./b";
+export function /*a*/f() {
+    return make();
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "a")
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.rewrite.function.returnType",
+		Description: "Infer function return type",
+		NewFileContent: `import { make } from "./b";
+import { Foo } from "./c";
+export function f(): Foo {
+    return make();
+}`,
+	})
+}
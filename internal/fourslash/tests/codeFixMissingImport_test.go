@@ -0,0 +1,81 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestCodeFixMissingImport(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export function join(a: string, b: string) {
+    return a + b;
+}
+// @Filename: /b.ts
+join("x", "y");`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/b.ts")
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: `Import 'join' from "./a"`,
+		NewFileContent: `import { join } from "./a";
+join("x", "y");`,
+	})
+}
+
+func TestAddMissingImports(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export const a1 = 1;
+export const a2 = 2;
+export interface Options {}
+// @Filename: /b.ts
+export default function b() {}
+export const unused = 0;
+// @Filename: /c.ts
+export const c = 0;
+// @Filename: /d.ts
+import { c } from "./c";
+const options: Options = {};
+b(a1, a2, c, a1, d);
+// @Filename: /e.ts
+export const d = 0;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/d.ts")
+	f.VerifySourceAction(t, ls.CodeActionKindSourceAddMissingImports, nil /*preferences*/, `import { c } from "./c";
+import { a1, a2, Options } from "./a";
+import b from "./b";
+import { d } from "./e";
+const options: Options = {};
+b(a1, a2, c, a1, d);`)
+}
+
+func TestFixAll(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function f() {
+    await g();
+    await g();
+}
+async function g() {
+    const x = await 1;
+    const y = await 2;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifySourceAction(t, lsproto.CodeActionKindSourceFixAll, nil /*preferences*/, `async function f() {
+    await g();
+    await g();
+}
+async function g() {
+    const x = 1;
+    const y = 2;
+}`)
+}
@@ -0,0 +1,24 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestCodeFixRemoveUnnecessaryAwait(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `export async function f() {
+    const x = await 1;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyCodeFix(t, fourslash.VerifyCodeFixOptions{
+		Description: "Remove unnecessary 'await'",
+		NewFileContent: `export async function f() {
+    const x = 1;
+}`,
+	})
+}
@@ -0,0 +1,58 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestCodeLens(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export interface Shape {
+    area(): number;
+}
+export abstract class Base implements Shape {
+    abstract area(): number;
+    describe() {
+        return "area " + this.area();
+    }
+}
+export class Square extends Base {
+    constructor(private size: number) {
+        super();
+    }
+    area() {
+        return this.size * this.size;
+    }
+}
+export function unused() {}
+export function overloaded(x: string): void;
+export function overloaded(x: number): void;
+export function overloaded(x: any) {}
+function internal() {}
+export namespace N {
+    export function nested() {}
+}
+// @Filename: /b.ts
+import { Shape, Square, overloaded } from "./a";
+const s: Shape = new Square(1);
+overloaded(1);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.ts")
+	f.VerifyCodeLenses(t, []string{
+		"Shape: 3 references",
+		"Shape: 2 implementations",
+		"Base: 1 reference",
+		"Base: 1 implementation",
+		"area: 1 reference",
+		"area: 1 implementation",
+		"Square: 2 references",
+		"unused: 0 references",
+		"overloaded: 2 references",
+		"nested: 0 references",
+	})
+}
@@ -0,0 +1,42 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestConfigureJavaScriptQuoteStyle(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @allowJs: true
+// @Filename: /a.ts
+export const someVar = 10;
+
+// @Filename: /b.ts
+export const anotherVar = 10;
+
+// @Filename: /c.ts
+export {};
+anoth/*1*/
+
+// @Filename: /d.js
+export {};
+anoth/*2*/
+`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	// TypeScript files use the "typescript" settings and JavaScript files the "javascript" settings.
+	f.ConfigureLanguages(t, &ls.UserPreferences{
+		QuotePreference:                       ls.QuotePreferenceDouble,
+		IncludeCompletionsForModuleExports:    core.TSTrue,
+		IncludeCompletionsForImportStatements: core.TSTrue,
+	}, &ls.UserPreferences{
+		QuotePreference:                       ls.QuotePreferenceSingle,
+		IncludeCompletionsForModuleExports:    core.TSTrue,
+		IncludeCompletionsForImportStatements: core.TSTrue,
+	})
+	f.BaselineAutoImportsCompletions(t, []string{"1", "2"})
+}
@@ -0,0 +1,40 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestConfigureQuoteStyle(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export const someVar = 10;
+
+// @Filename: /b.ts
+export const anotherVar = 10;
+
+// @Filename: /c.ts
+import { someVar } from "./a";
+someVar;
+anoth/*1*/
+
+// @Filename: /d.ts
+import { someVar } from './a';
+someVar;
+anoth/*2*/
+`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	// The quotes of existing imports are used when there is no preference.
+	f.BaselineAutoImportsCompletions(t, []string{"1", "2"})
+	f.Configure(t, &ls.UserPreferences{
+		QuotePreference:                       ls.QuotePreferenceSingle,
+		IncludeCompletionsForModuleExports:    core.TSTrue,
+		IncludeCompletionsForImportStatements: core.TSTrue,
+	})
+	f.BaselineAutoImportsCompletions(t, []string{"1"})
+}
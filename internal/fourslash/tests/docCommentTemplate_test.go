@@ -0,0 +1,71 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	. "github.com/microsoft/typescript-go/internal/fourslash/tests/util"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestDocCommentTemplate(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @allowJs: true
+// @Filename: /a.ts
+[|/** /*1*/ */|]
+function add(a: number, { b, c }: { b: number; c: number }): number {
+    return a + b + c;
+}
+class C {
+    [|/** /*2*/ */|]
+    log(message: string, ...rest: unknown[]): void {}
+    [|/** /*3*/ */|]
+    async load(): Promise<void> {}
+}
+[|/** /*4*/ */|]
+interface I {}
+/** Already documented. */
+function documented(x: number) {}
+/** /*5*/ */
+documented(1);
+// @Filename: /b.js
+[|/** /*6*/ */|]
+export const f = (name, count = 1) => name.repeat(count);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	ranges := f.Ranges()
+	expected := func(rangeIndex int, newText string) *fourslash.CompletionsExpectedList {
+		return &fourslash.CompletionsExpectedList{
+			Items: &fourslash.CompletionsExpectedItems{
+				Exact: []fourslash.CompletionsExpectedItem{
+					&lsproto.CompletionItem{
+						Label:            "/** */",
+						Kind:             PtrTo(lsproto.CompletionItemKindText),
+						InsertTextFormat: PtrTo(lsproto.InsertTextFormatSnippet),
+						TextEdit: &lsproto.TextEditOrInsertReplaceEdit{
+							TextEdit: &lsproto.TextEdit{
+								Range:   ranges[rangeIndex].LSRange,
+								NewText: newText,
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	f.VerifyCompletions(t, "1", expected(0, "/**\n * $0\n * @param a ${1}\n * @param param1 ${2}\n * @returns ${3}\n */"))
+	f.VerifyCompletions(t, "2", expected(1, "/**\n     * $0\n     * @param message ${1}\n     * @param rest ${2}\n     */"))
+	f.VerifyCompletions(t, "3", expected(2, "/** $0 */"))
+	f.VerifyCompletions(t, "4", expected(3, "/** $0 */"))
+	f.VerifyCompletions(t, "5", &fourslash.CompletionsExpectedList{
+		ItemDefaults: &fourslash.CompletionsExpectedItemDefaults{
+			CommitCharacters: &DefaultCommitCharacters,
+		},
+		Items: &fourslash.CompletionsExpectedItems{
+			Excludes: []string{"/** */"},
+		},
+	})
+	f.VerifyCompletions(t, "6", expected(4, "/**\n * $0\n * @param {${1:*}} name ${2}\n * @param {number} [count=1] ${3}\n * @returns ${4}\n */"))
+}
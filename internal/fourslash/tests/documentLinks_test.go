@@ -0,0 +1,83 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestDocumentLinks(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @allowJs: true
+// @Filename: /a.ts
+/// <reference path="./globals.d.ts" />
+/// <reference types="node" />
+import { b } from "./b";
+export * from "./c";
+import type { D } from "pkg";
+import { missing } from "./missing";
+const lazy = import("./b");
+type T = import("./c").C;
+// @Filename: /b.ts
+export const b = 1;
+// @Filename: /c.js
+const c = require("./b");
+module.exports = { c };
+// @Filename: /globals.d.ts
+declare var g: number;
+// @Filename: /node_modules/@types/node/index.d.ts
+declare var process: any;
+// @Filename: /node_modules/pkg/index.d.ts
+export interface D {}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.ts")
+	f.VerifyDocumentLinks(t, []string{
+		"./b -> /b.ts",
+		"./c -> /c.js",
+		"pkg -> /node_modules/pkg/index.d.ts",
+		"./b -> /b.ts",
+		"./c -> /c.js",
+		"./globals.d.ts -> /globals.d.ts",
+		"node -> /node_modules/@types/node/index.d.ts",
+	})
+	f.GoToFile(t, "/c.js")
+	f.VerifyDocumentLinks(t, []string{
+		"./b -> /b.ts",
+	})
+}
+
+func TestDocumentLinksConfigFile(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /project/tsconfig.json
+{
+    "extends": ["./tsconfig.base", "@org/config/tsconfig.json"],
+    "references": [
+        { "path": "../lib" },
+        { "path": "../other/tsconfig.build.json" },
+        { "path": "../missing" }
+    ]
+}
+// @Filename: /project/tsconfig.base.json
+{ "compilerOptions": { "strict": true } }
+// @Filename: /project/node_modules/@org/config/tsconfig.json
+{}
+// @Filename: /lib/tsconfig.json
+{ "compilerOptions": { "composite": true } }
+// @Filename: /other/tsconfig.build.json
+{ "compilerOptions": { "composite": true } }
+// @Filename: /project/index.ts
+export {};`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/project/tsconfig.json")
+	f.VerifyDocumentLinks(t, []string{
+		"./tsconfig.base -> /project/tsconfig.base.json",
+		"@org/config/tsconfig.json -> /project/node_modules/@org/config/tsconfig.json",
+		"../lib -> /lib/tsconfig.json",
+		"../other/tsconfig.build.json -> /other/tsconfig.build.json",
+	})
+}
@@ -0,0 +1,58 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestFoldingRange(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.tsx
+import { a } from "./b";
+import {
+    b,
+    c,
+} from "./c";
+
+// #region Helpers
+/**
+ * Adds two numbers.
+ */
+function add(x: number, y: number) {
+    return x + y;
+}
+// #endregion
+
+// First line
+// Second line
+const config = {
+    values: [
+        1,
+        2,
+    ],
+};
+
+const element = (
+    <div>
+        <span />
+    </div>
+);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyFoldingRanges(t, []fourslash.FoldingRange{
+		{StartLine: 0, EndLine: 4, Kind: lsproto.FoldingRangeKindImports},
+		{StartLine: 1, EndLine: 3},
+		{StartLine: 6, EndLine: 13, Kind: lsproto.FoldingRangeKindRegion},
+		{StartLine: 7, EndLine: 9, Kind: lsproto.FoldingRangeKindComment},
+		{StartLine: 10, EndLine: 11},
+		{StartLine: 15, EndLine: 16, Kind: lsproto.FoldingRangeKindComment},
+		{StartLine: 17, EndLine: 21},
+		{StartLine: 18, EndLine: 20},
+		{StartLine: 24, EndLine: 27},
+		{StartLine: 25, EndLine: 26},
+	})
+}
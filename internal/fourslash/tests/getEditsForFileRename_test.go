@@ -0,0 +1,80 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestGetEditsForFileRename(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /tsconfig.json
+{ "files": ["src/a.ts", "src/b.ts", "src/c.ts"] }
+// @Filename: /src/a.ts
+import { c } from "./c";
+export const a = c;
+// @Filename: /src/b.ts
+/// <reference path="./a.ts" />
+import { a } from "./a";
+export { a as b } from "./a";
+// @Filename: /src/c.ts
+export const c = 0;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyWillRenameFile(t, "/src/a.ts", "/src/lib/a.ts", nil /*preferences*/, map[string]string{
+		"/tsconfig.json": `{ "files": ["src/lib/a.ts", "src/b.ts", "src/c.ts"] }`,
+		"/src/a.ts": `import { c } from "../c";
+export const a = c;`,
+		"/src/b.ts": `/// <reference path="./lib/a.ts" />
+import { a } from "./lib/a";
+export { a as b } from "./lib/a";`,
+		"/src/c.ts": `export const c = 0;`,
+	})
+}
+
+func TestGetEditsForFileRenameDirectory(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /src/utils/strings.ts
+import { max } from "./math";
+import { app } from "../app";
+export const pad = (s: string) => s.padStart(max) + app;
+// @Filename: /src/utils/math.ts
+export const max = 10;
+// @Filename: /src/app.ts
+import { pad } from "./utils/strings";
+export const app = "";
+pad(app);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyWillRenameFile(t, "/src/utils", "/src/shared/helpers", nil /*preferences*/, map[string]string{
+		"/src/utils/strings.ts": `import { max } from "./math";
+import { app } from "../../app";
+export const pad = (s: string) => s.padStart(max) + app;`,
+		"/src/app.ts": `import { pad } from "./shared/helpers/strings";
+export const app = "";
+pad(app);`,
+	})
+}
+
+func TestGetEditsForFileRenameNonRelativePreference(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /tsconfig.json
+{ "compilerOptions": { "paths": { "@/*": ["./src/*"] } } }
+// @Filename: /src/a.ts
+export const a = 0;
+// @Filename: /src/b.ts
+import { a } from "./a";`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyWillRenameFile(t, "/src/a.ts", "/src/lib/a.ts", &ls.UserPreferences{
+		ImportModuleSpecifierPreference: modulespecifiers.ImportModuleSpecifierPreferenceNonRelative,
+	}, map[string]string{
+		"/src/b.ts": `import { a } from "@/lib/a";`,
+	})
+}
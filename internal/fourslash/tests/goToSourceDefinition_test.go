@@ -0,0 +1,61 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestGoToSourceDefinitionExports(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @moduleResolution: bundler
+// @module: esnext
+// @Filename: /node_modules/pkg/package.json
+{ "name": "pkg", "exports": { ".": { "types": "./dist/index.d.ts", "default": "./dist/index.js" } } }
+// @Filename: /node_modules/pkg/dist/index.d.ts
+export declare function foo(): void;
+export declare class Bar {
+    baz(): void;
+}
+// @Filename: /node_modules/pkg/dist/index.js
+export function /*foo*/foo() {}
+export class /*Bar*/Bar {
+    /*baz*/baz() {}
+}
+// @Filename: /a.ts
+import { foo, Bar } from "pkg";
+/*1*/foo();
+new /*2*/Bar()./*3*/baz();
+function /*local*/local() {}
+/*4*/local();`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "1")
+	f.VerifyGoToSourceDefinition(t, "foo")
+	f.GoToMarker(t, "2")
+	f.VerifyGoToSourceDefinition(t, "Bar")
+	f.GoToMarker(t, "3")
+	f.VerifyGoToSourceDefinition(t, "baz")
+	f.GoToMarker(t, "4")
+	f.VerifyGoToSourceDefinition(t, "local")
+}
+
+func TestGoToSourceDefinitionTypesPackage(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /node_modules/@types/lib/index.d.ts
+export declare function helper(): number;
+// @Filename: /node_modules/lib/package.json
+{ "name": "lib", "main": "lib/main.js" }
+// @Filename: /node_modules/lib/lib/main.js
+exports./*helper*/helper = function () { return 1; };
+// @Filename: /a.ts
+import * as lib from "lib";
+lib./*1*/helper();`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "1")
+	f.VerifyGoToSourceDefinition(t, "helper")
+}
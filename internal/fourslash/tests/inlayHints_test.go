@@ -0,0 +1,67 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestInlayHints(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function add(first: number, second: number)/*addReturn*/ {
+    return first + second;
+}
+function sum(...values: number[])/*sumReturn*/ {
+    return values.length;
+}
+const second = 2;
+const total/*total*/ = add(/*first*/1, second);
+const count/*count*/ = sum(/*values*/1, 2, 3);
+const literal = 1;
+[1, 2].map(/*callbackfn*/(n/*n*/)/*ret*/ => n * 2);
+enum E {
+    A/*A*/,
+    B/*B*/,
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyInlayHints(t, &ls.UserPreferences{
+		IncludeInlayParameterNameHints:          ls.IncludeInlayParameterNameHintsAll,
+		IncludeInlayVariableTypeHints:           true,
+		IncludeInlayFunctionParameterTypeHints:  true,
+		IncludeInlayFunctionLikeReturnTypeHints: true,
+		IncludeInlayEnumMemberValueHints:        true,
+	}, map[string]string{
+		"total":      ": number",
+		"first":      "first:",
+		"count":      ": number",
+		"values":     "...values:",
+		"n":          ": number",
+		"ret":        ": number",
+		"A":          "= 0",
+		"B":          "= 1",
+		"addReturn":  ": number",
+		"sumReturn":  ": number",
+		"callbackfn": "callbackfn:",
+	})
+}
+
+func TestInlayHintsComputedPropertyName(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `const k = "key";
+class C {
+    [k]/*computed*/ = 1;
+    number/*number*/ = 2;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyInlayHints(t, &ls.UserPreferences{
+		IncludeInlayPropertyDeclarationTypeHints: true,
+	}, map[string]string{
+		"computed": ": number",
+	})
+}
@@ -0,0 +1,96 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestLinkedEditingJsxTags(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @jsx: preserve
+// @Filename: /a.tsx
+const a = <[|d/*1*/iv|] className="x"><span>te/*3*/xt</span></[|div/*2*/|]>;
+// @Filename: /b.tsx
+const b = <[||]/*4*/><p /></[||]/*5*/>;
+// @Filename: /c.tsx
+const c = </*6*/>/*7*/<p /></>;
+// @Filename: /d.tsx
+const d = <[|Foo.B/*8*/ar|]></[|Foo.Bar|]>;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	ranges := f.Ranges()
+	f.GoToMarker(t, "1")
+	f.VerifyLinkedEditingRange(t, ranges[0:2])
+	f.GoToMarker(t, "2")
+	f.VerifyLinkedEditingRange(t, ranges[0:2])
+	f.GoToMarker(t, "3")
+	f.VerifyLinkedEditingRange(t, nil)
+	f.GoToMarker(t, "4")
+	f.VerifyLinkedEditingRange(t, ranges[2:4])
+	f.GoToMarker(t, "5")
+	f.VerifyLinkedEditingRange(t, ranges[2:4])
+	f.GoToMarker(t, "6")
+	f.VerifyLinkedEditingRange(t, ranges[2:4])
+	f.GoToMarker(t, "7")
+	f.VerifyLinkedEditingRange(t, nil)
+	f.GoToMarker(t, "8")
+	f.VerifyLinkedEditingRange(t, ranges[4:6])
+}
+
+func TestJsxClosingTag(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @jsx: preserve
+// @Filename: /0.tsx
+const x = <div>/*0*/;
+// @Filename: /1.tsx
+const x = <div> foo/*1*/ </div>;
+// @Filename: /2.tsx
+const x = <div></div>/*2*/;
+// @Filename: /3.tsx
+const x = <div/>/*3*/;
+// @Filename: /4.tsx
+const x = <div>
+    <p>/*4*/
+    </div>
+</p>;
+// @Filename: /5.tsx
+const x = <div> text /*5*/;
+// @Filename: /6.tsx
+const x = <div>
+    <div>/*6*/
+</div>;
+// @Filename: /7.tsx
+const x = <div>
+    <div>/*7*/</div>
+</div>;
+// @Filename: /8.tsx
+const x = <>/*8*/;
+// @Filename: /9.tsx
+const x = <><p>/*9*/</p></>;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "0")
+	f.VerifyJsxClosingTag(t, "</div>")
+	f.GoToMarker(t, "1")
+	f.VerifyJsxClosingTag(t, "")
+	f.GoToMarker(t, "2")
+	f.VerifyJsxClosingTag(t, "")
+	f.GoToMarker(t, "3")
+	f.VerifyJsxClosingTag(t, "")
+	f.GoToMarker(t, "4")
+	f.VerifyJsxClosingTag(t, "</p>")
+	f.GoToMarker(t, "5")
+	f.VerifyJsxClosingTag(t, "</div>")
+	f.GoToMarker(t, "6")
+	f.VerifyJsxClosingTag(t, "</div>")
+	f.GoToMarker(t, "7")
+	f.VerifyJsxClosingTag(t, "")
+	f.GoToMarker(t, "8")
+	f.VerifyJsxClosingTag(t, "</>")
+	f.GoToMarker(t, "9")
+	f.VerifyJsxClosingTag(t, "")
+}
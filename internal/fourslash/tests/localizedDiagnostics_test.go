@@ -0,0 +1,26 @@
+package fourslash_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/bundled"
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestLocalizedDiagnostics(t *testing.T) {
+	t.Parallel()
+	if !slices.Contains(bundled.LocaleNames, "ja") {
+		t.Skip("no translations are bundled for locale 'ja'")
+	}
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @locale: ja
+// @Filename: /a.ts
+export const a: string = 1;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifyWorkspaceDiagnostics(t, map[string][]string{
+		"/a.ts": {"型 'number' を型 'string' に割り当てることはできません。"},
+	}, nil)
+}
@@ -0,0 +1,158 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestOrganizeImports(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+// Header comment
+import { b, a as a } from "./lib";
+import { unused } from "./lib";
+import "./polyfill";
+// Comment on c
+import c from "c"; // Trailing comment on c
+import type { T } from "./lib";
+import { d } from "./lib";
+
+export const x: T = a + b + c + d;
+// @Filename: /lib.ts
+export const a = 1, b = 2, d = 3, unused = 4;
+export type T = number;
+// @Filename: /polyfill.ts
+export {};
+// @Filename: /c.d.ts
+declare module "c" {
+    const c: number;
+    export default c;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.ts")
+	f.VerifySourceAction(t, lsproto.CodeActionKindSourceOrganizeImports, nil /*preferences*/, `// Header comment
+// Comment on c
+import c from "c"; // Trailing comment on c
+import type { T } from "./lib";
+import { a, b, d } from "./lib";
+import "./polyfill";
+
+export const x: T = a + b + c + d;`)
+}
+
+func TestOrganizeImportsRemoveUnused(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+import { z, y } from "./lib";
+import * as ns from "./lib";
+import def, { x } from "./lib";
+z();
+// @Filename: /lib.ts
+export default function def() {}
+export function x() {}
+export function y() {}
+export function z() {}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.ts")
+	f.VerifySourceAction(t, ls.CodeActionKindSourceRemoveUnusedImports, nil /*preferences*/, `import { z } from "./lib";
+z();`)
+}
+
+func TestOrganizeImportsSortOnly(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+import { unused } from "./b";
+import {
+    y,
+    x,
+} from "./a";
+
+import { q } from "./c";
+import { p } from "./b";
+// @Filename: /a/index.ts
+export const x = 1, y = 2;
+// @Filename: /b.ts
+export const p = 1, unused = 2;
+// @Filename: /c.ts
+export const q = 1;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.ts")
+	f.VerifySourceAction(t, ls.CodeActionKindSourceSortImports, nil /*preferences*/, `import {
+    x,
+    y
+} from "./a";
+import { unused } from "./b";
+
+import { p } from "./b";
+import { q } from "./c";`)
+}
+
+func TestOrganizeImportsExports(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export { b } from "./lib";
+export * from "./other";
+export { a } from "./lib";
+export type { T } from "./lib";
+// @Filename: /lib.ts
+export const a = 1, b = 2;
+export type T = number;
+// @Filename: /other.ts
+export const c = 1;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.ts")
+	f.VerifySourceAction(t, lsproto.CodeActionKindSourceOrganizeImports, nil /*preferences*/, `export { a, b } from "./lib";
+export type { T } from "./lib";
+export * from "./other";`)
+}
+
+func TestOrganizeImportsCaseSensitivity(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+import { b, B, a, A } from "./lib";
+console.log(a, A, b, B);
+// @Filename: /lib.ts
+export const a = 1, A = 2, b = 3, B = 4;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.ts")
+	f.VerifySourceAction(t, lsproto.CodeActionKindSourceOrganizeImports, &ls.UserPreferences{
+		OrganizeImportsIgnoreCase: core.TSFalse,
+	}, `import { A, B, a, b } from "./lib";
+console.log(a, A, b, B);`)
+}
+
+func TestOrganizeImportsUnicodeCollation(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+import { a10, a2, B, b, a1 } from "./lib";
+console.log(a10, a2, B, b, a1);
+// @Filename: /lib.ts
+export const a1 = 1, a2 = 2, a10 = 3, b = 4, B = 5;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToFile(t, "/a.ts")
+	f.VerifySourceAction(t, lsproto.CodeActionKindSourceOrganizeImports, &ls.UserPreferences{
+		OrganizeImportsIgnoreCase:       core.TSFalse,
+		OrganizeImportsCollation:        ls.OrganizeImportsCollationUnicode,
+		OrganizeImportsLocale:           "en",
+		OrganizeImportsNumericCollation: true,
+		OrganizeImportsCaseFirst:        ls.OrganizeImportsCaseFirstUpper,
+	}, `import { a1, a2, a10, B, b } from "./lib";
+console.log(a10, a2, B, b, a1);`)
+}
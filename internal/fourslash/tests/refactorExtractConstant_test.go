@@ -0,0 +1,85 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestRefactorExtractConstant(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function f(a: number) {
+    const b = 1;
+    return /*start*/a * 2 + b/*end*/;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactorsAvailable(t, "refactor.extract.constant", []string{
+		"Extract to constant in enclosing scope",
+	})
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.extract.constant",
+		Description: "Extract to constant in enclosing scope",
+		NewFileContent: `function f(a: number) {
+    const b = 1;
+    const newLocal = a * 2 + b;
+    return newLocal;
+}`,
+	})
+}
+
+func TestRefactorExtractConstantToClass(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `class C {
+    x = 1;
+    m() {
+        return /*start*/[1, 2, 3]/*end*/.length;
+    }
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactorsAvailable(t, "refactor.extract.constant", []string{
+		"Extract to constant in enclosing scope",
+		"Extract to readonly field in class 'C'",
+		"Extract to constant in global scope",
+	})
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.extract.constant",
+		Description: "Extract to readonly field in class 'C'",
+		NewFileContent: `class C {
+    x = 1;
+    private readonly newProperty = [1, 2, 3];
+
+    m() {
+        return this.newProperty.length;
+    }
+}`,
+	})
+}
+
+func TestRefactorExtractConstantThis(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function f(this: { x: number }) {
+    return /*start*/this.x/*end*/ + 1;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactorsAvailable(t, "refactor.extract", []string{
+		"Extract to constant in enclosing scope",
+	})
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.extract.constant",
+		Description: "Extract to constant in enclosing scope",
+		NewFileContent: `function f(this: { x: number }) {
+    const newLocal = this.x;
+    return newLocal + 1;
+}`,
+	})
+}
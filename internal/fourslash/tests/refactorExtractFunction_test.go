@@ -0,0 +1,147 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestRefactorExtractFunction(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function f(a: number) {
+    let b = 1;
+    /*start*/const c = a + b;
+    b = c * 2;/*end*/
+    return b;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactorsAvailable(t, "refactor.extract", []string{
+		"Extract to inner function in function 'f'",
+		"Extract to function in global scope",
+	})
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.extract.function",
+		Description: "Extract to function in global scope",
+		NewFileContent: `function f(a: number) {
+    let b = 1;
+    b = newFunction(a, b);
+    return b;
+}
+
+function newFunction(a: number, b: number) {
+    const c = a + b;
+    b = c * 2;
+    return b;
+}
+`,
+	})
+}
+
+func TestRefactorExtractFunctionReturn(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `class C {
+    x = 1;
+    async m(y: number) {
+        /*start*/await Promise.resolve();
+        if (y > this.x) {
+            return y;
+        }
+        return this.x;/*end*/
+    }
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactorsAvailable(t, "refactor.extract.function", []string{
+		"Extract to method in class 'C'",
+	})
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.extract.function",
+		Description: "Extract to method in class 'C'",
+		NewFileContent: `class C {
+    x = 1;
+    async m(y: number) {
+        return await this.newMethod(y);
+    }
+
+    private async newMethod(y: number) {
+        await Promise.resolve();
+        if (y > this.x) {
+            return y;
+        }
+        return this.x;
+    }
+}`,
+	})
+}
+
+func TestRefactorExtractFunctionExposedDeclaration(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `export function f<T>(items: T[]) {
+    /*start*/const first = items[0];
+    const count = items.length;/*end*/
+    console.log(first, count);
+}
+
+function g() {
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.extract.function",
+		Description: "Extract to function in module scope",
+		NewFileContent: `export function f<T>(items: T[]) {
+    const { first, count } = newFunction<T>(items);
+    console.log(first, count);
+}
+
+function newFunction<T>(items: T[]) {
+    const first = items[0];
+    const count = items.length;
+    return { first, count };
+}
+
+function g() {
+}`,
+	})
+}
+
+func TestRefactorExtractFunctionUnavailable(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function f(xs: number[]) {
+    for (const x of xs) {
+        /*start*/if (x > 1) {
+            break;
+        }/*end*/
+    }
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactorsAvailable(t, "refactor.extract", nil)
+}
+
+func TestRefactorExtractFunctionWriteInExpression(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function f() {
+    let x = 1;
+    const y = /*start*/x++/*end*/;
+    return x + y;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	// Only a function that can write to x itself is offered; no constant is, because x++ would run earlier.
+	f.VerifyRefactorsAvailable(t, "refactor.extract", []string{
+		"Extract to inner function in function 'f'",
+	})
+}
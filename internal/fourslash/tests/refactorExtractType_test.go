@@ -0,0 +1,44 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestRefactorExtractType(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `interface Box<T extends object> {
+    value: /*start*/{ item: T; count: number }/*end*/;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactor(t, fourslash.VerifyRefactorOptions{
+		Kind:        "refactor.extract.type",
+		Description: "Extract to type alias",
+		NewFileContent: `type NewType<T extends object> = {
+    item: T;
+    count: number;
+};
+
+interface Box<T extends object> {
+    value: NewType<T>;
+}`,
+	})
+}
+
+func TestRefactorExtractTypeUnavailable(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `function f() {
+    const x = 1;
+    let y: /*start*/typeof x/*end*/;
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactorsAvailable(t, "refactor.extract.type", nil)
+}
@@ -0,0 +1,115 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestRefactorMoveToNewFile(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+import { join } from "./path";
+const sep = "/";
+/*start*/export function combine(a: string, b: string) {
+    return join(a, b) + sep;
+}/*end*/
+combine("x", "y");
+// @Filename: /path.ts
+export function join(a: string, b: string) {
+    return a + b;
+}
+// @Filename: /b.ts
+import { combine } from "./a";
+combine("1", "2");
+// @Filename: /c.ts
+import { combine, sep } from "./a";
+combine(sep, sep);`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyMoveToFile(t, fourslash.VerifyMoveToFileOptions{
+		NewFileContents: map[string]string{
+			"/a.ts": `import { combine } from "./combine";
+export const sep = "/";
+combine("x", "y");`,
+			"/combine.ts": `import { join } from "./path";
+import { sep } from "./a";
+
+export function combine(a: string, b: string) {
+    return join(a, b) + sep;
+}
+`,
+			"/b.ts": `import { combine } from "./combine";
+combine("1", "2");`,
+			"/c.ts": `import { sep } from "./a";
+import { combine } from "./combine";
+combine(sep, sep);`,
+		},
+	})
+}
+
+func TestRefactorMoveToExistingFile(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+import { helper, other } from "./b";
+/*start*/export interface Options {
+    verbose: boolean;
+}
+
+export function run(options: Options) {
+    return helper(options.verbose);
+}/*end*/
+other();
+// @Filename: /b.ts
+export function helper(verbose: boolean) {
+    return verbose;
+}
+export function other() {}
+// @Filename: /c.ts
+import { run, type Options } from "./a";
+import * as a from "./a";
+run({ verbose: true });
+a.run({ verbose: false });`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyMoveToFile(t, fourslash.VerifyMoveToFileOptions{
+		TargetFile: "/b.ts",
+		NewFileContents: map[string]string{
+			"/a.ts": `import { other } from "./b";
+other();`,
+			"/b.ts": `export function helper(verbose: boolean) {
+    return verbose;
+}
+export function other() {}
+
+export interface Options {
+    verbose: boolean;
+}
+
+export function run(options: Options) {
+    return helper(options.verbose);
+}
+`,
+			"/c.ts": `import { run, type Options } from "./b";
+import * as a from "./b";
+run({ verbose: true });
+a.run({ verbose: false });`,
+		},
+	})
+}
+
+func TestRefactorMoveToFileUnavailable(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `/*start*/import { x } from "./b";/*end*/
+export const y = x;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToSelect(t, "start", "end")
+	f.VerifyRefactorsAvailable(t, "refactor.move", nil)
+}
@@ -0,0 +1,40 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestSelectionRange(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `/** Greets someone. */
+function greet(name: string) {
+    console.log("Hello, /*1*/" + name);
+}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "1")
+	f.VerifySelectionRanges(t, []string{
+		`Hello, `,
+		`"Hello, "`,
+		`"Hello, " + name`,
+		`console.log("Hello, " + name)`,
+		`console.log("Hello, " + name);`,
+		`
+    console.log("Hello, " + name);
+`,
+		`{
+    console.log("Hello, " + name);
+}`,
+		`function greet(name: string) {
+    console.log("Hello, " + name);
+}`,
+		`/** Greets someone. */
+function greet(name: string) {
+    console.log("Hello, " + name);
+}`,
+	})
+}
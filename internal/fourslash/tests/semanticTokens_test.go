@@ -0,0 +1,68 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestSemanticTokens(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `interface Shape { readonly area: number }
+type Point = { x: number };
+enum Color { Red }
+namespace NS { export const value = 1; }
+class Circle implements Shape {
+    static count = 0;
+    constructor(private radius: number) {}
+    get area() { return this.radius * Math.PI; }
+    async grow<T>(by: T) {
+        let next = by;
+        function local() {}
+        return next;
+    }
+}
+const factory = Circle;
+const p: Point = { x: NS.value };
+const c = Color.Red;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.VerifySemanticTokens(t, []fourslash.SemanticToken{
+		{Type: "interface.declaration", Text: "Shape"},
+		{Type: "property.declaration.readonly", Text: "area"},
+		{Type: "type.declaration", Text: "Point"},
+		{Type: "property.declaration", Text: "x"},
+		{Type: "enum.declaration", Text: "Color"},
+		{Type: "enumMember.declaration.readonly", Text: "Red"},
+		{Type: "namespace.declaration", Text: "NS"},
+		{Type: "variable.declaration.readonly.local", Text: "value"},
+		{Type: "class.declaration", Text: "Circle"},
+		{Type: "interface", Text: "Shape"},
+		{Type: "property.declaration.static", Text: "count"},
+		{Type: "parameter.declaration", Text: "radius"},
+		{Type: "property.declaration", Text: "area"},
+		{Type: "property", Text: "radius"},
+		{Type: "variable.defaultLibrary", Text: "Math"},
+		{Type: "property.readonly.defaultLibrary", Text: "PI"},
+		{Type: "method.declaration.async", Text: "grow"},
+		{Type: "typeParameter.declaration", Text: "T"},
+		{Type: "parameter.declaration", Text: "by"},
+		{Type: "typeParameter", Text: "T"},
+		{Type: "variable.declaration.local", Text: "next"},
+		{Type: "parameter", Text: "by"},
+		{Type: "function.declaration.local", Text: "local"},
+		{Type: "variable.local", Text: "next"},
+		{Type: "class.declaration", Text: "factory"},
+		{Type: "class", Text: "Circle"},
+		{Type: "variable.declaration.readonly", Text: "p"},
+		{Type: "type", Text: "Point"},
+		{Type: "property.declaration", Text: "x"},
+		{Type: "namespace", Text: "NS"},
+		{Type: "variable.readonly.local", Text: "value"},
+		{Type: "variable.declaration.readonly", Text: "c"},
+		{Type: "enum", Text: "Color"},
+		{Type: "enumMember.readonly", Text: "Red"},
+	})
+}
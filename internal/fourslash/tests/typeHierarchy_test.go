@@ -0,0 +1,53 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestTypeHierarchy(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /plugin.ts
+export interface /*plugin*/Plugin {
+    name: string;
+}
+export interface Disposable {
+    dispose(): void;
+}
+export interface /*named*/NamedPlugin extends Plugin {}
+
+// @Filename: /impl.ts
+import { Plugin, Disposable, NamedPlugin } from "./plugin";
+abstract class /*base*/BasePlugin implements Plugin, Disposable {
+    name = "base";
+    dispose() {}
+}
+class LoggingPlugin extends BasePlugin {}
+class CachingPlugin extends BasePlugin implements NamedPlugin {}`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.GoToMarker(t, "plugin")
+	f.VerifyTypeHierarchy(t, &fourslash.VerifyTypeHierarchyOptions{
+		Name:     "Plugin",
+		Kind:     lsproto.SymbolKindInterface,
+		Subtypes: []string{"NamedPlugin", "BasePlugin"},
+	})
+	f.GoToMarker(t, "named")
+	f.VerifyTypeHierarchy(t, &fourslash.VerifyTypeHierarchyOptions{
+		Name:       "NamedPlugin",
+		Kind:       lsproto.SymbolKindInterface,
+		Supertypes: []string{"Plugin"},
+		Subtypes:   []string{"CachingPlugin"},
+	})
+	f.GoToMarker(t, "base")
+	f.VerifyTypeHierarchy(t, &fourslash.VerifyTypeHierarchyOptions{
+		Name:       "BasePlugin",
+		Kind:       lsproto.SymbolKindClass,
+		Supertypes: []string{"Plugin", "Disposable"},
+		Subtypes:   []string{"LoggingPlugin", "CachingPlugin"},
+	})
+}
@@ -0,0 +1,50 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/fourslash"
+	"github.com/microsoft/typescript-go/internal/testutil"
+)
+
+func TestWorkspaceDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+export const a = 1 as /*a*/number;
+// @Filename: /b.ts
+import { a } from "./a";
+export const b: string = a;
+// @Filename: /c.ts
+export const c = 0;
+// @Filename: /tsconfig.json
+{ "compilerOptions": { "strict": true } }`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	f.CloseFile(t, "/b.ts")
+	f.CloseFile(t, "/c.ts")
+	f.CloseFile(t, "/tsconfig.json")
+	f.VerifyWorkspaceDiagnostics(t, map[string][]string{
+		"/a.ts": nil,
+		"/b.ts": {"Type 'number' is not assignable to type 'string'."},
+		"/c.ts": nil,
+	}, nil)
+	f.VerifyWorkspaceDiagnostics(t, nil, []string{"/a.ts", "/b.ts", "/c.ts"})
+
+	// Editing a.ts changes the diagnostics of b.ts, which is not open, but not those of a.ts and c.ts.
+	f.GoToMarker(t, "a")
+	f.Insert(t, "string | ")
+	f.VerifyWorkspaceDiagnostics(t, map[string][]string{
+		"/b.ts": {"Type 'string | number' is not assignable to type 'string'.\n  Type 'number' is not assignable to type 'string'."},
+	}, []string{"/a.ts", "/c.ts"})
+
+	// c.ts is no longer part of the program, so its diagnostics are cleared. The open tsconfig.json is
+	// part of an inferred project.
+	f.GoToFile(t, "/tsconfig.json")
+	f.Replace(t, 0, len(`{ "compilerOptions": { "strict": true } }`), `{ "compilerOptions": { "strict": true }, "files": ["a.ts", "b.ts"] }`)
+	f.VerifyWorkspaceDiagnostics(t, map[string][]string{
+		"/c.ts":          nil,
+		"/tsconfig.json": nil,
+	}, []string{"/a.ts", "/b.ts"})
+	f.VerifyWorkspaceDiagnostics(t, nil, []string{"/a.ts", "/b.ts", "/tsconfig.json"})
+}
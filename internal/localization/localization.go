@@ -0,0 +1,77 @@
+// Package localization loads translated diagnostic messages.
+package localization
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs"
+	"golang.org/x/text/language"
+)
+
+// The file that holds the translated messages of a locale, in a directory named after the locale.
+const messagesFileName = "diagnosticMessages.generated.json"
+
+var localePattern = regexp.MustCompile(`^([a-z]+)(?:[_-]([a-z]+))?$`)
+
+// ParseLocale returns the language tag of a locale of the form <language> or <language>-<territory>, e.g. "ja" or
+// "zh-CN", or language.Und if it is not of that form.
+func ParseLocale(locale string) language.Tag {
+	if !localePattern.MatchString(strings.ToLower(locale)) {
+		return language.Und
+	}
+	tag, err := language.Parse(strings.ReplaceAll(locale, "_", "-"))
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
+
+// SetLanguage validates a locale and loads its translated messages from the diagnosticMessages.generated.json file
+// in the directory of the locale under directory, e.g. `ja-jp`, or in the directory of its language, e.g. `ja`. As
+// in the TypeScript package, the directory is the one the default library files are in. Messages without a
+// translation are in English, as are all messages if there is no file for the locale; that is reported with a
+// warning unless the locale is English.
+//
+// Only diagnostic messages are translated. Like the TypeScript language service, quick info and the other
+// language service results are not localized.
+func SetLanguage(locale string, fs vfs.FS, directory string) []*ast.Diagnostic {
+	tag := ParseLocale(locale)
+	if tag == language.Und {
+		return []*ast.Diagnostic{ast.NewCompilerDiagnostic(diagnostics.Locale_must_be_of_the_form_language_or_language_territory_For_example_0_or_1, "en", "ja-jp")}
+	}
+	match := localePattern.FindStringSubmatch(strings.ToLower(locale))
+	lang, territory := match[1], match[2]
+	var errors []*ast.Diagnostic
+	// Try the locale first, then its language alone.
+	if territory != "" && trySetLanguage(tag, lang+"-"+territory, fs, directory, &errors) {
+		return errors
+	}
+	if !trySetLanguage(tag, lang, fs, directory, &errors) && len(errors) == 0 && lang != "en" {
+		errors = append(errors, ast.NewCompilerDiagnostic(diagnostics.No_translations_of_diagnostic_messages_were_found_for_locale_0, locale))
+	}
+	return errors
+}
+
+func trySetLanguage(tag language.Tag, localeDirectory string, fs vfs.FS, directory string, errors *[]*ast.Diagnostic) bool {
+	filePath := tspath.CombinePaths(directory, localeDirectory, messagesFileName)
+	if !fs.FileExists(filePath) {
+		return false
+	}
+	contents, ok := fs.ReadFile(filePath)
+	if !ok {
+		*errors = append(*errors, ast.NewCompilerDiagnostic(diagnostics.Unable_to_open_file_0, filePath))
+		return false
+	}
+	var messages map[string]string
+	if err := json.Unmarshal([]byte(contents), &messages); err != nil {
+		*errors = append(*errors, ast.NewCompilerDiagnostic(diagnostics.Corrupted_locale_file_0, filePath))
+		return false
+	}
+	diagnostics.SetLocalizedMessages(tag, messages)
+	return true
+}
@@ -252,14 +252,14 @@ func (ct *changeTracker) makeImport(defaultImport *ast.IdentifierNode, namedImpo
 
 func (ct *changeTracker) getNewImports(
 	moduleSpecifier string,
-	// quotePreference quotePreference, // !!! quotePreference
+	quotePreference quotePreference,
 	defaultImport *Import,
 	namedImports []*Import,
 	namespaceLikeImport *Import, // { importKind: ImportKind.CommonJS | ImportKind.Namespace; }
 	compilerOptions *core.CompilerOptions,
 	preferences *UserPreferences,
 ) []*ast.Statement {
-	moduleSpecifierStringLiteral := ct.NodeFactory.NewStringLiteral(moduleSpecifier)
+	moduleSpecifierStringLiteral := ct.makeStringLiteral(moduleSpecifier, quotePreference)
 	var statements []*ast.Statement // []AnyImportSyntax
 	if defaultImport != nil || len(namedImports) > 0 {
 		// `verbatimModuleSyntax` should prefer top-level `import type` -
@@ -1445,7 +1445,7 @@ func (l *LanguageService) codeActionForFixWorker(
 			// !!! require
 			// declarations = getNewRequires(fixAddNew.moduleSpecifier, quotePreference, defaultImport, namedImports, namespaceLikeImport, l.GetProgram().Options(), preferences)
 		} else {
-			declarations = changeTracker.getNewImports(fix.moduleSpecifier, defaultImport, namedImports, namespaceLikeImport, l.GetProgram().Options(), preferences)
+			declarations = changeTracker.getNewImports(fix.moduleSpecifier, getQuotePreference(sourceFile, preferences), defaultImport, namedImports, namespaceLikeImport, l.GetProgram().Options(), preferences)
 		}
 
 		changeTracker.insertImports(
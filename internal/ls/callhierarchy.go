@@ -0,0 +1,631 @@
+package ls
+
+import (
+	"cmp"
+	"context"
+	"path"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// callSite is a single call, construction, tagged template, decorator or JSX element usage
+// of a call hierarchy declaration.
+type callSite struct {
+	declaration *ast.Node
+	sourceFile  *ast.SourceFile
+	textRange   core.TextRange
+}
+
+func (l *LanguageService) ProvidePrepareCallHierarchy(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (lsproto.CallHierarchyPrepareResponse, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	c, done := program.GetTypeChecker(ctx)
+	defer done()
+
+	node := astnav.GetTouchingPropertyName(file, int(l.converters.LineAndCharacterToPosition(file, position)))
+	declarations := resolveCallHierarchyDeclaration(c, node)
+	if len(declarations) == 0 {
+		return lsproto.CallHierarchyItemsOrNull{}, nil
+	}
+	items := core.Map(declarations, func(declaration *ast.Node) *lsproto.CallHierarchyItem {
+		return l.createCallHierarchyItem(c, declaration)
+	})
+	return lsproto.CallHierarchyItemsOrNull{CallHierarchyItems: &items}, nil
+}
+
+func (l *LanguageService) ProvideCallHierarchyIncomingCalls(ctx context.Context, item *lsproto.CallHierarchyItem) (lsproto.CallHierarchyIncomingCallsResponse, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	c, done := program.GetTypeChecker(ctx)
+	declaration := l.getCallHierarchyDeclarationOfItem(c, file, item)
+	// The reference search below acquires its own checker.
+	done()
+
+	calls := []*lsproto.CallHierarchyIncomingCall{}
+	if declaration == nil || ast.IsSourceFile(declaration) || ast.IsModuleDeclaration(declaration) || ast.IsClassStaticBlockDeclaration(declaration) {
+		// Source files, modules and static blocks have no incoming calls.
+		return lsproto.CallHierarchyIncomingCallsOrNull{CallHierarchyIncomingCalls: &calls}, nil
+	}
+
+	location := getCallHierarchyDeclarationReferenceNode(declaration)
+	options := refOptions{use: referenceUseReferences}
+	symbolsAndEntries := l.getReferencedSymbolsForNode(ctx, location.Pos(), location, program, program.GetSourceFiles(), options, nil)
+	var sites []callSite
+	for _, symbolAndEntries := range symbolsAndEntries {
+		for _, entry := range symbolAndEntries.references {
+			if site, ok := convertEntryToCallSite(entry); ok {
+				sites = append(sites, site)
+			}
+		}
+	}
+	if ctx.Err() != nil {
+		return lsproto.CallHierarchyIncomingCallsOrNull{}, ctx.Err()
+	}
+
+	c, done = program.GetTypeChecker(ctx)
+	defer done()
+	for _, group := range groupCallSitesByDeclaration(sites) {
+		calls = append(calls, &lsproto.CallHierarchyIncomingCall{
+			From:       l.createCallHierarchyItem(c, group[0].declaration),
+			FromRanges: l.callSiteRanges(group),
+		})
+	}
+	return lsproto.CallHierarchyIncomingCallsOrNull{CallHierarchyIncomingCalls: &calls}, nil
+}
+
+func (l *LanguageService) ProvideCallHierarchyOutgoingCalls(ctx context.Context, item *lsproto.CallHierarchyItem) (lsproto.CallHierarchyOutgoingCallsResponse, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	c, done := program.GetTypeChecker(ctx)
+	defer done()
+
+	calls := []*lsproto.CallHierarchyOutgoingCall{}
+	declaration := l.getCallHierarchyDeclarationOfItem(c, file, item)
+	if declaration == nil || declaration.Flags&ast.NodeFlagsAmbient != 0 || ast.IsMethodSignatureDeclaration(declaration) {
+		return lsproto.CallHierarchyOutgoingCallsOrNull{CallHierarchyOutgoingCalls: &calls}, nil
+	}
+
+	sites := collectCallSites(ctx, c, declaration)
+	if ctx.Err() != nil {
+		return lsproto.CallHierarchyOutgoingCallsOrNull{}, ctx.Err()
+	}
+	for _, group := range groupCallSitesByDeclaration(sites) {
+		calls = append(calls, &lsproto.CallHierarchyOutgoingCall{
+			To:         l.createCallHierarchyItem(c, group[0].declaration),
+			FromRanges: l.callSiteRanges(group),
+		})
+	}
+	return lsproto.CallHierarchyOutgoingCallsOrNull{CallHierarchyOutgoingCalls: &calls}, nil
+}
+
+// getCallHierarchyDeclarationOfItem re-resolves the declaration of an item produced by a previous prepare request
+// from the start of its selection range.
+func (l *LanguageService) getCallHierarchyDeclarationOfItem(c *checker.Checker, file *ast.SourceFile, item *lsproto.CallHierarchyItem) *ast.Node {
+	position := int(l.converters.LineAndCharacterToPosition(file, item.SelectionRange.Start))
+	var node *ast.Node
+	if position == 0 {
+		node = file.AsNode()
+	} else {
+		node = astnav.GetTouchingPropertyName(file, position)
+	}
+	declarations := resolveCallHierarchyDeclaration(c, node)
+	if len(declarations) == 0 {
+		return nil
+	}
+	return declarations[0]
+}
+
+func (l *LanguageService) callSiteRanges(sites []callSite) []lsproto.Range {
+	return core.Map(sites, func(site callSite) lsproto.Range {
+		return *l.createLspRangeFromBounds(site.textRange.Pos(), site.textRange.End(), site.sourceFile)
+	})
+}
+
+func (l *LanguageService) createCallHierarchyItem(c *checker.Checker, node *ast.Node) *lsproto.CallHierarchyItem {
+	file := ast.GetSourceFileOfNode(node)
+	name, nameRange := getCallHierarchyItemName(c, node)
+	start := scanner.SkipTriviaEx(file.Text(), node.Pos(), &scanner.SkipTriviaOptions{StopAtComments: true})
+	item := &lsproto.CallHierarchyItem{
+		Name:           name,
+		Kind:           getCallHierarchyItemKind(node),
+		Uri:            FileNameToDocumentURI(file.FileName()),
+		Range:          *l.createLspRangeFromBounds(start, node.End(), file),
+		SelectionRange: *l.createLspRangeFromBounds(nameRange.Pos(), nameRange.End(), file),
+	}
+	if containerName := getCallHierarchyItemContainerName(node); containerName != "" {
+		item.Detail = &containerName
+	}
+	return item
+}
+
+func getCallHierarchyItemKind(node *ast.Node) lsproto.SymbolKind {
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return lsproto.SymbolKindFile
+	case ast.KindArrowFunction:
+		return lsproto.SymbolKindFunction
+	case ast.KindClassStaticBlockDeclaration:
+		return lsproto.SymbolKindMethod
+	}
+	return getSymbolKindFromNode(node)
+}
+
+func getCallHierarchyItemName(c *checker.Checker, node *ast.Node) (string, core.TextRange) {
+	file := ast.GetSourceFileOfNode(node)
+	if ast.IsSourceFile(node) {
+		return path.Base(file.FileName()), core.NewTextRange(0, 0)
+	}
+	if (ast.IsFunctionDeclaration(node) || ast.IsClassDeclaration(node)) && node.Name() == nil {
+		if defaultModifier := findDefaultModifier(node); defaultModifier != nil {
+			return "default", core.NewTextRange(scanner.GetTokenPosOfNode(defaultModifier, file, false /*includeJSDoc*/), defaultModifier.End())
+		}
+	}
+	if ast.IsClassStaticBlockDeclaration(node) {
+		pos := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/)
+		prefix := ""
+		if symbol := c.GetSymbolAtLocation(node.Parent); symbol != nil {
+			prefix = c.SymbolToString(symbol) + " "
+		}
+		return prefix + "static {}", core.NewTextRange(pos, pos+len("static"))
+	}
+
+	var declName *ast.Node
+	if isAssignedExpression(node) {
+		declName = node.Parent.Name()
+	} else {
+		declName = ast.GetNameOfDeclaration(node)
+	}
+	nameRange := core.NewTextRange(scanner.GetTokenPosOfNode(declName, file, false /*includeJSDoc*/), declName.End())
+	switch {
+	case ast.IsIdentifier(declName), ast.IsStringOrNumericLiteralLike(declName):
+		return declName.Text(), nameRange
+	case ast.IsComputedPropertyName(declName) && ast.IsStringOrNumericLiteralLike(declName.Expression()):
+		return declName.Expression().Text(), nameRange
+	}
+	if symbol := c.GetSymbolAtLocation(declName); symbol != nil {
+		return c.SymbolToString(symbol), nameRange
+	}
+	return scanner.GetTextOfNode(declName), nameRange
+}
+
+func getCallHierarchyItemContainerName(node *ast.Node) string {
+	if isAssignedExpression(node) {
+		declaration := node.Parent
+		if ast.IsPropertyDeclaration(declaration) && ast.IsClassLike(declaration.Parent) {
+			if name := declaration.Parent.Name(); name != nil {
+				return scanner.GetTextOfNode(name)
+			}
+			return ""
+		}
+		// A variable declaration directly inside a namespace: `namespace N { const f = () => {} }`.
+		if statement := declaration.Parent.Parent; statement != nil && ast.IsModuleBlock(statement.Parent) {
+			if name := statement.Parent.Parent.Name(); ast.IsIdentifier(name) {
+				return name.Text()
+			}
+		}
+		return ""
+	}
+	switch node.Kind {
+	case ast.KindGetAccessor, ast.KindSetAccessor, ast.KindMethodDeclaration:
+		if ast.IsObjectLiteralExpression(node.Parent) {
+			if ast.IsVariableDeclaration(node.Parent.Parent) || ast.IsPropertyAssignment(node.Parent.Parent) {
+				return scanner.GetTextOfNode(node.Parent.Parent.Name())
+			}
+			return ""
+		}
+		if name := ast.GetNameOfDeclaration(node.Parent); name != nil {
+			return scanner.GetTextOfNode(name)
+		}
+	case ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindModuleDeclaration:
+		if ast.IsModuleBlock(node.Parent) && ast.IsIdentifier(node.Parent.Parent.Name()) {
+			return node.Parent.Parent.Name().Text()
+		}
+	}
+	return ""
+}
+
+// resolveCallHierarchyDeclaration finds the call hierarchy declaration(s) for a location, following
+// references to their declarations. Overloads resolve to their implementation when one exists.
+func resolveCallHierarchyDeclaration(c *checker.Checker, location *ast.Node) []*ast.Node {
+	followingSymbol := false
+	for location != nil {
+		if isValidCallHierarchyDeclaration(location) {
+			return findImplementationOrAllInitialDeclarations(c, location)
+		}
+		if isPossibleCallHierarchyDeclaration(location) {
+			if ancestor := ast.FindAncestor(location, isValidCallHierarchyDeclaration); ancestor != nil {
+				return findImplementationOrAllInitialDeclarations(c, ancestor)
+			}
+			return nil
+		}
+		if ast.IsDeclarationName(location) {
+			parent := location.Parent
+			if isValidCallHierarchyDeclaration(parent) {
+				return findImplementationOrAllInitialDeclarations(c, parent)
+			}
+			if isPossibleCallHierarchyDeclaration(parent) {
+				if ancestor := ast.FindAncestor(parent, isValidCallHierarchyDeclaration); ancestor != nil {
+					return findImplementationOrAllInitialDeclarations(c, ancestor)
+				}
+				return nil
+			}
+			if isVariableLikeWithIdentifierName(parent) && parent.Initializer() != nil && isAssignedExpression(parent.Initializer()) {
+				return []*ast.Node{parent.Initializer()}
+			}
+			return nil
+		}
+		if ast.IsConstructorDeclaration(location) {
+			if isValidCallHierarchyDeclaration(location.Parent) {
+				return []*ast.Node{location.Parent}
+			}
+			return nil
+		}
+		if location.Kind == ast.KindStaticKeyword && ast.IsClassStaticBlockDeclaration(location.Parent) {
+			location = location.Parent
+			continue
+		}
+		if ast.IsVariableDeclaration(location) && location.Initializer() != nil && isAssignedExpression(location.Initializer()) {
+			return []*ast.Node{location.Initializer()}
+		}
+		if followingSymbol {
+			return nil
+		}
+		symbol := c.GetSymbolAtLocation(location)
+		if symbol == nil {
+			return nil
+		}
+		if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+			symbol = c.GetAliasedSymbol(symbol)
+		}
+		if symbol.ValueDeclaration == nil {
+			return nil
+		}
+		followingSymbol = true
+		location = symbol.ValueDeclaration
+	}
+	return nil
+}
+
+// isPossibleCallHierarchyDeclaration reports whether node is of a kind that could be a call hierarchy declaration.
+func isPossibleCallHierarchyDeclaration(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindSourceFile, ast.KindModuleDeclaration, ast.KindFunctionDeclaration, ast.KindFunctionExpression,
+		ast.KindClassDeclaration, ast.KindClassExpression, ast.KindClassStaticBlockDeclaration,
+		ast.KindMethodDeclaration, ast.KindMethodSignature, ast.KindGetAccessor, ast.KindSetAccessor:
+		return true
+	}
+	return false
+}
+
+// isValidCallHierarchyDeclaration reports whether node can be used as a call hierarchy item.
+func isValidCallHierarchyDeclaration(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindSourceFile, ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindClassStaticBlockDeclaration,
+		ast.KindMethodDeclaration, ast.KindMethodSignature, ast.KindGetAccessor, ast.KindSetAccessor:
+		return true
+	case ast.KindModuleDeclaration:
+		return ast.IsIdentifier(node.Name())
+	}
+	return isNamedExpression(node) || isAssignedExpression(node)
+}
+
+// isNamedExpression reports whether node is a function or class expression with a name.
+func isNamedExpression(node *ast.Node) bool {
+	return (ast.IsFunctionExpression(node) || ast.IsClassExpression(node)) && node.Name() != nil
+}
+
+func isVariableLikeWithIdentifierName(node *ast.Node) bool {
+	return (ast.IsPropertyDeclaration(node) || ast.IsVariableDeclaration(node)) && ast.IsIdentifier(node.Name())
+}
+
+// isAssignedExpression reports whether node is a function, arrow function or class expression that initializes
+// a const variable or a property, as in `const f = () => {}`.
+func isAssignedExpression(node *ast.Node) bool {
+	if !ast.IsFunctionExpression(node) && !ast.IsArrowFunction(node) && !ast.IsClassExpression(node) {
+		return false
+	}
+	parent := node.Parent
+	return isVariableLikeWithIdentifierName(parent) && parent.Initializer() == node &&
+		(ast.GetCombinedNodeFlags(parent)&ast.NodeFlagsConst != 0 || ast.IsPropertyDeclaration(parent))
+}
+
+func getCallHierarchyDeclarationReferenceNode(node *ast.Node) *ast.Node {
+	if ast.IsSourceFile(node) {
+		return node
+	}
+	if name := node.Name(); name != nil {
+		return name
+	}
+	if isAssignedExpression(node) {
+		return node.Parent.Name()
+	}
+	return findDefaultModifier(node)
+}
+
+func findDefaultModifier(node *ast.Node) *ast.Node {
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if modifier.Kind == ast.KindDefaultKeyword {
+				return modifier
+			}
+		}
+	}
+	return nil
+}
+
+func getSymbolOfCallHierarchyDeclaration(c *checker.Checker, node *ast.Node) *ast.Symbol {
+	location := getCallHierarchyDeclarationReferenceNode(node)
+	if location == nil {
+		return nil
+	}
+	return c.GetSymbolAtLocation(location)
+}
+
+func findImplementation(c *checker.Checker, node *ast.Node) *ast.Node {
+	if node.Body() != nil {
+		return node
+	}
+	if ast.IsConstructorDeclaration(node) {
+		return core.Find(node.Parent.Members(), func(member *ast.Node) bool {
+			return ast.IsConstructorDeclaration(member) && member.Body() != nil
+		})
+	}
+	if ast.IsFunctionDeclaration(node) || ast.IsMethodDeclaration(node) {
+		symbol := getSymbolOfCallHierarchyDeclaration(c, node)
+		if symbol != nil && symbol.ValueDeclaration != nil && ast.IsFunctionLikeDeclaration(symbol.ValueDeclaration) && symbol.ValueDeclaration.Body() != nil {
+			return symbol.ValueDeclaration
+		}
+		return nil
+	}
+	return node
+}
+
+// findAllInitialDeclarations returns the first declaration of each group of adjacent declarations of the symbol
+// declared by node, e.g. the first signature of each run of overloads.
+func findAllInitialDeclarations(c *checker.Checker, node *ast.Node) []*ast.Node {
+	symbol := getSymbolOfCallHierarchyDeclaration(c, node)
+	if symbol == nil || len(symbol.Declarations) == 0 {
+		return nil
+	}
+	sorted := slices.Clone(symbol.Declarations)
+	slices.SortStableFunc(sorted, func(a, b *ast.Node) int {
+		return cmp.Or(
+			cmp.Compare(ast.GetSourceFileOfNode(a).FileName(), ast.GetSourceFileOfNode(b).FileName()),
+			cmp.Compare(a.Pos(), b.Pos()),
+		)
+	})
+	var declarations []*ast.Node
+	var lastDecl *ast.Node
+	for _, decl := range sorted {
+		if isValidCallHierarchyDeclaration(decl) {
+			if lastDecl == nil || lastDecl.Parent != decl.Parent || lastDecl.End() != decl.Pos() {
+				declarations = append(declarations, decl)
+			}
+			lastDecl = decl
+		}
+	}
+	return declarations
+}
+
+func findImplementationOrAllInitialDeclarations(c *checker.Checker, node *ast.Node) []*ast.Node {
+	if ast.IsClassStaticBlockDeclaration(node) {
+		return []*ast.Node{node}
+	}
+	if ast.IsFunctionLikeDeclaration(node) {
+		if implementation := findImplementation(c, node); implementation != nil {
+			return []*ast.Node{implementation}
+		}
+	}
+	if declarations := findAllInitialDeclarations(c, node); len(declarations) != 0 {
+		return declarations
+	}
+	return []*ast.Node{node}
+}
+
+// convertEntryToCallSite converts a reference that calls, constructs or otherwise invokes its target into a call site
+// attributed to the innermost enclosing call hierarchy declaration.
+func convertEntryToCallSite(entry *referenceEntry) (callSite, bool) {
+	// Plain references are recorded with entryKindNone, which is equivalent to entryKindNode here.
+	if entry.kind != entryKindNode && entry.kind != entryKindNone {
+		return callSite{}, false
+	}
+	node := entry.node
+	if !isCallSiteTarget(node) && !isRightSideOfPropertyAccess(node) && !isArgumentOfElementAccessExpression(node) {
+		return callSite{}, false
+	}
+	sourceFile := ast.GetSourceFileOfNode(node)
+	declaration := ast.FindAncestor(node, isValidCallHierarchyDeclaration)
+	if declaration == nil {
+		declaration = sourceFile.AsNode()
+	}
+	start := scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/)
+	return callSite{declaration: declaration, sourceFile: sourceFile, textRange: core.NewTextRange(start, node.End())}, true
+}
+
+// isCallSiteTarget reports whether node, or the property or element access it names, is the callee of a call or
+// new expression, the tag of a tagged template, the expression of a decorator, or the tag name of a JSX element.
+func isCallSiteTarget(node *ast.Node) bool {
+	target := node
+	if isRightSideOfPropertyAccess(target) || isArgumentOfElementAccessExpression(target) {
+		target = target.Parent
+	}
+	for target.Parent != nil && ast.IsOuterExpression(target.Parent, ast.OEKAll) {
+		target = target.Parent
+	}
+	parent := target.Parent
+	if parent == nil {
+		return false
+	}
+	switch parent.Kind {
+	case ast.KindCallExpression, ast.KindNewExpression, ast.KindDecorator:
+		return parent.Expression() == target
+	case ast.KindTaggedTemplateExpression:
+		return parent.AsTaggedTemplateExpression().Tag == target
+	case ast.KindJsxOpeningElement, ast.KindJsxSelfClosingElement:
+		return parent.TagName() == target
+	}
+	return false
+}
+
+// groupCallSitesByDeclaration groups call sites by declaration, in order of each declaration's first call site.
+func groupCallSitesByDeclaration(sites []callSite) [][]callSite {
+	var groups [][]callSite
+	indexOf := make(map[*ast.Node]int)
+	for _, site := range sites {
+		if index, ok := indexOf[site.declaration]; ok {
+			groups[index] = append(groups[index], site)
+			continue
+		}
+		indexOf[site.declaration] = len(groups)
+		groups = append(groups, []callSite{site})
+	}
+	return groups
+}
+
+type callSiteCollector struct {
+	ctx     context.Context
+	checker *checker.Checker
+	sites   []callSite
+}
+
+func collectCallSites(ctx context.Context, c *checker.Checker, node *ast.Node) []callSite {
+	collector := &callSiteCollector{ctx: ctx, checker: c}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		collector.collectAll(node.Statements())
+	case ast.KindModuleDeclaration:
+		if !ast.HasSyntacticModifier(node, ast.ModifierFlagsAmbient) && node.Body() != nil && ast.IsModuleBlock(node.Body()) {
+			collector.collectAll(node.Body().Statements())
+		}
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindArrowFunction, ast.KindMethodDeclaration,
+		ast.KindGetAccessor, ast.KindSetAccessor:
+		if implementation := findImplementation(c, node); implementation != nil {
+			collector.collectAll(implementation.Parameters())
+			collector.collect(implementation.Body())
+		}
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		collector.collectClassLikeDeclaration(node)
+	case ast.KindClassStaticBlockDeclaration:
+		collector.collect(node.Body())
+	}
+	return collector.sites
+}
+
+func (c *callSiteCollector) collectClassLikeDeclaration(node *ast.Node) {
+	c.collectModifiers(node)
+	if heritage := ast.GetClassExtendsHeritageElement(node); heritage != nil {
+		c.collect(heritage.Expression())
+	}
+	for _, member := range node.Members() {
+		if ast.CanHaveModifiers(member) {
+			c.collectModifiers(member)
+		}
+		switch {
+		case ast.IsPropertyDeclaration(member):
+			c.collect(member.Initializer())
+		case ast.IsConstructorDeclaration(member) && member.Body() != nil:
+			c.collectAll(member.Parameters())
+			c.collect(member.Body())
+		case ast.IsClassStaticBlockDeclaration(member):
+			c.collect(member)
+		}
+	}
+}
+
+func (c *callSiteCollector) collectModifiers(node *ast.Node) {
+	if modifiers := node.Modifiers(); modifiers != nil {
+		c.collectAll(modifiers.Nodes)
+	}
+}
+
+func (c *callSiteCollector) collectAll(nodes []*ast.Node) {
+	for _, node := range nodes {
+		c.collect(node)
+	}
+}
+
+func (c *callSiteCollector) visit(node *ast.Node) bool {
+	c.collect(node)
+	return c.ctx.Err() != nil
+}
+
+func (c *callSiteCollector) collect(node *ast.Node) {
+	if node == nil || node.Flags&ast.NodeFlagsAmbient != 0 {
+		// Do not descend into ambient nodes.
+		return
+	}
+
+	if isValidCallHierarchyDeclaration(node) {
+		// Do not descend into other call hierarchy declarations, other than computed class member names.
+		if ast.IsClassLike(node) {
+			for _, member := range node.Members() {
+				if name := member.Name(); name != nil && ast.IsComputedPropertyName(name) {
+					c.collect(name.Expression())
+				}
+			}
+		}
+		return
+	}
+
+	switch node.Kind {
+	case ast.KindIdentifier, ast.KindImportEqualsDeclaration, ast.KindImportDeclaration, ast.KindExportDeclaration,
+		ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration:
+		// Do not descend into nodes that cannot contain callable nodes.
+		return
+	case ast.KindClassStaticBlockDeclaration:
+		c.recordCallSite(node, node)
+		return
+	case ast.KindTypeAssertionExpression, ast.KindAsExpression, ast.KindSatisfiesExpression:
+		// Do not descend into the type side of an assertion.
+		c.collect(node.Expression())
+		return
+	case ast.KindVariableDeclaration, ast.KindParameter:
+		// Do not descend into the type of a variable or parameter declaration.
+		c.collect(node.Name())
+		c.collect(node.Initializer())
+		return
+	case ast.KindCallExpression, ast.KindNewExpression:
+		// Do not descend into type arguments.
+		c.recordCallSite(node, node.Expression())
+		c.collect(node.Expression())
+		c.collectAll(node.Arguments())
+		return
+	case ast.KindTaggedTemplateExpression:
+		tagged := node.AsTaggedTemplateExpression()
+		c.recordCallSite(node, tagged.Tag)
+		c.collect(tagged.Tag)
+		c.collect(tagged.Template)
+		return
+	case ast.KindJsxOpeningElement, ast.KindJsxSelfClosingElement:
+		c.recordCallSite(node, node.TagName())
+		c.collect(node.TagName())
+		c.collect(node.Attributes())
+		return
+	case ast.KindDecorator:
+		c.recordCallSite(node, node.Expression())
+		c.collect(node.Expression())
+		return
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+		c.recordCallSite(node, node)
+	}
+
+	if ast.IsPartOfTypeNode(node) {
+		// Do not descend into types.
+		return
+	}
+	node.ForEachChild(c.visit)
+}
+
+func (c *callSiteCollector) recordCallSite(node *ast.Node, target *ast.Node) {
+	if target == nil {
+		return
+	}
+	sourceFile := ast.GetSourceFileOfNode(node)
+	textRange := core.NewTextRange(scanner.GetTokenPosOfNode(target, sourceFile, false /*includeJSDoc*/), target.End())
+	for _, declaration := range resolveCallHierarchyDeclaration(c.checker, target) {
+		c.sites = append(c.sites, callSite{declaration: declaration, sourceFile: sourceFile, textRange: textRange})
+	}
+}
@@ -3,6 +3,7 @@ package ls
 import (
 	"context"
 	"slices"
+	"strings"
 
 	"github.com/microsoft/typescript-go/internal/ast"
 	"github.com/microsoft/typescript-go/internal/astnav"
@@ -82,6 +83,10 @@ type changeTracker struct {
 
 	*ast.NodeFactory
 	changes *collections.MultiMap[*ast.SourceFile, *trackerEdit]
+	// text of files that do not exist yet, keyed by file name
+	newFiles map[string]string
+	// classes that members were inserted into, whose closing brace may need to be moved to a new line
+	classesWithNodesInsertedAtStart map[*ast.Node]*ast.SourceFile
 
 	// created during call to getChanges
 	writer *printer.ChangeTrackerWriter
@@ -90,8 +95,8 @@ type changeTracker struct {
 
 func (ls *LanguageService) newChangeTracker(ctx context.Context) *changeTracker {
 	emitContext := printer.NewEmitContext()
-	newLine := ls.GetProgram().Options().NewLine.GetNewLineCharacter()
-	formatCodeSettings := format.GetDefaultFormatCodeSettings(newLine) // !!! format.GetFormatCodeSettingsFromContext(ctx),
+	formatCodeSettings := getFormatCodeSettings(ctx, ls.GetProgram().Options().NewLine.GetNewLineCharacter())
+	newLine := formatCodeSettings.NewLineCharacter
 	ctx = format.WithFormatCodeSettings(ctx, formatCodeSettings, newLine)
 	return &changeTracker{
 		ls:             ls,
@@ -108,12 +113,28 @@ func (ls *LanguageService) newChangeTracker(ctx context.Context) *changeTracker
 //   - Note: after calling this, the TextChanges object must be discarded!
 func (ct *changeTracker) getChanges() map[string][]*lsproto.TextEdit {
 	// !!! finishDeleteDeclarations
-	// !!! finishClassesWithNodesInsertedAtStart
+	ct.finishClassesWithNodesInsertedAtStart()
 	changes := ct.getTextChangesFromChanges()
-	// !!! changes for new files
 	return changes
 }
 
+// createNewFile records a file to be created. Its text is the printed statements, followed by a blank line
+// and text copied verbatim from existing files.
+func (ct *changeTracker) createNewFile(oldFile *ast.SourceFile, fileName string, statements []*ast.Statement, text string) {
+	var sb strings.Builder
+	for _, statement := range statements {
+		sb.WriteString(ct.getFormattedTextOfNode(statement, oldFile, oldFile, 0, changeNodeOptions{indentation: ptrTo(0)}))
+	}
+	if len(statements) > 0 && text != "" {
+		sb.WriteString(ct.newLine)
+	}
+	sb.WriteString(text)
+	if ct.newFiles == nil {
+		ct.newFiles = make(map[string]string)
+	}
+	ct.newFiles[fileName] = sb.String()
+}
+
 func (ct *changeTracker) replaceNode(sourceFile *ast.SourceFile, oldNode *ast.Node, newNode *ast.Node, options *changeNodeOptions) {
 	if options == nil {
 		// defaults to `useNonAdjustedPositions`
@@ -141,6 +162,10 @@ func (ct *changeTracker) replaceRangeWithNodes(sourceFile *ast.SourceFile, lspro
 	ct.changes.Add(sourceFile, &trackerEdit{kind: trackerEditKindReplaceWithMultipleNodes, Range: lsprotoRange, nodes: newNodes, options: options})
 }
 
+func (ct *changeTracker) deleteNodeRange(sourceFile *ast.SourceFile, startNode *ast.Node, endNode *ast.Node, leadingOption leadingTriviaOption, trailingOption trailingTriviaOption) {
+	ct.changes.Add(sourceFile, &trackerEdit{kind: trackerEditKindRemove, Range: ct.getAdjustedRange(sourceFile, startNode, endNode, leadingOption, trailingOption)})
+}
+
 func (ct *changeTracker) insertText(sourceFile *ast.SourceFile, pos lsproto.Position, text string) {
 	ct.replaceRangeWithText(sourceFile, lsproto.Range{Start: pos, End: pos}, text)
 }
@@ -302,6 +327,163 @@ func (ct *changeTracker) insertAtTopOfFile(sourceFile *ast.SourceFile, insert []
 	}
 }
 
+// insertNodeBefore inserts a statement or class element on its own line before another one, at the same
+// indentation.
+func (ct *changeTracker) insertNodeBefore(sourceFile *ast.SourceFile, before *ast.Node, newNode *ast.Node, blankLineBetween bool) {
+	pos := ct.getAdjustedStartPosition(sourceFile, before, leadingTriviaOptionNone, false)
+	beforeStart := astnav.GetStartOfNode(before, sourceFile, false)
+	indentation := format.FindFirstNonWhitespaceColumn(format.GetLineStartPositionForPosition(beforeStart, sourceFile), beforeStart, sourceFile, ct.formatSettings)
+	ct.insertNodeAt(sourceFile, core.TextPos(pos), newNode, changeNodeOptions{
+		prefix:      format.GetIndentationString(indentation, ct.formatSettings),
+		indentation: ptrTo(indentation),
+	})
+	suffix := core.IfElse(blankLineBetween, ct.newLine, "")
+	if !ast.IsStatement(newNode) {
+		// Only statements are printed with a trailing line break.
+		suffix += ct.newLine
+	}
+	if suffix != "" {
+		ct.insertText(sourceFile, ct.ls.converters.PositionToLineAndCharacter(sourceFile, core.TextPos(pos)), suffix)
+	}
+}
+
+// insertNodeAtEndOfScope inserts a statement or class element after the last one in a source file, class
+// or function body, separated from it by a blank line.
+func (ct *changeTracker) insertNodeAtEndOfScope(sourceFile *ast.SourceFile, scope *ast.Node, newNode *ast.Node) {
+	text := sourceFile.Text()
+	if ast.IsSourceFile(scope) {
+		prefix := ct.newLine
+		if len(text) > 0 && !stringutil.IsLineBreak(rune(text[len(text)-1])) {
+			prefix += ct.newLine
+		}
+		ct.insertNodeAt(sourceFile, core.TextPos(len(text)), newNode, changeNodeOptions{prefix: prefix, indentation: ptrTo(0)})
+		return
+	}
+	closeBrace := scope
+	if !ast.IsClassLike(scope) {
+		closeBrace = scope.Body()
+	}
+	pos := closeBrace.End() - 1
+	lineStart := format.GetLineStartPositionForPosition(pos, sourceFile)
+	indentation := format.FindFirstNonWhitespaceColumn(lineStart, pos, sourceFile, ct.formatSettings) + ct.formatSettings.IndentSize
+	prefix := ct.newLine
+	if strings.TrimSpace(text[lineStart:pos]) == "" {
+		// The closing brace is on its own line, so insert on a new line before it.
+		pos = lineStart
+	} else {
+		prefix += ct.newLine
+	}
+	ct.insertNodeAt(sourceFile, core.TextPos(pos), newNode, changeNodeOptions{
+		prefix:      prefix + format.GetIndentationString(indentation, ct.formatSettings),
+		indentation: ptrTo(indentation),
+	})
+	if !ast.IsStatement(newNode) {
+		// Only statements are printed with a trailing line break.
+		ct.insertText(sourceFile, ct.ls.converters.PositionToLineAndCharacter(sourceFile, core.TextPos(pos)), ct.newLine)
+	}
+}
+
+// tryInsertTypeAnnotation inserts a type annotation after the parameter list of a function, or after the name
+// of a parameter, property or variable. Returns false if there is no place to insert it.
+func (ct *changeTracker) tryInsertTypeAnnotation(sourceFile *ast.SourceFile, node *ast.Node, typeNode *ast.Node) bool {
+	var endNode *ast.Node
+	if ast.IsFunctionLike(node) {
+		endNode = findChildOfKind(node, ast.KindCloseParenToken, sourceFile)
+		if endNode == nil {
+			if !ast.IsArrowFunction(node) {
+				// Function missing parentheses, give up
+				return false
+			}
+			// If no `)`, is an arrow function `x => x`, so use the end of the first parameter
+			endNode = node.Parameters()[0]
+		}
+	} else {
+		if ast.IsVariableDeclaration(node) {
+			endNode = node.AsVariableDeclaration().ExclamationToken
+		} else {
+			endNode = node.QuestionToken()
+		}
+		if endNode == nil {
+			endNode = node.Name()
+		}
+	}
+	ct.insertNodeAt(sourceFile, core.TextPos(endNode.End()), typeNode, changeNodeOptions{prefix: ": "})
+	return true
+}
+
+// insertMemberAtStart inserts a class element on a new line before the first member of a class.
+func (ct *changeTracker) insertMemberAtStart(sourceFile *ast.SourceFile, cls *ast.Node, newElement *ast.Node) {
+	ct.insertMemberAt(sourceFile, cls, cls.MemberList().Pos(), newElement)
+}
+
+// insertMemberAfter inserts a class element on a new line after a member of a class.
+func (ct *changeTracker) insertMemberAfter(sourceFile *ast.SourceFile, cls *ast.Node, after *ast.Node, newElement *ast.Node) {
+	ct.insertMemberAt(sourceFile, cls, after.End(), newElement)
+}
+
+func (ct *changeTracker) insertMemberAt(sourceFile *ast.SourceFile, cls *ast.Node, pos int, newElement *ast.Node) {
+	indentation, ok := ct.guessIndentationFromExistingMembers(sourceFile, cls)
+	if !ok {
+		indentation = ct.computeIndentationForNewMember(sourceFile, cls)
+	}
+	ct.insertNodeAt(sourceFile, core.TextPos(pos), newElement, changeNodeOptions{
+		prefix:      ct.newLine + format.GetIndentationString(indentation, ct.formatSettings),
+		indentation: ptrTo(indentation),
+	})
+	if ct.classesWithNodesInsertedAtStart == nil {
+		ct.classesWithNodesInsertedAtStart = make(map[*ast.Node]*ast.SourceFile)
+	}
+	ct.classesWithNodesInsertedAtStart[cls] = sourceFile
+}
+
+// guessIndentationFromExistingMembers returns the indentation shared by the members of a class, if each of them
+// starts on a line of its own.
+func (ct *changeTracker) guessIndentationFromExistingMembers(sourceFile *ast.SourceFile, cls *ast.Node) (int, bool) {
+	indentation := -1
+	lastStart := astnav.GetStartOfNode(cls, sourceFile, false)
+	for _, member := range cls.Members() {
+		memberStart := astnav.GetStartOfNode(member, sourceFile, false)
+		lineStart := format.GetLineStartPositionForPosition(memberStart, sourceFile)
+		if lineStart == format.GetLineStartPositionForPosition(lastStart, sourceFile) {
+			return 0, false
+		}
+		memberIndentation := format.FindFirstNonWhitespaceColumn(lineStart, memberStart, sourceFile, ct.formatSettings)
+		if indentation == -1 {
+			indentation = memberIndentation
+		} else if memberIndentation != indentation {
+			return 0, false
+		}
+		lastStart = memberStart
+	}
+	return indentation, indentation != -1
+}
+
+func (ct *changeTracker) computeIndentationForNewMember(sourceFile *ast.SourceFile, cls *ast.Node) int {
+	start := astnav.GetStartOfNode(cls, sourceFile, false)
+	return format.FindFirstNonWhitespaceColumn(format.GetLineStartPositionForPosition(start, sourceFile), start, sourceFile, ct.formatSettings) + ct.formatSettings.IndentSize
+}
+
+// finishClassesWithNodesInsertedAtStart moves the closing brace of single-line classes that members were
+// inserted into to a new line, e.g. `class C {}` becomes `class C {\n    m() {}\n}`.
+func (ct *changeTracker) finishClassesWithNodesInsertedAtStart() {
+	for cls, sourceFile := range ct.classesWithNodesInsertedAtStart {
+		openBrace := findChildOfKind(cls, ast.KindOpenBraceToken, sourceFile)
+		closeBrace := findChildOfKind(cls, ast.KindCloseBraceToken, sourceFile)
+		if openBrace == nil || closeBrace == nil {
+			continue
+		}
+		openBraceEnd, closeBraceEnd := openBrace.End(), closeBrace.End()
+		if format.GetLineStartPositionForPosition(openBraceEnd, sourceFile) != format.GetLineStartPositionForPosition(closeBraceEnd, sourceFile) {
+			continue
+		}
+		if len(cls.Members()) == 0 && openBraceEnd != closeBraceEnd-1 {
+			// For `class C { }` remove the whitespace inside the braces.
+			ct.replaceRangeWithText(sourceFile, *ct.ls.createLspRangeFromBounds(openBraceEnd, closeBraceEnd-1, sourceFile), "")
+		}
+		ct.insertText(sourceFile, ct.ls.createLspPosition(closeBraceEnd-1, sourceFile), ct.newLine)
+	}
+}
+
 func (ct *changeTracker) getInsertNodeAfterOptions(sourceFile *ast.SourceFile, node *ast.Node) changeNodeOptions {
 	newLineChar := ct.newLine
 	var options changeNodeOptions
@@ -74,8 +74,16 @@ func (ct *changeTracker) computeNewText(change *trackerEdit, targetSourceFile *a
 			change.options.joiner = ct.newLine
 		}
 		text = strings.Join(core.Map(change.nodes, func(n *ast.Node) string { return strings.TrimSuffix(formatNode(n), ct.newLine) }), change.options.joiner)
+		if ast.IsStatement(change.nodes[len(change.nodes)-1]) && change.Range.Start == change.Range.End {
+			// Inserted statements end with a line break, like a single inserted statement does.
+			text += ct.newLine
+		}
 	case trackerEditKindReplaceWithSingleNode:
 		text = formatNode(change.Node)
+		if ast.IsStatement(change.Node) && change.Range.Start != change.Range.End {
+			// A replaced statement keeps the line break that followed the original text.
+			text = strings.TrimSuffix(text, ct.newLine)
+		}
 	default:
 		panic(fmt.Sprintf("change kind %d should have been handled earlier", change.kind))
 	}
@@ -107,7 +115,10 @@ func (ct *changeTracker) getFormattedTextOfNode(nodeIn *ast.Node, targetSourceFi
 		delta = formatOptions.IndentSize
 	}
 
-	changes := format.FormatNodeGivenIndentation(ct.ctx, sourceFileLike, sourceFileLike.AsSourceFile(), targetSourceFile.LanguageVariant, initialIndentation, delta)
+	// Format the printed node rather than the source file wrapping it, so that its lines are indented
+	// relative to initialIndentation.
+	formatNode := sourceFileLike.AsSourceFile().Statements.Nodes[0]
+	changes := format.FormatNodeGivenIndentation(ct.ctx, formatNode, sourceFileLike.AsSourceFile(), targetSourceFile.LanguageVariant, initialIndentation, delta)
 	return core.ApplyBulkEdits(text, changes)
 }
 
@@ -165,7 +176,18 @@ func (ct *changeTracker) getNonformattedText(node *ast.Node, sourceFile *ast.Sou
 		})
 		sourceFileLike.Loc = nodeOut.Loc
 	} else {
-		sourceFileLike = nodeOut
+		// The source file wrapping the statement was created before its text was known.
+		sourceFileLike = ct.Factory.NewSourceFile(
+			ast.SourceFileParseOptions{FileName: sourceFile.FileName(), Path: sourceFile.Path()},
+			text,
+			nodeOut.AsSourceFile().Statements,
+			nodeOut.AsSourceFile().EndOfFileToken,
+		)
+		sourceFileLike.ForEachChild(func(child *ast.Node) bool {
+			child.Parent = sourceFileLike
+			return false
+		})
+		sourceFileLike.Loc = nodeOut.Loc
 	}
 	return text, sourceFileLike
 }
@@ -0,0 +1,312 @@
+package ls
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+// SupportedCodeActionKinds lists the code action kinds advertised in the server capabilities.
+var SupportedCodeActionKinds = []lsproto.CodeActionKind{
+	lsproto.CodeActionKindQuickFix,
+	lsproto.CodeActionKindRefactorExtract,
+	lsproto.CodeActionKindRefactorMove,
+	lsproto.CodeActionKindRefactorRewrite,
+	lsproto.CodeActionKindSourceOrganizeImports,
+	CodeActionKindSourceSortImports,
+	CodeActionKindSourceRemoveUnusedImports,
+	CodeActionKindSourceAddMissingImports,
+	lsproto.CodeActionKindSourceFixAll,
+}
+
+// codeFixProvider describes a quick fix for one or more diagnostic codes.
+type codeFixProvider struct {
+	errorCodes     []int32
+	fixIds         []string
+	getCodeActions func(ctx context.Context, fixContext *codeFixContext) []*codeFixAction
+	// fixAll applies the fix of one diagnostic when all fixable diagnostics of a file are fixed at once, with a
+	// change tracker shared by all of them. seen holds the nodes changed so far, so that no node is fixed twice.
+	// It is nil for fixes that should be reviewed before they are applied.
+	fixAll func(ct *changeTracker, fixContext *codeFixContext, seen *collections.Set[*ast.Node])
+}
+
+type codeFixContext struct {
+	ls          *LanguageService
+	program     *compiler.Program
+	sourceFile  *ast.SourceFile
+	errorCode   int32
+	span        core.TextRange
+	preferences *UserPreferences
+	// importAdder collects the imports that the fixes of fixAll need, so that they are written once after all
+	// fixes of the file. It is nil outside of fixAll.
+	importAdder *importAdder
+}
+
+type codeFixAction struct {
+	fixName     string
+	description string
+	// Text changes to apply to each file as part of the code fix
+	changes map[string][]*lsproto.TextEdit
+	// If present, the fix can be applied to every diagnostic in a file with the same fix id
+	fixId string
+}
+
+// codeFixProviders is the registry of all quick fixes known to the language service.
+var codeFixProviders = []*codeFixProvider{
+	fixAwaitInSyncFunctionProvider,
+	removeUnnecessaryAwaitProvider,
+	missingImportProvider,
+	implementInterfaceProvider,
+	implementAbstractMembersProvider,
+	inferFromUsageProvider,
+	addReturnTypeProvider,
+}
+
+var codeFixProvidersByErrorCode = sync.OnceValue(func() map[int32][]*codeFixProvider {
+	providers := make(map[int32][]*codeFixProvider)
+	for _, provider := range codeFixProviders {
+		for _, errorCode := range provider.errorCodes {
+			providers[errorCode] = append(providers[errorCode], provider)
+		}
+	}
+	return providers
+})
+
+func (l *LanguageService) ProvideCodeActions(ctx context.Context, params *lsproto.CodeActionParams, preferences *UserPreferences) (lsproto.CodeActionResponse, error) {
+	program, sourceFile := l.getProgramAndFile(params.TextDocument.Uri)
+	var only *[]lsproto.CodeActionKind
+	var diagnostics []*lsproto.Diagnostic
+	invoked := true
+	if params.Context != nil {
+		only = params.Context.Only
+		diagnostics = params.Context.Diagnostics
+		invoked = params.Context.TriggerKind == nil || *params.Context.TriggerKind == lsproto.CodeActionTriggerKindInvoked
+	}
+
+	actions := []lsproto.CommandOrCodeAction{}
+	if codeActionKindIsRequested(only, lsproto.CodeActionKindQuickFix) {
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Code == nil || diagnostic.Code.Integer == nil || diagnostic.Source != nil && *diagnostic.Source != "ts" {
+				continue
+			}
+			fixContext := &codeFixContext{
+				ls:          l,
+				program:     program,
+				sourceFile:  sourceFile,
+				errorCode:   *diagnostic.Code.Integer,
+				span:        l.converters.FromLSPRange(sourceFile, diagnostic.Range),
+				preferences: preferences,
+			}
+			for _, fix := range l.getCodeFixesAtPosition(ctx, fixContext) {
+				actions = append(actions, lsproto.CommandOrCodeAction{
+					CodeAction: l.toLSPCodeAction(fix, lsproto.CodeActionKindQuickFix, []*lsproto.Diagnostic{diagnostic}),
+				})
+			}
+		}
+	}
+
+	refactorContext := &refactorContext{
+		ls:          l,
+		program:     program,
+		sourceFile:  sourceFile,
+		span:        l.converters.FromLSPRange(sourceFile, params.Range),
+		preferences: preferences,
+		invoked:     invoked,
+	}
+	for _, refactor := range l.getApplicableRefactors(ctx, refactorContext, only) {
+		codeAction := &lsproto.CodeAction{
+			Title: refactor.description,
+			Kind:  &refactor.kind,
+		}
+		if refactor.command != nil {
+			codeAction.Command = refactor.command
+		} else {
+			codeAction.Edit = toLSPWorkspaceEditWithNewFiles(refactor.changes, refactor.newFiles)
+		}
+		actions = append(actions, lsproto.CommandOrCodeAction{CodeAction: codeAction})
+	}
+
+	// Source actions apply to the whole file, so they are only offered when asked for explicitly.
+	if only != nil {
+		for _, action := range sourceActions {
+			if !codeActionKindIsRequested(only, action.kind) {
+				continue
+			}
+			kind := action.kind
+			actions = append(actions, lsproto.CommandOrCodeAction{CodeAction: &lsproto.CodeAction{
+				Title: action.description,
+				Kind:  &kind,
+				Edit:  toLSPWorkspaceEdit(action.getChanges(l, ctx, params.TextDocument.Uri, preferences)),
+			}})
+		}
+	}
+
+	return lsproto.CommandOrCodeActionArrayOrNull{CommandOrCodeActionArray: &actions}, nil
+}
+
+// sourceActions are the code actions that apply to a whole file.
+var sourceActions = []struct {
+	kind        lsproto.CodeActionKind
+	description string
+	getChanges  func(l *LanguageService, ctx context.Context, uri lsproto.DocumentUri, preferences *UserPreferences) map[string][]*lsproto.TextEdit
+}{
+	{lsproto.CodeActionKindSourceOrganizeImports, "Organize Imports", func(l *LanguageService, ctx context.Context, uri lsproto.DocumentUri, preferences *UserPreferences) map[string][]*lsproto.TextEdit {
+		return l.OrganizeImports(ctx, uri, OrganizeImportsModeAll, preferences)
+	}},
+	{CodeActionKindSourceSortImports, "Sort Imports", func(l *LanguageService, ctx context.Context, uri lsproto.DocumentUri, preferences *UserPreferences) map[string][]*lsproto.TextEdit {
+		return l.OrganizeImports(ctx, uri, OrganizeImportsModeSortAndCombine, preferences)
+	}},
+	{CodeActionKindSourceRemoveUnusedImports, "Remove Unused Imports", func(l *LanguageService, ctx context.Context, uri lsproto.DocumentUri, preferences *UserPreferences) map[string][]*lsproto.TextEdit {
+		return l.OrganizeImports(ctx, uri, OrganizeImportsModeRemoveUnused, preferences)
+	}},
+	{CodeActionKindSourceAddMissingImports, "Add all missing imports", (*LanguageService).AddMissingImports},
+	{lsproto.CodeActionKindSourceFixAll, "Fix all fixable issues", (*LanguageService).FixAll},
+}
+
+// FixAll returns the edits that fix every diagnostic of a file that has a fix that is safe to apply without review.
+func (l *LanguageService) FixAll(ctx context.Context, uri lsproto.DocumentUri, preferences *UserPreferences) map[string][]*lsproto.TextEdit {
+	program, sourceFile := l.getProgramAndFile(uri)
+	fileDiagnostics := getCodeFixDiagnostics(ctx, program, sourceFile)
+	ct := l.newChangeTracker(ctx)
+	// The import adder uses the checker until its fixes are written.
+	c, done := program.GetTypeCheckerForFile(ctx, sourceFile)
+	defer done()
+	adder := l.newImportAdder(ctx, c, sourceFile, preferences)
+	for _, provider := range codeFixProviders {
+		if provider.fixAll == nil {
+			continue
+		}
+		var seen collections.Set[*ast.Node]
+		for _, diagnostic := range fileDiagnostics {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if !slices.Contains(provider.errorCodes, diagnostic.Code()) {
+				continue
+			}
+			provider.fixAll(ct, &codeFixContext{
+				ls:          l,
+				program:     program,
+				sourceFile:  sourceFile,
+				errorCode:   diagnostic.Code(),
+				span:        diagnostic.Loc(),
+				preferences: preferences,
+				importAdder: adder,
+			}, &seen)
+		}
+	}
+	adder.writeFixes(ct)
+	return ct.getChanges()
+}
+
+// getCodeFixDiagnostics returns the diagnostics of a file that fixes may apply to.
+func getCodeFixDiagnostics(ctx context.Context, program *compiler.Program, sourceFile *ast.SourceFile) []*ast.Diagnostic {
+	fileDiagnostics := slices.Concat(
+		program.GetSyntacticDiagnostics(ctx, sourceFile),
+		program.GetSemanticDiagnostics(ctx, sourceFile),
+		program.GetSuggestionDiagnostics(ctx, sourceFile),
+	)
+	if program.Options().GetEmitDeclarations() {
+		fileDiagnostics = append(fileDiagnostics, program.GetDeclarationDiagnostics(ctx, sourceFile)...)
+	}
+	return fileDiagnostics
+}
+
+func (l *LanguageService) getCodeFixesAtPosition(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	var fixes []*codeFixAction
+	for _, provider := range codeFixProvidersByErrorCode()[fixContext.errorCode] {
+		if ctx.Err() != nil {
+			return nil
+		}
+		fixes = append(fixes, provider.getCodeActions(ctx, fixContext)...)
+	}
+	return fixes
+}
+
+func (l *LanguageService) toLSPCodeAction(fix *codeFixAction, kind lsproto.CodeActionKind, diagnostics []*lsproto.Diagnostic) *lsproto.CodeAction {
+	return &lsproto.CodeAction{
+		Title:       fix.description,
+		Kind:        &kind,
+		Diagnostics: ptrToSliceIfNonEmpty(diagnostics),
+		Edit:        toLSPWorkspaceEdit(fix.changes),
+	}
+}
+
+func toLSPWorkspaceEdit(changesByFileName map[string][]*lsproto.TextEdit) *lsproto.WorkspaceEdit {
+	changes := make(map[lsproto.DocumentUri][]*lsproto.TextEdit, len(changesByFileName))
+	for fileName, edits := range changesByFileName {
+		changes[FileNameToDocumentURI(fileName)] = edits
+	}
+	return &lsproto.WorkspaceEdit{
+		Changes: &changes,
+	}
+}
+
+// toLSPWorkspaceEditWithNewFiles creates the files in newFiles before applying the other changes. Creating
+// files requires document changes, so edits to existing files are listed as document changes too.
+func toLSPWorkspaceEditWithNewFiles(changesByFileName map[string][]*lsproto.TextEdit, newFiles map[string]string) *lsproto.WorkspaceEdit {
+	if len(newFiles) == 0 {
+		return toLSPWorkspaceEdit(changesByFileName)
+	}
+	var documentChanges []lsproto.TextDocumentEditOrCreateFileOrRenameFileOrDeleteFile
+	newDocumentChange := func(fileName string, edits []*lsproto.TextEdit) lsproto.TextDocumentEditOrCreateFileOrRenameFileOrDeleteFile {
+		return lsproto.TextDocumentEditOrCreateFileOrRenameFileOrDeleteFile{
+			TextDocumentEdit: &lsproto.TextDocumentEdit{
+				TextDocument: lsproto.OptionalVersionedTextDocumentIdentifier{Uri: FileNameToDocumentURI(fileName)},
+				Edits: core.Map(edits, func(edit *lsproto.TextEdit) lsproto.TextEditOrAnnotatedTextEditOrSnippetTextEdit {
+					return lsproto.TextEditOrAnnotatedTextEditOrSnippetTextEdit{TextEdit: edit}
+				}),
+			},
+		}
+	}
+	for _, fileName := range slices.Sorted(maps.Keys(newFiles)) {
+		documentChanges = append(documentChanges,
+			lsproto.TextDocumentEditOrCreateFileOrRenameFileOrDeleteFile{
+				CreateFile: &lsproto.CreateFile{Uri: FileNameToDocumentURI(fileName)},
+			},
+			newDocumentChange(fileName, []*lsproto.TextEdit{{NewText: newFiles[fileName]}}),
+		)
+	}
+	for _, fileName := range slices.Sorted(maps.Keys(changesByFileName)) {
+		documentChanges = append(documentChanges, newDocumentChange(fileName, changesByFileName[fileName]))
+	}
+	return &lsproto.WorkspaceEdit{
+		DocumentChanges: &documentChanges,
+	}
+}
+
+// createCodeFixAction runs a change function against a fresh change tracker and packages the result.
+// Returns nil when the change function produced no edits.
+func (l *LanguageService) createCodeFixAction(ctx context.Context, fixName string, description string, fixId string, fn func(ct *changeTracker)) *codeFixAction {
+	tracker := l.newChangeTracker(ctx)
+	fn(tracker)
+	changes := tracker.getChanges()
+	if len(changes) == 0 {
+		return nil
+	}
+	return &codeFixAction{
+		fixName:     fixName,
+		description: description,
+		changes:     changes,
+		fixId:       fixId,
+	}
+}
+
+// codeActionKindIsRequested reports whether an action of the given kind passes the client's `only` filter.
+// A requested kind matches itself and every kind nested below it, e.g. `source` matches `source.organizeImports`.
+func codeActionKindIsRequested(only *[]lsproto.CodeActionKind, kind lsproto.CodeActionKind) bool {
+	if only == nil {
+		return true
+	}
+	return slices.ContainsFunc(*only, func(requested lsproto.CodeActionKind) bool {
+		return requested == kind || strings.HasPrefix(string(kind), string(requested)+".")
+	})
+}
@@ -0,0 +1,431 @@
+package ls
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"github.com/microsoft/typescript-go/internal/nodebuilder"
+	"github.com/microsoft/typescript-go/internal/printer"
+)
+
+// memberStubBuilder creates declarations of class members with the signatures of the members of another type,
+// and bodies that throw.
+type memberStubBuilder struct {
+	ct              *changeTracker
+	checker         *checker.Checker
+	nodeBuilder     *checker.NodeBuilder
+	tracker         nodebuilder.SymbolTracker
+	importAdder     *importAdder
+	sourceFile      *ast.SourceFile
+	quotePreference quotePreference
+}
+
+// newMemberStubBuilder returns a builder of member stubs for a file. The symbols of other modules that the stubs
+// refer to are imported with importAdder.
+func newMemberStubBuilder(ct *changeTracker, c *checker.Checker, sourceFile *ast.SourceFile, importAdder *importAdder, preferences *UserPreferences) *memberStubBuilder {
+	return &memberStubBuilder{
+		ct:              ct,
+		checker:         c,
+		nodeBuilder:     checker.NewNodeBuilder(c, ct.EmitContext),
+		tracker:         &noopSymbolTracker{host: importAdder.ls.GetProgram()},
+		importAdder:     importAdder,
+		sourceFile:      sourceFile,
+		quotePreference: getQuotePreference(sourceFile, preferences),
+	}
+}
+
+func (b *memberStubBuilder) nodeBuilderFlags() nodebuilder.Flags {
+	flags := nodebuilder.FlagsNoTruncation
+	if b.quotePreference == quotePreferenceSingle {
+		flags |= nodebuilder.FlagsUseSingleQuotesForStringLiteralType
+	}
+	return flags
+}
+
+// createMissingMemberNodes adds a declaration for each symbol that is not a member of the class yet.
+func (b *memberStubBuilder) createMissingMemberNodes(classDeclaration *ast.Node, possiblyMissingSymbols []*ast.Symbol, addClassElement func(member *ast.Node)) {
+	classMembers := classDeclaration.Symbol().Members
+	for _, symbol := range possiblyMissingSymbols {
+		if _, ok := classMembers[symbol.Name]; !ok {
+			b.addNewNodeForMemberSymbol(symbol, classDeclaration, addClassElement)
+		}
+	}
+}
+
+// addNewNodeForMemberSymbol adds the declarations that implement a property, accessor or method symbol in a class.
+func (b *memberStubBuilder) addNewNodeForMemberSymbol(symbol *ast.Symbol, enclosingDeclaration *ast.Node, addClassElement func(member *ast.Node)) {
+	factory := b.ct.NodeFactory
+	declarations := symbol.Declarations
+	var declaration *ast.Node
+	kind := ast.KindPropertySignature
+	if len(declarations) > 0 {
+		declaration = declarations[0]
+		kind = declaration.Kind
+	}
+
+	createName := func() *ast.Node {
+		if declaration == nil || declaration.Name() == nil {
+			return factory.NewIdentifier(symbol.Name)
+		}
+		name := declaration.Name()
+		if ast.IsIdentifier(name) && name.Text() == "constructor" {
+			return factory.NewComputedPropertyName(b.ct.makeStringLiteral(name.Text(), b.quotePreference))
+		}
+		return factory.DeepCloneNode(name)
+	}
+	createModifiers := func() *ast.ModifierList {
+		var effectiveModifierFlags ast.ModifierFlags
+		if declaration != nil {
+			effectiveModifierFlags = declaration.ModifierFlags()
+		}
+		modifierFlags := effectiveModifierFlags & ast.ModifierFlagsStatic
+		if effectiveModifierFlags&ast.ModifierFlagsPublic != 0 {
+			modifierFlags |= ast.ModifierFlagsPublic
+		} else if effectiveModifierFlags&ast.ModifierFlagsProtected != 0 {
+			modifierFlags |= ast.ModifierFlagsProtected
+		}
+		if declaration != nil && ast.IsAutoAccessorPropertyDeclaration(declaration) {
+			modifierFlags |= ast.ModifierFlagsAccessor
+		}
+		if modifierFlags == 0 {
+			return nil
+		}
+		return factory.NewModifierList(ast.CreateModifiersFromModifierFlags(modifierFlags, factory.NewModifier))
+	}
+	createBody := func() *ast.Node {
+		if enclosingDeclaration.Flags&ast.NodeFlagsAmbient != 0 {
+			return nil
+		}
+		return b.createStubbedMethodBody()
+	}
+
+	t := b.checker.GetWidenedType(b.checker.GetTypeOfSymbolAtLocation(symbol, enclosingDeclaration))
+	optional := symbol.Flags&ast.SymbolFlagsOptional != 0
+	var questionToken *ast.Node
+	if optional {
+		questionToken = factory.NewToken(ast.KindQuestionToken)
+	}
+
+	switch kind {
+	case ast.KindPropertySignature, ast.KindPropertyDeclaration:
+		typeNode := b.typeToTypeNode(t, enclosingDeclaration)
+		addClassElement(factory.NewPropertyDeclaration(createModifiers(), createName(), questionToken, typeNode, nil /*initializer*/))
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		for _, accessor := range declarations {
+			switch accessor.Kind {
+			case ast.KindGetAccessor:
+				typeNode := b.typeToTypeNode(t, enclosingDeclaration)
+				addClassElement(factory.NewGetAccessorDeclaration(createModifiers(), createName(), nil /*typeParameters*/, factory.NewNodeList(nil), typeNode, nil /*fullSignature*/, createBody()))
+			case ast.KindSetAccessor:
+				parameterName := "value"
+				if parameters := accessor.Parameters(); len(parameters) > 0 && ast.IsIdentifier(parameters[0].Name()) {
+					parameterName = parameters[0].Name().Text()
+				}
+				typeNode := b.typeToTypeNode(t, enclosingDeclaration)
+				parameters := b.createDummyParameters(1, []string{parameterName}, []*ast.Node{typeNode}, 1)
+				addClassElement(factory.NewSetAccessorDeclaration(createModifiers(), createName(), nil /*typeParameters*/, parameters, nil /*returnType*/, nil /*fullSignature*/, createBody()))
+			}
+		}
+	case ast.KindMethodSignature, ast.KindMethodDeclaration:
+		var signatures []*checker.Signature
+		if t.IsUnion() {
+			for _, member := range t.Types() {
+				signatures = append(signatures, b.checker.GetCallSignatures(member)...)
+			}
+		} else {
+			signatures = b.checker.GetCallSignatures(t)
+		}
+		if len(signatures) == 0 {
+			return
+		}
+		outputMethod := func(signature *checker.Signature, body *ast.Node) {
+			if method := b.createSignatureDeclarationFromSignature(signature, enclosingDeclaration, createModifiers(), createName(), questionToken, body); method != nil {
+				addClassElement(method)
+			}
+		}
+		if len(declarations) == 1 {
+			outputMethod(signatures[0], createBody())
+			return
+		}
+		ambient := enclosingDeclaration.Flags&ast.NodeFlagsAmbient != 0
+		for _, signature := range signatures {
+			if signature.Declaration() != nil && signature.Declaration().Flags&ast.NodeFlagsAmbient != 0 {
+				continue
+			}
+			outputMethod(signature, nil /*body*/)
+		}
+		if !ambient {
+			if len(declarations) > len(signatures) {
+				outputMethod(b.checker.GetSignatureFromDeclaration(declarations[len(declarations)-1]), createBody())
+			} else {
+				addClassElement(b.createMethodImplementingSignatures(signatures, enclosingDeclaration, createModifiers(), createName(), questionToken))
+			}
+		}
+	}
+}
+
+func (b *memberStubBuilder) typeToTypeNode(t *checker.Type, enclosingDeclaration *ast.Node) *ast.Node {
+	return b.autoImportable(b.nodeBuilder.TypeToTypeNode(t, enclosingDeclaration, b.nodeBuilderFlags(), nodebuilder.InternalFlagsNone, b.tracker))
+}
+
+// autoImportable replaces the import types in nodes created by the node builder with names that are imported.
+func (b *memberStubBuilder) autoImportable(node *ast.Node) *ast.Node {
+	return makeAutoImportable(node, b.nodeBuilder, b.importAdder, b.ct.NodeFactory)
+}
+
+// createSignatureDeclarationFromSignature creates a method declaration with the parameters and return type of
+// a signature.
+func (b *memberStubBuilder) createSignatureDeclarationFromSignature(signature *checker.Signature, enclosingDeclaration *ast.Node, modifiers *ast.ModifierList, name *ast.Node, questionToken *ast.Node, body *ast.Node) *ast.Node {
+	flags := b.nodeBuilderFlags() | nodebuilder.FlagsSuppressAnyReturnType | nodebuilder.FlagsAllowEmptyTuple
+	signatureDeclaration := b.nodeBuilder.SignatureToSignatureDeclaration(signature, ast.KindMethodDeclaration, enclosingDeclaration, flags, nodebuilder.InternalFlagsNone, b.tracker)
+	if signatureDeclaration == nil || !ast.IsMethodDeclaration(signatureDeclaration) {
+		return nil
+	}
+	signatureDeclaration = b.autoImportable(signatureDeclaration)
+	method := signatureDeclaration.AsMethodDeclaration()
+	typeParameters, returnType := method.TypeParameters, method.Type
+	if ast.IsInJSFile(enclosingDeclaration) {
+		typeParameters, returnType = nil, nil
+	}
+	return b.ct.NodeFactory.NewMethodDeclaration(modifiers, method.AsteriskToken, name, questionToken, typeParameters, method.Parameters, returnType, nil /*fullSignature*/, body)
+}
+
+// createMethodImplementingSignatures creates a method that can implement each of the overloads of a method, with
+// as many parameters as the longest overload.
+func (b *memberStubBuilder) createMethodImplementingSignatures(signatures []*checker.Signature, enclosingDeclaration *ast.Node, modifiers *ast.ModifierList, name *ast.Node, questionToken *ast.Node) *ast.Node {
+	factory := b.ct.NodeFactory
+	maxArgsSignature := signatures[0]
+	minArgumentCount := signatures[0].MinArgumentCount()
+	someSigHasRestParameter := false
+	for _, signature := range signatures {
+		minArgumentCount = min(signature.MinArgumentCount(), minArgumentCount)
+		if signature.HasRestParameter() {
+			someSigHasRestParameter = true
+		}
+		if len(signature.Parameters()) >= len(maxArgsSignature.Parameters()) && (!signature.HasRestParameter() || maxArgsSignature.HasRestParameter()) {
+			maxArgsSignature = signature
+		}
+	}
+	maxNonRestArgs := len(maxArgsSignature.Parameters())
+	if maxArgsSignature.HasRestParameter() {
+		maxNonRestArgs--
+	}
+	maxArgsParameterSymbolNames := core.Map(maxArgsSignature.Parameters(), func(symbol *ast.Symbol) string { return symbol.Name })
+	parameters := b.createDummyParameters(maxNonRestArgs, maxArgsParameterSymbolNames, nil /*types*/, minArgumentCount)
+	if someSigHasRestParameter {
+		restName := "rest"
+		if maxNonRestArgs < len(maxArgsParameterSymbolNames) {
+			restName = maxArgsParameterSymbolNames[maxNonRestArgs]
+		}
+		var restQuestionToken *ast.Node
+		if maxNonRestArgs >= minArgumentCount {
+			restQuestionToken = factory.NewToken(ast.KindQuestionToken)
+		}
+		restParameter := factory.NewParameterDeclaration(
+			nil, /*modifiers*/
+			factory.NewToken(ast.KindDotDotDotToken),
+			factory.NewIdentifier(restName),
+			restQuestionToken,
+			factory.NewArrayTypeNode(factory.NewKeywordTypeNode(ast.KindUnknownKeyword)),
+			nil, /*initializer*/
+		)
+		parameters = factory.NewNodeList(append(parameters.Nodes, restParameter))
+	}
+	returnType := b.typeToTypeNode(b.checker.GetUnionType(core.Map(signatures, b.checker.GetReturnTypeOfSignature)), enclosingDeclaration)
+	return factory.NewMethodDeclaration(modifiers, nil /*asteriskToken*/, name, questionToken, nil /*typeParameters*/, parameters, returnType, nil /*fullSignature*/, b.createStubbedMethodBody())
+}
+
+// createDummyParameters creates argCount parameters with the given names and types. Parameters without a name
+// are named `argN`, parameters without a type are `unknown`, and parameters after minArgumentCount are optional.
+func (b *memberStubBuilder) createDummyParameters(argCount int, names []string, types []*ast.Node, minArgumentCount int) *ast.NodeList {
+	factory := b.ct.NodeFactory
+	isJS := ast.IsInJSFile(b.sourceFile.AsNode())
+	parameters := make([]*ast.Node, 0, argCount)
+	parameterNameCounts := make(map[string]int)
+	for i := range argCount {
+		parameterName := fmt.Sprintf("arg%d", i)
+		if i < len(names) && names[i] != "" {
+			parameterName = names[i]
+		}
+		parameterNameCount := parameterNameCounts[parameterName]
+		parameterNameCounts[parameterName] = parameterNameCount + 1
+		if parameterNameCount != 0 {
+			parameterName += strconv.Itoa(parameterNameCount)
+		}
+		var questionToken *ast.Node
+		if i >= minArgumentCount {
+			questionToken = factory.NewToken(ast.KindQuestionToken)
+		}
+		var typeNode *ast.Node
+		if !isJS {
+			if i < len(types) && types[i] != nil {
+				typeNode = types[i]
+			} else {
+				typeNode = factory.NewKeywordTypeNode(ast.KindUnknownKeyword)
+			}
+		}
+		parameters = append(parameters, factory.NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, factory.NewIdentifier(parameterName), questionToken, typeNode, nil /*initializer*/))
+	}
+	return factory.NewNodeList(parameters)
+}
+
+// createStubbedMethodBody creates the body `{ throw new Error("Method not implemented."); }`.
+func (b *memberStubBuilder) createStubbedMethodBody() *ast.Node {
+	factory := b.ct.NodeFactory
+	newError := factory.NewNewExpression(
+		factory.NewIdentifier("Error"),
+		nil, /*typeArguments*/
+		factory.NewNodeList([]*ast.Node{b.ct.makeStringLiteral(diagnostics.Method_not_implemented.Message(), b.quotePreference)}),
+	)
+	return factory.NewBlock(factory.NewNodeList([]*ast.Node{factory.NewThrowStatement(newError)}), true /*multiline*/)
+}
+
+// indexSignatureToDeclaration creates an index signature declaration for an index of a type.
+func (b *memberStubBuilder) indexSignatureToDeclaration(info *checker.IndexInfo, enclosingDeclaration *ast.Node) *ast.Node {
+	return b.autoImportable(b.nodeBuilder.IndexInfoToIndexSignatureDeclaration(info, enclosingDeclaration, b.nodeBuilderFlags(), nodebuilder.InternalFlagsNone, b.tracker))
+}
+
+// typeToAutoImportableTypeNode returns a type node for a type, or nil if the node would refer to a symbol that is
+// not accessible from the enclosing scope. The symbols of other modules that the node refers to are imported with
+// importAdder.
+func typeToAutoImportableTypeNode(c *checker.Checker, nodeBuilder *checker.NodeBuilder, importAdder *importAdder, factory *ast.NodeFactory, t *checker.Type, enclosingScope *ast.Node) *ast.Node {
+	return makeAutoImportable(typeNodeIfAccessible(c, nodeBuilder, importAdder.ls.GetProgram(), t, enclosingScope), nodeBuilder, importAdder, factory)
+}
+
+// typeNodeIfAccessible returns a type node for a type, or nil if the node would refer to a symbol that is not
+// accessible from the enclosing scope. The symbols of other modules are referred to with import types, whose
+// module specifiers host creates.
+func typeNodeIfAccessible(c *checker.Checker, nodeBuilder *checker.NodeBuilder, host modulespecifiers.ModuleSpecifierGenerationHost, t *checker.Type, enclosingScope *ast.Node) *ast.Node {
+	tracker := newAccessibilityTracker(c, host)
+	typeNode := nodeBuilder.TypeToTypeNode(t, enclosingScope, nodebuilder.FlagsNoTruncation, nodebuilder.InternalFlagsNone, tracker)
+	if !tracker.accessible {
+		return nil
+	}
+	return typeNode
+}
+
+// makeAutoImportable replaces the import types that a node builder created for the symbols of other modules, e.g.
+// `import("./a").Foo`, with references to the symbols by name, and adds imports of the symbols to importAdder.
+// Import types of symbols that can not be imported are kept.
+func makeAutoImportable(node *ast.Node, nodeBuilder *checker.NodeBuilder, importAdder *importAdder, factory *ast.NodeFactory) *ast.Node {
+	if node == nil {
+		return nil
+	}
+	var visitor *ast.NodeVisitor
+	visitor = ast.NewNodeVisitor(func(node *ast.Node) *ast.Node {
+		if ast.IsLiteralImportTypeNode(node) && node.AsImportTypeNode().Qualifier != nil {
+			importType := node.AsImportTypeNode()
+			firstIdentifier := ast.GetFirstIdentifier(importType.Qualifier)
+			// Import types written by the user have no symbol, and are kept as they are.
+			if symbol := nodeBuilder.SymbolOfIdentifier(firstIdentifier); symbol != nil {
+				if name := importAdder.addImportFromExportedSymbol(symbol, true /*isValidTypeOnlyUseSite*/); name != "" {
+					qualifier := importType.Qualifier
+					if name != firstIdentifier.Text() {
+						qualifier = replaceFirstIdentifierOfEntityName(factory, qualifier, factory.NewIdentifier(name))
+					}
+					typeArguments := visitor.VisitNodes(importType.TypeArguments)
+					if importType.IsTypeOf {
+						return factory.NewTypeQueryNode(qualifier, typeArguments)
+					}
+					return factory.NewTypeReferenceNode(qualifier, typeArguments)
+				}
+			}
+		}
+		return visitor.VisitEachChild(node)
+	}, factory, ast.NodeVisitorHooks{})
+	return visitor.VisitNode(node)
+}
+
+func replaceFirstIdentifierOfEntityName(factory *ast.NodeFactory, name *ast.Node, newIdentifier *ast.Node) *ast.Node {
+	if ast.IsIdentifier(name) {
+		return newIdentifier
+	}
+	qualifiedName := name.AsQualifiedName()
+	return factory.NewQualifiedName(replaceFirstIdentifierOfEntityName(factory, qualifiedName.Left, newIdentifier), qualifiedName.Right)
+}
+
+// noopSymbolTracker ignores what a node builder reports, but lets it create module specifiers for import types
+// relative to the file the nodes are created for.
+type noopSymbolTracker struct {
+	host modulespecifiers.ModuleSpecifierGenerationHost
+}
+
+var _ nodebuilder.SymbolTracker = (*noopSymbolTracker)(nil)
+
+func (t *noopSymbolTracker) GetModuleSpecifierGenerationHost() modulespecifiers.ModuleSpecifierGenerationHost {
+	return t.host
+}
+
+func (t *noopSymbolTracker) TrackSymbol(symbol *ast.Symbol, enclosingDeclaration *ast.Node, meaning ast.SymbolFlags) bool {
+	return false
+}
+
+func (t *noopSymbolTracker) ReportInaccessibleThisError() {}
+
+func (t *noopSymbolTracker) ReportPrivateInBaseOfClassExpression(propertyName string) {}
+
+func (t *noopSymbolTracker) ReportInaccessibleUniqueSymbolError() {}
+
+func (t *noopSymbolTracker) ReportCyclicStructureError() {}
+
+func (t *noopSymbolTracker) ReportLikelyUnsafeImportRequiredError(specifier string) {}
+
+func (t *noopSymbolTracker) ReportTruncationError() {}
+
+func (t *noopSymbolTracker) ReportNonlocalAugmentation(containingFile *ast.SourceFile, parentSymbol *ast.Symbol, augmentingSymbol *ast.Symbol) {
+}
+
+func (t *noopSymbolTracker) ReportNonSerializableProperty(propertyName string) {}
+
+func (t *noopSymbolTracker) ReportInferenceFallback(node *ast.Node) {}
+
+func (t *noopSymbolTracker) PushErrorFallbackNode(node *ast.Node) {}
+
+func (t *noopSymbolTracker) PopErrorFallbackNode() {}
+
+// accessibilityTracker records whether the nodes built by a node builder only refer to symbols that are
+// accessible where the nodes are used. Symbols exported from other modules count as accessible, since the node
+// builder writes import types for them, which can be replaced with imports.
+type accessibilityTracker struct {
+	noopSymbolTracker
+	checker    *checker.Checker
+	accessible bool
+}
+
+var _ nodebuilder.SymbolTracker = (*accessibilityTracker)(nil)
+
+func newAccessibilityTracker(c *checker.Checker, host modulespecifiers.ModuleSpecifierGenerationHost) *accessibilityTracker {
+	return &accessibilityTracker{
+		noopSymbolTracker: noopSymbolTracker{host: host},
+		checker:           c,
+		accessible:        true,
+	}
+}
+
+func (t *accessibilityTracker) TrackSymbol(symbol *ast.Symbol, enclosingDeclaration *ast.Node, meaning ast.SymbolFlags) bool {
+	switch t.checker.IsSymbolAccessible(symbol, enclosingDeclaration, meaning, false /*shouldComputeAliasesToMakeVisible*/).Accessibility {
+	case printer.SymbolAccessibilityAccessible:
+	case printer.SymbolAccessibilityCannotBeNamed:
+		t.accessible = t.accessible && symbol.Parent != nil && checker.IsExternalModuleSymbol(symbol.Parent)
+	default:
+		t.accessible = false
+	}
+	return !t.accessible
+}
+
+func (t *accessibilityTracker) ReportInaccessibleThisError() {
+	t.accessible = false
+}
+
+func (t *accessibilityTracker) ReportPrivateInBaseOfClassExpression(propertyName string) {
+	t.accessible = false
+}
+
+func (t *accessibilityTracker) ReportInaccessibleUniqueSymbolError() {
+	t.accessible = false
+}
@@ -0,0 +1,164 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+// ShowReferencesCommand is the command that code lenses run to list the locations they count. The server does not
+// run it; clients implement it to show the locations. Its arguments are the URI and position of the declaration
+// and the locations, as LSP values. The VS Code extension converts them to run `editor.action.showReferences`,
+// which does not accept LSP values.
+const ShowReferencesCommand = "typescript.showReferences"
+
+type codeLensKind string
+
+const (
+	codeLensKindReferences      codeLensKind = "references"
+	codeLensKindImplementations codeLensKind = "implementations"
+)
+
+type codeLensData struct {
+	Uri      lsproto.DocumentUri `json:"uri"`
+	Position lsproto.Position    `json:"position"`
+	Kind     codeLensKind        `json:"kind"`
+}
+
+// ProvideCodeLenses returns unresolved reference lenses for the exported functions, classes and interfaces of a
+// file and the abstract members of its exported classes, and implementation lenses for its interfaces, abstract
+// classes and abstract members. The counts are computed when a lens is resolved.
+func (l *LanguageService) ProvideCodeLenses(ctx context.Context, documentURI lsproto.DocumentUri) (lsproto.CodeLensResponse, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	var lenses []*lsproto.CodeLens
+	addLenses := func(declaration *ast.Node, kinds ...codeLensKind) {
+		name := declaration.Name()
+		if name == nil {
+			// e.g. `export default class {}`
+			return
+		}
+		lspRange := l.createLspRangeFromNode(name, file)
+		for _, kind := range kinds {
+			var data any = &codeLensData{Uri: documentURI, Position: lspRange.Start, Kind: kind}
+			lenses = append(lenses, &lsproto.CodeLens{Range: *lspRange, Data: &data})
+		}
+	}
+
+	var visitStatements func(statements []*ast.Node)
+	visitStatements = func(statements []*ast.Node) {
+		for _, statement := range statements {
+			if ctx.Err() != nil {
+				return
+			}
+			if ast.IsModuleDeclaration(statement) {
+				if body := statement.Body(); body != nil && ast.IsModuleBlock(body) {
+					visitStatements(body.Statements())
+				}
+				continue
+			}
+			if !ast.HasSyntacticModifier(statement, ast.ModifierFlagsExport) {
+				continue
+			}
+			switch statement.Kind {
+			case ast.KindInterfaceDeclaration:
+				addLenses(statement, codeLensKindReferences, codeLensKindImplementations)
+			case ast.KindClassDeclaration:
+				if !ast.HasSyntacticModifier(statement, ast.ModifierFlagsAbstract) {
+					addLenses(statement, codeLensKindReferences)
+					continue
+				}
+				addLenses(statement, codeLensKindReferences, codeLensKindImplementations)
+				for _, member := range statement.Members() {
+					if ast.HasSyntacticModifier(member, ast.ModifierFlagsAbstract) {
+						addLenses(member, codeLensKindReferences, codeLensKindImplementations)
+					}
+				}
+			case ast.KindFunctionDeclaration:
+				// Overloads share the lens of the implementation.
+				if statement.Body() != nil {
+					addLenses(statement, codeLensKindReferences)
+				}
+			}
+		}
+	}
+	visitStatements(file.Statements.Nodes)
+	return lsproto.CodeLenssOrNull{CodeLenss: &lenses}, ctx.Err()
+}
+
+func GetCodeLensData(codeLens *lsproto.CodeLens) (*codeLensData, error) {
+	bytes, err := json.Marshal(codeLens.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal code lens data: %w", err)
+	}
+	var data codeLensData
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal code lens data: %w", err)
+	}
+	return &data, nil
+}
+
+// ResolveCodeLens counts the references or implementations of the declaration of a code lens, not counting the
+// declaration itself, and sets the command of the lens to show them.
+func (l *LanguageService) ResolveCodeLens(ctx context.Context, codeLens *lsproto.CodeLens, data *codeLensData) (*lsproto.CodeLens, error) {
+	var locations []lsproto.Location
+	var singular, plural string
+	switch data.Kind {
+	case codeLensKindReferences:
+		program, file := l.getProgramAndFile(data.Uri)
+		position := int(l.converters.LineAndCharacterToPosition(file, data.Position))
+		node := astnav.GetTouchingPropertyName(file, position)
+		symbolsAndEntries := l.getReferencedSymbolsForNode(ctx, position, node, program, program.GetSourceFiles(), refOptions{use: referenceUseReferences}, nil)
+		for _, symbolAndEntries := range symbolsAndEntries {
+			// Declarations, e.g. of overloads or of the members a member overrides, are not counted as references.
+			// Imports are.
+			references := core.Filter(symbolAndEntries.references, func(entry *referenceEntry) bool {
+				return symbolAndEntries.definition == nil || !isDeclarationNameOfSymbol(entry.node, symbolAndEntries.definition.symbol)
+			})
+			locations = append(locations, l.convertEntriesToLocations(references)...)
+		}
+		singular, plural = "reference", "references"
+	case codeLensKindImplementations:
+		implementations, err := l.ProvideImplementations(ctx, &lsproto.ImplementationParams{TextDocument: lsproto.TextDocumentIdentifier{Uri: data.Uri}, Position: data.Position})
+		if err != nil {
+			return nil, err
+		}
+		if implementations.Locations != nil {
+			locations = *implementations.Locations
+		}
+		singular, plural = "implementation", "implementations"
+	default:
+		return nil, fmt.Errorf("unknown code lens kind %q", data.Kind)
+	}
+
+	// The declaration of the lens is not counted.
+	filtered := make([]lsproto.Location, 0, len(locations))
+	for _, location := range locations {
+		if location.Uri != data.Uri || location.Range.Start != data.Position {
+			filtered = append(filtered, location)
+		}
+	}
+	title := fmt.Sprintf("%d %s", len(filtered), plural)
+	if len(filtered) == 1 {
+		title = "1 " + singular
+	}
+	return &lsproto.CodeLens{
+		Range: codeLens.Range,
+		Command: &lsproto.Command{
+			Title:     title,
+			Command:   ShowReferencesCommand,
+			Arguments: &[]any{data.Uri, data.Position, filtered},
+		},
+		Data: codeLens.Data,
+	}, nil
+}
+
+// isDeclarationNameOfSymbol reports whether a node is the name of a declaration of a symbol other than an alias.
+func isDeclarationNameOfSymbol(node *ast.Node, symbol *ast.Symbol) bool {
+	return node != nil && symbol != nil && symbol.Flags&ast.SymbolFlagsAlias == 0 && node.Parent != nil && slices.Contains(symbol.Declarations, node.Parent) && ast.GetNameOfDeclaration(node.Parent) == node
+}
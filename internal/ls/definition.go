@@ -47,6 +47,10 @@ func (l *LanguageService) ProvideDefinition(ctx context.Context, documentURI lsp
 		}
 	}
 
+	return l.createLocationsFromDeclarations(getDefinitionDeclarations(c, node)), nil
+}
+
+func getDefinitionDeclarations(c *checker.Checker, node *ast.Node) []*ast.Node {
 	declarations := getDeclarationsFromLocation(c, node)
 	calledDeclaration := tryGetSignatureDeclaration(c, node)
 	if calledDeclaration != nil {
@@ -54,7 +58,7 @@ func (l *LanguageService) ProvideDefinition(ctx context.Context, documentURI lsp
 		nonFunctionDeclarations := core.Filter(slices.Clip(declarations), func(node *ast.Node) bool { return !ast.IsFunctionLike(node) })
 		declarations = append(nonFunctionDeclarations, calledDeclaration)
 	}
-	return l.createLocationsFromDeclarations(declarations), nil
+	return declarations
 }
 
 func (l *LanguageService) ProvideTypeDefinition(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (lsproto.DefinitionResponse, error) {
@@ -2,17 +2,152 @@ package ls
 
 import (
 	"context"
+	"encoding/hex"
 	"strings"
+	"sync"
 
+	"github.com/go-json-experiment/json"
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
 	"github.com/microsoft/typescript-go/internal/diagnostics"
 	"github.com/microsoft/typescript-go/internal/diagnosticwriter"
 	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/zeebo/xxh3"
+	"golang.org/x/text/language"
 )
 
-func (l *LanguageService) ProvideDiagnostics(ctx context.Context, uri lsproto.DocumentUri) (lsproto.DocumentDiagnosticResponse, error) {
+// ProvideDiagnostics returns the diagnostics of a file. If they are the same as the ones the client received
+// with previousResultId, only an unchanged report is returned.
+func (l *LanguageService) ProvideDiagnostics(ctx context.Context, uri lsproto.DocumentUri, previousResultId *string) (lsproto.DocumentDiagnosticResponse, error) {
 	program, file := l.getProgramAndFile(uri)
+	items := getFileDiagnostics(ctx, program, file, l.converters)
+	resultId := getDiagnosticsResultId(items)
+	if previousResultId != nil && *previousResultId == resultId {
+		return lsproto.RelatedFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
+			UnchangedDocumentDiagnosticReport: &lsproto.RelatedUnchangedDocumentDiagnosticReport{
+				ResultId: resultId,
+			},
+		}, nil
+	}
+	return lsproto.RelatedFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
+		FullDocumentDiagnosticReport: &lsproto.RelatedFullDocumentDiagnosticReport{
+			ResultId: &resultId,
+			Items:    items,
+		},
+	}, nil
+}
+
+// WorkspaceDiagnosticsCache keeps the diagnostics of the files of the programs last passed to
+// ProvideWorkspaceDiagnostics. The diagnostics of a file depend on the other files of its program, so they are
+// reused for as long as the program is unchanged. The zero value is ready to use.
+type WorkspaceDiagnosticsCache struct {
+	mu       sync.Mutex
+	programs map[*compiler.Program]*collections.SyncMap[tspath.Path, *workspaceFileDiagnostics]
+}
+
+type workspaceFileDiagnostics struct {
+	locale   language.Tag
+	items    []*lsproto.Diagnostic
+	resultId string
+}
+
+// filesOf returns the cached diagnostics of the files of each program, and forgets the programs that are no
+// longer in use.
+func (c *WorkspaceDiagnosticsCache) filesOf(programs []*compiler.Program) []*collections.SyncMap[tspath.Path, *workspaceFileDiagnostics] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cached := make(map[*compiler.Program]*collections.SyncMap[tspath.Path, *workspaceFileDiagnostics], len(programs))
+	files := make([]*collections.SyncMap[tspath.Path, *workspaceFileDiagnostics], len(programs))
+	for i, program := range programs {
+		if cached[program] = c.programs[program]; cached[program] == nil {
+			cached[program] = &collections.SyncMap[tspath.Path, *workspaceFileDiagnostics]{}
+		}
+		files[i] = cached[program]
+	}
+	c.programs = cached
+	return files
+}
 
+// ProvideWorkspaceDiagnostics returns the diagnostics of every file of the programs, including files that are
+// not open. Files of the default library and of external libraries are skipped. A file that is part of
+// several programs is reported for the first of them. Files whose diagnostics are the same as the ones the
+// client received with the result ID in previousResultIds only get an unchanged report, and files of
+// previousResultIds that are no longer part of any program get an empty report, so that the client clears
+// their diagnostics. getVersion returns the version of an open file, or nil if the file is not open. Only the
+// files of programs that changed since the last call are checked again.
+func (c *WorkspaceDiagnosticsCache) ProvideWorkspaceDiagnostics(ctx context.Context, programs []*compiler.Program, converters *Converters, previousResultIds []lsproto.PreviousResultId, getVersion func(fileName string) *int32) (lsproto.WorkspaceDiagnosticResponse, error) {
+	previousResultIdsByUri := make(map[lsproto.DocumentUri]string, len(previousResultIds))
+	for _, previous := range previousResultIds {
+		previousResultIdsByUri[previous.Uri] = previous.Value
+	}
+
+	locale := core.GetLocale(ctx)
+	cachedFiles := c.filesOf(programs)
+	var seenFiles collections.Set[tspath.Path]
+	var reportedUris collections.Set[lsproto.DocumentUri]
+	var items []lsproto.WorkspaceFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport
+	for i, program := range programs {
+		for _, file := range program.GetSourceFiles() {
+			if program.IsSourceFileDefaultLibrary(file.Path()) || program.IsSourceFileFromExternalLibrary(file) || !seenFiles.AddIfAbsent(file.Path()) {
+				continue
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			uri := FileNameToDocumentURI(file.FileName())
+			reportedUris.Add(uri)
+			version := lsproto.IntegerOrNull{Integer: getVersion(file.FileName())}
+			fileDiagnostics, ok := cachedFiles[i].Load(file.Path())
+			if !ok || fileDiagnostics.locale != locale {
+				fileItems := getFileDiagnostics(ctx, program, file, converters)
+				if ctx.Err() != nil {
+					// Diagnostics computed for a canceled request may be incomplete.
+					return nil, ctx.Err()
+				}
+				fileDiagnostics = &workspaceFileDiagnostics{locale: locale, items: fileItems, resultId: getDiagnosticsResultId(fileItems)}
+				cachedFiles[i].Store(file.Path(), fileDiagnostics)
+			}
+			resultId := fileDiagnostics.resultId
+			if previousResultIdsByUri[uri] == resultId {
+				items = append(items, lsproto.WorkspaceFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
+					UnchangedDocumentDiagnosticReport: &lsproto.WorkspaceUnchangedDocumentDiagnosticReport{
+						ResultId: resultId,
+						Uri:      uri,
+						Version:  version,
+					},
+				})
+				continue
+			}
+			items = append(items, lsproto.WorkspaceFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
+				FullDocumentDiagnosticReport: &lsproto.WorkspaceFullDocumentDiagnosticReport{
+					ResultId: &resultId,
+					Items:    fileDiagnostics.items,
+					Uri:      uri,
+					Version:  version,
+				},
+			})
+		}
+	}
+	for _, previous := range previousResultIds {
+		if !reportedUris.AddIfAbsent(previous.Uri) {
+			continue
+		}
+		// Without a result ID, the client stops sending the file with later requests.
+		items = append(items, lsproto.WorkspaceFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
+			FullDocumentDiagnosticReport: &lsproto.WorkspaceFullDocumentDiagnosticReport{
+				Items:   []*lsproto.Diagnostic{},
+				Uri:     previous.Uri,
+				Version: lsproto.IntegerOrNull{Integer: getVersion(previous.Uri.FileName())},
+			},
+		})
+	}
+	return &lsproto.WorkspaceDiagnosticReport{Items: items}, nil
+}
+
+func getFileDiagnostics(ctx context.Context, program *compiler.Program, file *ast.SourceFile, converters *Converters) []*lsproto.Diagnostic {
 	diagnostics := make([][]*ast.Diagnostic, 0, 4)
 	diagnostics = append(diagnostics, program.GetSyntacticDiagnostics(ctx, file))
 	diagnostics = append(diagnostics, program.GetSemanticDiagnostics(ctx, file))
@@ -22,15 +157,21 @@ func (l *LanguageService) ProvideDiagnostics(ctx context.Context, uri lsproto.Do
 	if program.Options().GetEmitDeclarations() {
 		diagnostics = append(diagnostics, program.GetDeclarationDiagnostics(ctx, file))
 	}
+	return toLSPDiagnostics(converters, core.GetLocale(ctx), diagnostics...)
+}
 
-	return lsproto.RelatedFullDocumentDiagnosticReportOrUnchangedDocumentDiagnosticReport{
-		FullDocumentDiagnosticReport: &lsproto.RelatedFullDocumentDiagnosticReport{
-			Items: toLSPDiagnostics(l.converters, diagnostics...),
-		},
-	}, nil
+// getDiagnosticsResultId identifies a set of diagnostics by a hash of their contents, so that the result ID
+// only changes when the diagnostics the client would display do.
+func getDiagnosticsResultId(items []*lsproto.Diagnostic) string {
+	data, err := json.Marshal(items)
+	if err != nil {
+		panic(err)
+	}
+	hash := xxh3.Hash128(data).Bytes()
+	return hex.EncodeToString(hash[:])
 }
 
-func toLSPDiagnostics(converters *Converters, diagnostics ...[]*ast.Diagnostic) []*lsproto.Diagnostic {
+func toLSPDiagnostics(converters *Converters, locale language.Tag, diagnostics ...[]*ast.Diagnostic) []*lsproto.Diagnostic {
 	size := 0
 	for _, diagSlice := range diagnostics {
 		size += len(diagSlice)
@@ -38,13 +179,13 @@ func toLSPDiagnostics(converters *Converters, diagnostics ...[]*ast.Diagnostic)
 	lspDiagnostics := make([]*lsproto.Diagnostic, 0, size)
 	for _, diagSlice := range diagnostics {
 		for _, diag := range diagSlice {
-			lspDiagnostics = append(lspDiagnostics, toLSPDiagnostic(converters, diag))
+			lspDiagnostics = append(lspDiagnostics, toLSPDiagnostic(converters, locale, diag))
 		}
 	}
 	return lspDiagnostics
 }
 
-func toLSPDiagnostic(converters *Converters, diagnostic *ast.Diagnostic) *lsproto.Diagnostic {
+func toLSPDiagnostic(converters *Converters, locale language.Tag, diagnostic *ast.Diagnostic) *lsproto.Diagnostic {
 	var severity lsproto.DiagnosticSeverity
 	switch diagnostic.Category() {
 	case diagnostics.CategorySuggestion:
@@ -64,7 +205,7 @@ func toLSPDiagnostic(converters *Converters, diagnostic *ast.Diagnostic) *lsprot
 				Uri:   FileNameToDocumentURI(related.File().FileName()),
 				Range: converters.ToLSPRange(related.File(), related.Loc()),
 			},
-			Message: related.Message(),
+			Message: related.Localize(locale),
 		})
 	}
 
@@ -85,19 +226,19 @@ func toLSPDiagnostic(converters *Converters, diagnostic *ast.Diagnostic) *lsprot
 			Integer: ptrTo(diagnostic.Code()),
 		},
 		Severity:           &severity,
-		Message:            messageChainToString(diagnostic),
+		Message:            messageChainToString(diagnostic, locale),
 		Source:             ptrTo("ts"),
 		RelatedInformation: ptrToSliceIfNonEmpty(relatedInformation),
 		Tags:               ptrToSliceIfNonEmpty(tags),
 	}
 }
 
-func messageChainToString(diagnostic *ast.Diagnostic) string {
+func messageChainToString(diagnostic *ast.Diagnostic, locale language.Tag) string {
 	if len(diagnostic.MessageChain()) == 0 {
-		return diagnostic.Message()
+		return diagnostic.Localize(locale)
 	}
 	var b strings.Builder
-	diagnosticwriter.WriteFlattenedDiagnosticMessage(&b, diagnostic, "\n")
+	diagnosticwriter.WriteFlattenedDiagnosticMessage(&b, diagnostic, "\n", locale)
 	return b.String()
 }
 
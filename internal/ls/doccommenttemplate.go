@@ -0,0 +1,263 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+)
+
+// getDocCommentTemplateCompletions returns a completion that expands a `/**` typed on the line before a declaration,
+// and the `*/` editors insert after it, into a JSDoc comment with a `@param` tag for each parameter of the declaration
+// and a `@returns` tag if it returns a value.
+func (l *LanguageService) getDocCommentTemplateCompletions(
+	ctx context.Context,
+	file *ast.SourceFile,
+	position int,
+	preferences *UserPreferences,
+	clientOptions *lsproto.CompletionClientCapabilities,
+) *lsproto.CompletionList {
+	text := file.Text()
+	lineStart := int(scanner.GetECMALineStarts(file)[getLineOfPosition(file, position)])
+	linePrefix := strings.TrimRight(text[lineStart:position], " \t")
+	if !strings.HasSuffix(linePrefix, "/**") || strings.HasSuffix(linePrefix, "//**") || strings.HasSuffix(linePrefix, "/***") {
+		return nil
+	}
+	start := lineStart + len(linePrefix) - len("/**")
+	end := position
+	lineSuffix := text[position:getLineEndOfPosition(file, position)]
+	if trimmed := strings.TrimLeft(lineSuffix, " \t"); strings.HasPrefix(trimmed, "*/") {
+		end += len(lineSuffix) - len(trimmed) + len("*/")
+	}
+
+	program := l.GetProgram()
+	checker, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+	isSnippet := clientSupportsItemSnippet(clientOptions) && !preferences.IncludeCompletionsWithSnippetText.IsFalse()
+	template := getDocCommentTemplate(checker, file, position, program.Options(), preferences, isSnippet)
+	if template == "" {
+		return nil
+	}
+
+	return &lsproto.CompletionList{
+		Items: []*lsproto.CompletionItem{{
+			Label:            "/** */",
+			Kind:             ptrTo(lsproto.CompletionItemKindText),
+			SortText:         ptrTo(string(SortTextLocationPriority)),
+			InsertTextFormat: core.IfElse(isSnippet, ptrTo(lsproto.InsertTextFormatSnippet), nil),
+			TextEdit: &lsproto.TextEditOrInsertReplaceEdit{
+				TextEdit: &lsproto.TextEdit{
+					Range:   *l.createLspRangeFromBounds(start, end, file),
+					NewText: template,
+				},
+			},
+		}},
+	}
+}
+
+// getDocCommentTemplate returns the JSDoc comment for the declaration that a comment at position documents, or "" if
+// there is none or it is already documented. In snippets, the description is the final tab stop and the parameter
+// descriptions and types that are not known are placeholders.
+func getDocCommentTemplate(
+	c *checker.Checker,
+	file *ast.SourceFile,
+	position int,
+	options *core.CompilerOptions,
+	preferences *UserPreferences,
+	isSnippet bool,
+) string {
+	tokenAtPos := astnav.GetTokenAtPosition(file, position)
+	existingDocComment := ast.FindAncestor(tokenAtPos, (*ast.Node).IsJSDoc)
+	if existingDocComment != nil && (hasNodes(existingDocComment.AsJSDoc().Comment) || hasNodes(existingDocComment.AsJSDoc().Tags)) {
+		// The comment is not empty.
+		return ""
+	}
+	// Don't document a declaration that precedes the position, unless the position is in an empty comment on it.
+	tokenStart := scanner.GetTokenPosOfNode(tokenAtPos, file, false /*includeJSDoc*/)
+	if existingDocComment == nil && tokenStart < position {
+		return ""
+	}
+
+	var owner *commentOwnerInfo
+	for node := tokenAtPos; node != nil && owner == nil; node = node.Parent {
+		var quit bool
+		if owner, quit = getCommentOwnerInfo(node); quit {
+			return ""
+		}
+	}
+	if owner == nil || scanner.GetTokenPosOfNode(owner.commentOwner, file, false /*includeJSDoc*/) < position {
+		return ""
+	}
+	jsDocs := owner.commentOwner.JSDoc(file)
+	if len(jsDocs) > 0 && existingDocComment != nil && jsDocs[len(jsDocs)-1] != existingDocComment {
+		return ""
+	}
+	hasTag := core.Some(jsDocs, func(jsDoc *ast.Node) bool {
+		return hasNodes(jsDoc.AsJSDoc().Tags)
+	})
+
+	newLine := options.NewLine.GetNewLineCharacter()
+	var tags []string
+	isJS := ast.IsSourceFileJS(file)
+	tabstopCounter := 1
+	for i, parameter := range owner.parameters {
+		// Destructured parameters are documented by position.
+		name := fmt.Sprintf("param%d", i)
+		if ast.IsIdentifier(parameter.Name()) {
+			name = parameter.Name().Text()
+		}
+		tags = append(tags, getJSDocParamAnnotation(
+			name,
+			parameter.Initializer(),
+			parameter.AsParameterDeclaration().DotDotDotToken,
+			isJS,
+			/*isObject*/ false,
+			isSnippet,
+			c,
+			options,
+			preferences,
+			&tabstopCounter,
+		))
+	}
+	if owner.hasReturn && hasNonVoidReturn(c, owner.returnOwner) {
+		if isSnippet {
+			tags = append(tags, fmt.Sprintf("@returns ${%d}", tabstopCounter))
+		} else {
+			tags = append(tags, "@returns")
+		}
+	}
+
+	caret := core.IfElse(isSnippet, "$0", "")
+	if len(tags) == 0 || hasTag {
+		return "/** " + caret + " */"
+	}
+	indentation := getIndentationStringAtPosition(file, position)
+	var b strings.Builder
+	b.WriteString("/**" + newLine + indentation + " * " + caret + newLine)
+	for _, tag := range tags {
+		if !isSnippet {
+			tag = strings.TrimRight(tag, " ")
+		}
+		b.WriteString(indentation + " * " + tag + newLine)
+	}
+	b.WriteString(indentation + " */")
+	return b.String()
+}
+
+type commentOwnerInfo struct {
+	commentOwner *ast.Node
+	parameters   []*ast.ParameterDeclarationNode
+	// hasReturn is set if the comment owner is or holds a function other than a constructor, returnOwner.
+	hasReturn   bool
+	returnOwner *ast.Node
+}
+
+// getCommentOwnerInfo returns the declaration a JSDoc comment on a node or one of its ancestors documents, or
+// reports that the search should stop.
+func getCommentOwnerInfo(commentOwner *ast.Node) (*commentOwnerInfo, bool) {
+	functionInfo := func(owner *ast.Node, function *ast.Node) *commentOwnerInfo {
+		return &commentOwnerInfo{
+			commentOwner: owner,
+			parameters:   function.Parameters(),
+			hasReturn:    !ast.IsConstructorDeclaration(function),
+			returnOwner:  function,
+		}
+	}
+	switch commentOwner.Kind {
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindMethodDeclaration, ast.KindConstructor,
+		ast.KindMethodSignature, ast.KindArrowFunction:
+		return functionInfo(commentOwner, commentOwner), false
+	case ast.KindPropertyAssignment:
+		return getCommentOwnerInfo(commentOwner.Initializer())
+	case ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindEnumDeclaration, ast.KindEnumMember,
+		ast.KindTypeAliasDeclaration:
+		return &commentOwnerInfo{commentOwner: commentOwner}, false
+	case ast.KindPropertySignature:
+		if typeNode := commentOwner.Type(); typeNode != nil && ast.IsFunctionTypeNode(typeNode) {
+			return functionInfo(commentOwner, typeNode), false
+		}
+		return &commentOwnerInfo{commentOwner: commentOwner}, false
+	case ast.KindVariableStatement:
+		declarations := commentOwner.AsVariableStatement().DeclarationList.AsVariableDeclarationList().Declarations.Nodes
+		if len(declarations) == 1 && declarations[0].Initializer() != nil {
+			if function := getFunctionOfInitializer(declarations[0].Initializer()); function != nil {
+				return functionInfo(commentOwner, function), false
+			}
+		}
+		return &commentOwnerInfo{commentOwner: commentOwner}, false
+	case ast.KindSourceFile:
+		return nil, true
+	case ast.KindModuleDeclaration:
+		// Inner names of `namespace a.b.c {}` are not documented separately.
+		if ast.IsModuleDeclaration(commentOwner.Parent) {
+			return nil, false
+		}
+		return &commentOwnerInfo{commentOwner: commentOwner}, false
+	case ast.KindExpressionStatement:
+		return getCommentOwnerInfo(commentOwner.Expression())
+	case ast.KindBinaryExpression:
+		binary := commentOwner.AsBinaryExpression()
+		if ast.GetAssignmentDeclarationKind(binary) == ast.JSDeclarationKindNone {
+			return nil, true
+		}
+		if ast.IsFunctionLike(binary.Right) {
+			return functionInfo(commentOwner, binary.Right), false
+		}
+		return &commentOwnerInfo{commentOwner: commentOwner}, false
+	case ast.KindPropertyDeclaration:
+		if initializer := commentOwner.Initializer(); initializer != nil && (ast.IsFunctionExpression(initializer) || ast.IsArrowFunction(initializer)) {
+			return functionInfo(commentOwner, initializer), false
+		}
+	}
+	return nil, false
+}
+
+// getFunctionOfInitializer returns the function or the constructor of the class an initializer creates, if any.
+func getFunctionOfInitializer(initializer *ast.Node) *ast.Node {
+	initializer = ast.SkipParentheses(initializer)
+	switch initializer.Kind {
+	case ast.KindFunctionExpression, ast.KindArrowFunction:
+		return initializer
+	case ast.KindClassExpression:
+		return core.Find(initializer.Members(), ast.IsConstructorDeclaration)
+	}
+	return nil
+}
+
+// hasNonVoidReturn reports whether the return type of a function, or the type its promise resolves to if it is
+// async, is a value.
+func hasNonVoidReturn(c *checker.Checker, function *ast.Node) bool {
+	signature := c.GetSignatureFromDeclaration(function)
+	if signature == nil {
+		return false
+	}
+	returnType := c.GetReturnTypeOfSignature(signature)
+	if ast.HasSyntacticModifier(function, ast.ModifierFlagsAsync) {
+		if promisedType := c.GetPromisedTypeOfPromise(returnType); promisedType != nil {
+			returnType = promisedType
+		}
+	}
+	return returnType.Flags()&(checker.TypeFlagsVoid|checker.TypeFlagsUndefined|checker.TypeFlagsNever) == 0
+}
+
+func hasNodes(list *ast.NodeList) bool {
+	return list != nil && len(list.Nodes) > 0
+}
+
+// getIndentationStringAtPosition returns the whitespace at the start of the line of a position.
+func getIndentationStringAtPosition(file *ast.SourceFile, position int) string {
+	text := file.Text()
+	lineStart := int(scanner.GetECMALineStarts(file)[getLineOfPosition(file, position)])
+	pos := lineStart
+	for pos < position && stringutil.IsWhiteSpaceSingleLine(rune(text[pos])) {
+		pos++
+	}
+	return text[lineStart:pos]
+}
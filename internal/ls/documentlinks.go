@@ -0,0 +1,117 @@
+package ls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// ProvideDocumentLinks returns links from the module specifiers and triple-slash references of a file to the
+// files the program resolved them to. In tsconfig and jsconfig files, `extends` and the paths of `references`
+// are linked to the config files they refer to.
+func (l *LanguageService) ProvideDocumentLinks(ctx context.Context, documentURI lsproto.DocumentUri) (lsproto.DocumentLinkResponse, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	var links []*lsproto.DocumentLink
+	addLink := func(textRange core.TextRange, target string) {
+		uri := lsproto.URI(FileNameToDocumentURI(target))
+		links = append(links, &lsproto.DocumentLink{
+			Range:  *l.createLspRangeFromRange(textRange, file),
+			Target: &uri,
+		})
+	}
+
+	if ast.IsJsonSourceFile(file) {
+		if isConfigFileName(file.FileName()) {
+			l.addConfigFileLinks(program, file, addLink)
+		}
+		return lsproto.DocumentLinksOrNull{DocumentLinks: &links}, nil
+	}
+
+	for _, moduleSpecifier := range file.Imports() {
+		if resolved := program.GetResolvedModuleFromModuleSpecifier(file, moduleSpecifier); resolved.IsResolved() {
+			addLink(getStringLiteralTextRange(moduleSpecifier, file), resolved.ResolvedFileName)
+		}
+	}
+	for _, reference := range file.ReferencedFiles {
+		if referencedFile := program.GetSourceFileFromReference(file, reference); referencedFile != nil {
+			addLink(reference.TextRange, referencedFile.FileName())
+		}
+	}
+	for _, reference := range file.TypeReferenceDirectives {
+		if resolved := program.GetResolvedTypeReferenceDirectiveFromTypeReferenceDirective(reference, file); resolved != nil && resolved.IsResolved() {
+			addLink(reference.TextRange, resolved.ResolvedFileName)
+		}
+	}
+	return lsproto.DocumentLinksOrNull{DocumentLinks: &links}, nil
+}
+
+// addConfigFileLinks adds links for the `extends` value and the paths of the `references` of a config file.
+func (l *LanguageService) addConfigFileLinks(program *compiler.Program, file *ast.SourceFile, addLink func(textRange core.TextRange, target string)) {
+	if len(file.Statements.Nodes) == 0 {
+		return
+	}
+	root := file.Statements.Nodes[0].Expression()
+	if root == nil || !ast.IsObjectLiteralExpression(root) {
+		return
+	}
+	fs := program.Host().FS()
+	configDirectory := tspath.GetDirectoryPath(file.FileName())
+	for _, property := range root.Properties() {
+		if !ast.IsPropertyAssignment(property) || !ast.IsStringLiteral(property.Name()) {
+			continue
+		}
+		switch property.Name().Text() {
+		case "extends":
+			values := []*ast.Node{property.Initializer()}
+			if ast.IsArrayLiteralExpression(property.Initializer()) {
+				values = property.Initializer().AsArrayLiteralExpression().Elements.Nodes
+			}
+			for _, value := range values {
+				if !ast.IsStringLiteral(value) {
+					continue
+				}
+				if target := tsoptions.GetExtendsConfigPath(value.Text(), program.Host(), configDirectory); target != "" && fs.FileExists(target) {
+					addLink(getStringLiteralTextRange(value, file), target)
+				}
+			}
+		case "references":
+			if !ast.IsArrayLiteralExpression(property.Initializer()) {
+				continue
+			}
+			for _, reference := range property.Initializer().AsArrayLiteralExpression().Elements.Nodes {
+				if !ast.IsObjectLiteralExpression(reference) {
+					continue
+				}
+				for _, referenceProperty := range reference.Properties() {
+					if !ast.IsPropertyAssignment(referenceProperty) || !ast.IsStringLiteral(referenceProperty.Name()) || referenceProperty.Name().Text() != "path" || !ast.IsStringLiteral(referenceProperty.Initializer()) {
+						continue
+					}
+					path := referenceProperty.Initializer()
+					target := core.ResolveConfigFileNameOfProjectReference(tspath.GetNormalizedAbsolutePath(path.Text(), configDirectory))
+					if fs.FileExists(target) {
+						addLink(getStringLiteralTextRange(path, file), target)
+					}
+				}
+			}
+		}
+	}
+}
+
+// isConfigFileName reports whether a file name is that of a tsconfig or jsconfig file, e.g. `tsconfig.base.json`.
+func isConfigFileName(fileName string) bool {
+	baseName := tspath.GetBaseFileName(fileName)
+	return (strings.HasPrefix(baseName, "tsconfig") || strings.HasPrefix(baseName, "jsconfig")) && tspath.FileExtensionIs(baseName, tspath.ExtensionJson)
+}
+
+// getStringLiteralTextRange returns the range of the text of a string literal, without its quotes.
+func getStringLiteralTextRange(node *ast.Node, file *ast.SourceFile) core.TextRange {
+	start := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/) + 1
+	return core.NewTextRange(start, max(start, node.End()-1))
+}
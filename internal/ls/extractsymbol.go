@@ -0,0 +1,1032 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/nodebuilder"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+)
+
+const (
+	refactorKindExtractFunction lsproto.CodeActionKind = "refactor.extract.function"
+	refactorKindExtractConstant lsproto.CodeActionKind = "refactor.extract.constant"
+)
+
+var extractSymbolProvider = &refactorProvider{
+	kinds:              []lsproto.CodeActionKind{refactorKindExtractFunction, refactorKindExtractConstant},
+	getRefactorActions: getRefactorActionsToExtractSymbol,
+}
+
+type rangeFacts int
+
+const (
+	rangeFactsHasReturn rangeFacts = 1 << iota
+	rangeFactsIsGenerator
+	rangeFactsIsAsyncFunction
+	rangeFactsUsesThis
+)
+
+// extractRange is the part of a file selected for extraction: either a single expression or a run of
+// sibling statements.
+type extractRange struct {
+	expression *ast.Node
+	statements []*ast.Node
+	facts      rangeFacts
+}
+
+func (r *extractRange) first() *ast.Node {
+	if r.expression != nil {
+		return r.expression
+	}
+	return r.statements[0]
+}
+
+func (r *extractRange) last() *ast.Node {
+	if r.expression != nil {
+		return r.expression
+	}
+	return r.statements[len(r.statements)-1]
+}
+
+func (r *extractRange) nodes() []*ast.Node {
+	if r.expression != nil {
+		return []*ast.Node{r.expression}
+	}
+	return r.statements
+}
+
+func (r *extractRange) contains(node *ast.Node) bool {
+	return r.first().Pos() <= node.Pos() && node.End() <= r.last().End()
+}
+
+// constantExpression returns the expression to extract to a constant, if the range is a single expression.
+func (r *extractRange) constantExpression() *ast.Node {
+	if r.expression != nil {
+		return r.expression
+	}
+	if len(r.statements) == 1 && ast.IsExpressionStatement(r.statements[0]) {
+		return r.statements[0].Expression()
+	}
+	return nil
+}
+
+// extractUsage is a symbol declared outside the extracted range and referenced from inside it.
+type extractUsage struct {
+	symbol  *ast.Symbol
+	node    *ast.Node
+	written bool
+}
+
+func getRefactorActionsToExtractSymbol(ctx context.Context, refactorContext *refactorContext) []*refactorAction {
+	file := refactorContext.sourceFile
+	r := getRangeToExtract(file, refactorContext.span)
+	if r == nil {
+		return nil
+	}
+	c, done := refactorContext.program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	e := &symbolExtractor{
+		refactorContext: refactorContext,
+		checker:         c,
+		file:            file,
+		r:               r,
+	}
+	e.collectUsages()
+	if !e.collectExposedDeclarations() {
+		return nil
+	}
+
+	var actions []*refactorAction
+	scopes := collectExtractionScopes(r)
+	for i, scope := range scopes {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if action := e.extractFunctionInScope(ctx, scope); action != nil {
+			actions = append(actions, action)
+		}
+		if action := e.extractConstantInScope(ctx, scope, i == 0); action != nil {
+			actions = append(actions, action)
+		}
+	}
+	// Offer all function extractions before all constant extractions, innermost scope first.
+	slices.SortStableFunc(actions, func(a, b *refactorAction) int {
+		return core.IfElse(a.kind == b.kind, 0, core.IfElse(a.kind == refactorKindExtractFunction, -1, 1))
+	})
+	return actions
+}
+
+// getRangeToExtract finds the expression or statements exactly covered by span, ignoring surrounding
+// whitespace. Returns nil if the span does not cover something that can be extracted.
+func getRangeToExtract(file *ast.SourceFile, span core.TextRange) *extractRange {
+	text := file.Text()
+	start := scanner.SkipTrivia(text, span.Pos())
+	end := span.End()
+	for end > start && stringutil.IsWhiteSpaceLike(rune(text[end-1])) {
+		end--
+	}
+	if start >= end {
+		return nil
+	}
+	span = core.NewTextRange(start, end)
+
+	startNode := getParentNodeInSpan(astnav.GetTokenAtPosition(file, start), file, span)
+	endNode := getParentNodeInSpan(astnav.FindPrecedingToken(file, end), file, span)
+	if startNode == nil || endNode == nil {
+		return nil
+	}
+	// Selecting statements without the semicolon of the last one still selects the whole statement.
+	if startNode != endNode && startNode.Parent != endNode.Parent && ast.IsExpressionStatement(endNode.Parent) && endNode.Parent.Parent == startNode.Parent {
+		endNode = endNode.Parent
+	}
+	if startNode.Parent != endNode.Parent {
+		return nil
+	}
+
+	var r *extractRange
+	if startNode != endNode {
+		if !isBlockLike(startNode.Parent) {
+			return nil
+		}
+		statements := startNode.Parent.Statements()
+		startIndex := slices.Index(statements, startNode)
+		endIndex := slices.Index(statements, endNode)
+		if startIndex < 0 || endIndex < startIndex {
+			return nil
+		}
+		r = &extractRange{statements: statements[startIndex : endIndex+1]}
+	} else {
+		node := refineExtractNode(startNode)
+		if ast.IsIdentifier(node) || ast.IsExpressionStatement(node) && ast.IsIdentifier(node.Expression()) {
+			return nil
+		}
+		switch {
+		case ast.IsStatement(node):
+			r = &extractRange{statements: []*ast.Node{node}}
+		case ast.IsExpressionNode(node) && isExtractableExpression(node):
+			if ast.IsExpressionStatement(node.Parent) {
+				r = &extractRange{statements: []*ast.Node{node.Parent}}
+			} else {
+				r = &extractRange{expression: node}
+			}
+		default:
+			return nil
+		}
+	}
+
+	if !checkExtractRange(r) {
+		return nil
+	}
+	return r
+}
+
+// getParentNodeInSpan returns the outermost ancestor of node that is still contained in span.
+func getParentNodeInSpan(node *ast.Node, file *ast.SourceFile, span core.TextRange) *ast.Node {
+	if node == nil {
+		return nil
+	}
+	for node.Parent != nil {
+		if ast.IsSourceFile(node.Parent) || !spanContainsNode(span, node.Parent, file) {
+			return node
+		}
+		node = node.Parent
+	}
+	return nil
+}
+
+func spanContainsNode(span core.TextRange, node *ast.Node, file *ast.SourceFile) bool {
+	start := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/)
+	return span.Pos() <= start && start < span.End() && node.End() <= span.End()
+}
+
+func isBlockLike(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindBlock, ast.KindModuleBlock, ast.KindSourceFile, ast.KindCaseClause, ast.KindDefaultClause:
+		return true
+	}
+	return false
+}
+
+// refineExtractNode narrows a selected statement to the expression a user most likely means to extract:
+// the operand of a `return`, or the initializer of a variable with a single initializer.
+func refineExtractNode(node *ast.Node) *ast.Node {
+	switch {
+	case ast.IsReturnStatement(node):
+		if node.Expression() != nil {
+			return node.Expression()
+		}
+	case ast.IsVariableStatement(node) || ast.IsVariableDeclarationList(node):
+		declarationList := node
+		if ast.IsVariableStatement(node) {
+			declarationList = node.AsVariableStatement().DeclarationList
+		}
+		var lastInitializer *ast.Node
+		numInitializers := 0
+		for _, declaration := range declarationList.AsVariableDeclarationList().Declarations.Nodes {
+			if initializer := declaration.Initializer(); initializer != nil {
+				numInitializers++
+				lastInitializer = initializer
+			}
+		}
+		// No special handling if there are multiple initializers.
+		if numInitializers == 1 {
+			return lastInitializer
+		}
+	case ast.IsVariableDeclaration(node):
+		if initializer := node.Initializer(); initializer != nil {
+			return initializer
+		}
+	}
+	return node
+}
+
+func isExtractableExpression(node *ast.Node) bool {
+	if ast.IsEnumMember(node.Parent) || ast.IsPartOfTypeNode(node) {
+		return false
+	}
+	switch node.Kind {
+	case ast.KindStringLiteral:
+		return !ast.IsImportDeclaration(node.Parent) && !ast.IsImportSpecifier(node.Parent) && !ast.IsExternalModuleReference(node.Parent)
+	case ast.KindSpreadElement, ast.KindObjectBindingPattern, ast.KindArrayBindingPattern, ast.KindBindingElement:
+		return false
+	case ast.KindIdentifier:
+		return !ast.IsBindingElement(node.Parent) && !ast.IsImportSpecifier(node.Parent) && !ast.IsExportSpecifier(node.Parent)
+	}
+	return true
+}
+
+type permittedJumps int
+
+const (
+	permittedJumpsBreak permittedJumps = 1 << iota
+	permittedJumpsContinue
+)
+
+// checkExtractRange records the facts of the range that affect the extracted code, and reports whether the
+// range can be extracted at all. Jumps out of the range and `super` calls cannot be extracted.
+func checkExtractRange(r *extractRange) bool {
+	for _, statement := range r.statements {
+		if ast.IsDeclaration(statement) && ast.HasSyntacticModifier(statement, ast.ModifierFlagsExport) || statement.Flags&ast.NodeFlagsAmbient != 0 {
+			return false
+		}
+	}
+
+	var labels []string
+	var visit func(node *ast.Node, permitted permittedJumps, inArrowFunction bool) bool
+	visit = func(node *ast.Node, permitted permittedJumps, inArrowFunction bool) bool {
+		switch node.Kind {
+		case ast.KindThisKeyword:
+			r.facts |= rangeFactsUsesThis
+		case ast.KindSuperKeyword:
+			if ast.IsCallExpression(node.Parent) && node.Parent.Expression() == node {
+				return true
+			}
+			r.facts |= rangeFactsUsesThis
+		}
+		if ast.IsFunctionLike(node) && !ast.IsArrowFunction(node) || ast.IsClassLike(node) {
+			// `this`, jumps, `await` and `yield` inside nested functions and classes don't affect the range.
+			return false
+		}
+		if ast.IsArrowFunction(node) {
+			inArrowFunction = true
+		}
+		if !inArrowFunction {
+			switch node.Kind {
+			case ast.KindReturnStatement:
+				r.facts |= rangeFactsHasReturn
+			case ast.KindAwaitExpression:
+				r.facts |= rangeFactsIsAsyncFunction
+			case ast.KindForOfStatement:
+				if node.AsForInOrOfStatement().AwaitModifier != nil {
+					r.facts |= rangeFactsIsAsyncFunction
+				}
+			case ast.KindYieldExpression:
+				r.facts |= rangeFactsIsGenerator
+			case ast.KindBreakStatement, ast.KindContinueStatement:
+				if label := node.Label(); label != nil {
+					if !slices.Contains(labels, label.Text()) {
+						return true
+					}
+				} else if node.Kind == ast.KindBreakStatement && permitted&permittedJumpsBreak == 0 ||
+					node.Kind == ast.KindContinueStatement && permitted&permittedJumpsContinue == 0 {
+					return true
+				}
+			case ast.KindLabeledStatement:
+				labels = append(labels, node.Label().Text())
+				defer func() { labels = labels[:len(labels)-1] }()
+			case ast.KindSwitchStatement:
+				permitted |= permittedJumpsBreak
+			}
+			if ast.IsIterationStatement(node, false /*lookInLabeledStatements*/) {
+				permitted |= permittedJumpsBreak | permittedJumpsContinue
+			}
+		}
+		return node.ForEachChild(func(child *ast.Node) bool {
+			return visit(child, permitted, inArrowFunction)
+		})
+	}
+	for _, node := range r.nodes() {
+		if visit(node, 0, false) {
+			return false
+		}
+	}
+	return true
+}
+
+func isExtractionScope(node *ast.Node) bool {
+	if ast.IsArrowFunction(node) {
+		return ast.IsBlock(node.Body())
+	}
+	return ast.IsFunctionLikeDeclaration(node) && node.Body() != nil || ast.IsSourceFile(node) || ast.IsModuleBlock(node) || ast.IsClassLike(node)
+}
+
+// collectExtractionScopes returns the nodes the range can be extracted into, innermost first.
+func collectExtractionScopes(r *extractRange) []*ast.Node {
+	current := r.first()
+	if r.facts&rangeFactsUsesThis != 0 {
+		// A range using `this` can only be extracted where `this` keeps its meaning.
+		if containingClass := ast.GetContainingClass(current); containingClass != nil {
+			if containingFunction := ast.FindAncestor(current, ast.IsFunctionLikeDeclaration); containingFunction != nil && ast.IsNodeDescendantOf(containingFunction, containingClass) {
+				return []*ast.Node{containingFunction, containingClass}
+			}
+			return []*ast.Node{containingClass}
+		}
+	}
+	var scopes []*ast.Node
+	for current = current.Parent; current != nil; current = current.Parent {
+		// A function parameter's initializer is actually in the outer scope, not the function declaration.
+		if ast.IsParameter(current) {
+			current = ast.FindAncestor(current, ast.IsFunctionLikeDeclaration)
+			continue
+		}
+		if isExtractionScope(current) {
+			scopes = append(scopes, current)
+		}
+	}
+	return scopes
+}
+
+type symbolExtractor struct {
+	*refactorContext
+	checker *checker.Checker
+	file    *ast.SourceFile
+	r       *extractRange
+
+	usages []*extractUsage
+	// Type parameters referenced by name from types in the range
+	typeParameterNames collections.Set[string]
+	// Variables declared by the range and referenced after it
+	exposed []*ast.Node
+	// Whether the range declares a variable that is referenced after it with `let` or `var`
+	exposedMutable bool
+}
+
+func (e *symbolExtractor) collectUsages() {
+	usagesBySymbol := make(map[*ast.Symbol]*extractUsage)
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		switch {
+		case ast.IsTypeReferenceNode(node):
+			if typeName := node.AsTypeReferenceNode().TypeName; ast.IsIdentifier(typeName) {
+				e.typeParameterNames.Add(typeName.Text())
+			}
+		case ast.IsIdentifier(node) && !ast.IsPartOfTypeNode(node):
+			var symbol *ast.Symbol
+			if ast.IsShorthandPropertyAssignment(node.Parent) && node.Parent.Name() == node {
+				symbol = e.checker.GetShorthandAssignmentValueSymbol(node.Parent)
+			} else {
+				symbol = e.checker.GetSymbolAtLocation(node)
+			}
+			if symbol == nil || !isLocalValueDeclaration(symbol.ValueDeclaration) ||
+				ast.GetSourceFileOfNode(symbol.ValueDeclaration) != e.file || e.r.contains(symbol.ValueDeclaration) {
+				break
+			}
+			usage := usagesBySymbol[symbol]
+			if usage == nil {
+				usage = &extractUsage{symbol: symbol, node: node}
+				usagesBySymbol[symbol] = usage
+				e.usages = append(e.usages, usage)
+			}
+			if ast.IsWriteAccess(node) {
+				usage.written = true
+			}
+		}
+		return node.ForEachChild(visit)
+	}
+	for _, node := range e.r.nodes() {
+		visit(node)
+	}
+}
+
+// collectExposedDeclarations finds the variables declared at the top level of the range that are referenced
+// after it, which the extracted function has to return. Returns false if the range declares anything else
+// that is referenced after it.
+func (e *symbolExtractor) collectExposedDeclarations() bool {
+	if e.r.expression != nil {
+		return true
+	}
+	declaredVariables := make(map[*ast.Symbol]*ast.Node)
+	var declaredOthers []*ast.Symbol
+	for _, statement := range e.r.statements {
+		if ast.IsVariableStatement(statement) {
+			declarationList := statement.AsVariableStatement().DeclarationList
+			for _, declaration := range declarationList.AsVariableDeclarationList().Declarations.Nodes {
+				forEachBindingIdentifier(declaration.Name(), func(name *ast.Node) {
+					if symbol := e.checker.GetSymbolAtLocation(name); symbol != nil {
+						declaredVariables[symbol] = name
+					}
+				})
+			}
+		} else if ast.IsDeclaration(statement) && statement.Symbol() != nil {
+			declaredOthers = append(declaredOthers, statement.Symbol())
+		}
+	}
+	if len(declaredVariables) == 0 && len(declaredOthers) == 0 {
+		return true
+	}
+
+	var exposed collections.Set[*ast.Node]
+	ok := true
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if ast.IsIdentifier(node) {
+			symbol := e.checker.GetSymbolAtLocation(node)
+			if ast.IsShorthandPropertyAssignment(node.Parent) && node.Parent.Name() == node {
+				symbol = e.checker.GetShorthandAssignmentValueSymbol(node.Parent)
+			}
+			if name, found := declaredVariables[symbol]; found {
+				exposed.Add(name)
+			} else if symbol != nil && slices.Contains(declaredOthers, symbol) {
+				ok = false
+				return true
+			}
+		}
+		return node.ForEachChild(visit)
+	}
+	statements := e.r.statements[0].Parent.Statements()
+	for _, statement := range statements[slices.Index(statements, e.r.last())+1:] {
+		if visit(statement) {
+			break
+		}
+	}
+	if !ok {
+		return false
+	}
+	for _, statement := range e.r.statements {
+		if !ast.IsVariableStatement(statement) {
+			continue
+		}
+		declarationList := statement.AsVariableStatement().DeclarationList
+		for _, declaration := range declarationList.AsVariableDeclarationList().Declarations.Nodes {
+			forEachBindingIdentifier(declaration.Name(), func(name *ast.Node) {
+				if exposed.Has(name) {
+					e.exposed = append(e.exposed, name)
+					if declarationList.Flags&ast.NodeFlagsConst == 0 {
+						e.exposedMutable = true
+					}
+				}
+			})
+		}
+	}
+	return true
+}
+
+func forEachBindingIdentifier(name *ast.Node, cb func(name *ast.Node)) {
+	if ast.IsIdentifier(name) {
+		cb(name)
+		return
+	}
+	if ast.IsBindingPattern(name) {
+		for _, element := range name.AsBindingPattern().Elements.Nodes {
+			if element.Name() != nil {
+				forEachBindingIdentifier(element.Name(), cb)
+			}
+		}
+	}
+}
+
+func isLocalValueDeclaration(declaration *ast.Node) bool {
+	if declaration == nil {
+		return false
+	}
+	switch declaration.Kind {
+	case ast.KindVariableDeclaration, ast.KindParameter, ast.KindBindingElement, ast.KindFunctionDeclaration,
+		ast.KindClassDeclaration, ast.KindEnumDeclaration:
+		return true
+	}
+	return false
+}
+
+// isDeclarationVisibleFrom reports whether a local declaration is in scope at location.
+func isDeclarationVisibleFrom(declaration *ast.Node, location *ast.Node) bool {
+	container := getDeclarationScopeContainer(declaration)
+	return container == location || ast.IsNodeDescendantOf(location, container)
+}
+
+func getDeclarationScopeContainer(declaration *ast.Node) *ast.Node {
+	declaration = ast.GetRootDeclaration(declaration)
+	if ast.IsParameter(declaration) {
+		return declaration.Parent
+	}
+	if ast.IsVariableDeclaration(declaration) && !ast.IsBlockOrCatchScoped(declaration) {
+		return ast.FindAncestor(declaration.Parent, func(node *ast.Node) bool {
+			return ast.IsFunctionLikeOrClassStaticBlockDeclaration(node) || ast.IsSourceFile(node) || ast.IsModuleBlock(node)
+		})
+	}
+	return ast.GetEnclosingBlockScopeContainer(declaration)
+}
+
+// canReturnCallResult reports whether a range containing `return` statements can be replaced with
+// `return newFunction()`: either no code after the range is reachable from its end, or the range ends
+// the body of its function.
+func (e *symbolExtractor) canReturnCallResult() bool {
+	last := e.r.last()
+	if ast.IsReturnStatement(last) || ast.IsThrowStatement(last) {
+		return true
+	}
+	container := last.Parent
+	statements := container.Statements()
+	index := slices.Index(statements, last)
+	if index == len(statements)-1 {
+		return ast.IsFunctionBlock(container)
+	}
+	if e.program.Options().AllowUnreachableCode.IsTrue() {
+		// The binder doesn't record flow nodes for statements when unreachable code is allowed.
+		return false
+	}
+	flowNode := statements[index+1].FlowNodeData().FlowNode
+	return flowNode == nil || !e.checker.IsReachableFlowNode(flowNode)
+}
+
+// getTypeParametersForScope returns the type parameters of declarations between the range and scope that are
+// referenced from the range or from the types of the extracted function's parameters.
+func (e *symbolExtractor) getTypeParametersForScope(scope *ast.Node, parameterTypes []*ast.Node) []*ast.Node {
+	var referencedNames collections.Set[string]
+	for name := range e.typeParameterNames.Keys() {
+		referencedNames.Add(name)
+	}
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if ast.IsTypeReferenceNode(node) {
+			if typeName := node.AsTypeReferenceNode().TypeName; ast.IsIdentifier(typeName) {
+				referencedNames.Add(typeName.Text())
+			}
+		}
+		return node.ForEachChild(visit)
+	}
+	for _, typeNode := range parameterTypes {
+		if typeNode != nil {
+			visit(typeNode)
+		}
+	}
+
+	var typeParameters []*ast.Node
+	for node := e.r.first().Parent; node != nil && node != scope; node = node.Parent {
+		if !ast.IsFunctionLike(node) && !ast.IsClassLike(node) {
+			continue
+		}
+		for _, typeParameter := range node.TypeParameters() {
+			if referencedNames.Has(typeParameter.Name().Text()) {
+				typeParameters = append(typeParameters, typeParameter)
+			}
+		}
+	}
+	slices.SortFunc(typeParameters, func(a, b *ast.Node) int { return a.Pos() - b.Pos() })
+	return typeParameters
+}
+
+func (e *symbolExtractor) extractFunctionInScope(ctx context.Context, scope *ast.Node) *refactorAction {
+	r := e.r
+	isClassScope := ast.IsClassLike(scope)
+	if r.facts&rangeFactsUsesThis != 0 && !isClassScope {
+		return nil
+	}
+
+	var parameters, written []*extractUsage
+	for _, usage := range e.usages {
+		if isDeclarationVisibleFrom(usage.symbol.ValueDeclaration, scope) {
+			continue
+		}
+		parameters = append(parameters, usage)
+		if usage.written {
+			written = append(written, usage)
+		}
+	}
+	if len(written) > 0 && (len(e.exposed) > 0 || r.expression != nil) {
+		// The written variables are returned to the caller, which isn't possible if the range declares variables
+		// the caller needs too, or if the range is an expression whose value is returned instead.
+		return nil
+	}
+	if r.facts&rangeFactsHasReturn != 0 && (len(written) > 0 || len(e.exposed) > 0 || !e.canReturnCallResult()) {
+		return nil
+	}
+
+	isStatic := false
+	if isClassScope {
+		member := ast.FindAncestor(r.first(), func(node *ast.Node) bool { return node.Parent == scope })
+		isStatic = member != nil && ast.IsStatic(member)
+		if isStatic && scope.Name() == nil {
+			return nil
+		}
+	}
+
+	file := e.file
+	isJS := ast.IsInJSFile(file.AsNode())
+	functionName := getUniqueName(core.IfElse(isClassScope, "newMethod", "newFunction"), file)
+	description := getExtractFunctionDescription(scope)
+	return e.ls.createRefactorAction(ctx, refactorKindExtractFunction, description, func(ct *changeTracker) {
+		factory := ct.NodeFactory
+
+		var parameterDeclarations, parameterTypes, arguments []*ast.Node
+		for _, usage := range parameters {
+			var typeNode *ast.Node
+			if !isJS {
+				t := e.checker.GetBaseTypeOfLiteralType(e.checker.GetTypeOfSymbolAtLocation(usage.symbol, usage.node))
+				typeNode = e.checker.TypeToTypeNode(t, scope, nodebuilder.FlagsNoTruncation)
+			}
+			parameterTypes = append(parameterTypes, typeNode)
+			parameterDeclarations = append(parameterDeclarations, factory.NewParameterDeclaration(nil, nil, factory.NewIdentifier(usage.symbol.Name), nil, typeNode, nil))
+			arguments = append(arguments, factory.NewIdentifier(usage.symbol.Name))
+		}
+
+		var typeParameterList, typeArgumentList *ast.NodeList
+		if !isJS {
+			if typeParameters := e.getTypeParametersForScope(scope, parameterTypes); len(typeParameters) > 0 {
+				typeParameterList = factory.NewNodeList(core.Map(typeParameters, factory.DeepCloneNode))
+				typeArgumentList = factory.NewNodeList(core.Map(typeParameters, func(typeParameter *ast.Node) *ast.Node {
+					return factory.NewTypeReferenceNode(factory.NewIdentifier(typeParameter.Name().Text()), nil)
+				}))
+			}
+		}
+
+		// Names the extracted function returns to its caller
+		returnedNames := core.Map(e.exposed, (*ast.Node).Text)
+		for _, usage := range written {
+			returnedNames = append(returnedNames, usage.symbol.Name)
+		}
+
+		var bodyStatements []*ast.Node
+		if r.expression != nil {
+			bodyStatements = append(bodyStatements, factory.NewReturnStatement(factory.DeepCloneNode(r.expression)))
+		} else {
+			bodyStatements = core.Map(r.statements, factory.DeepCloneNode)
+			if len(returnedNames) > 0 {
+				bodyStatements = append(bodyStatements, factory.NewReturnStatement(createReturnedValue(factory, returnedNames)))
+			}
+		}
+
+		var modifiers []*ast.Node
+		if isClassScope && !isJS {
+			modifiers = append(modifiers, factory.NewModifier(ast.KindPrivateKeyword))
+		}
+		if isStatic {
+			modifiers = append(modifiers, factory.NewModifier(ast.KindStaticKeyword))
+		}
+		if r.facts&rangeFactsIsAsyncFunction != 0 {
+			modifiers = append(modifiers, factory.NewModifier(ast.KindAsyncKeyword))
+		}
+		var modifierList *ast.ModifierList
+		if len(modifiers) > 0 {
+			modifierList = factory.NewModifierList(modifiers)
+		}
+		var asteriskToken *ast.Node
+		if r.facts&rangeFactsIsGenerator != 0 {
+			asteriskToken = factory.NewToken(ast.KindAsteriskToken)
+		}
+		body := factory.NewBlock(factory.NewNodeList(bodyStatements), true /*multiline*/)
+		var newFunction *ast.Node
+		if isClassScope {
+			newFunction = factory.NewMethodDeclaration(modifierList, asteriskToken, factory.NewIdentifier(functionName), nil, typeParameterList, factory.NewNodeList(parameterDeclarations), nil, nil, body)
+		} else {
+			newFunction = factory.NewFunctionDeclaration(modifierList, asteriskToken, factory.NewIdentifier(functionName), typeParameterList, factory.NewNodeList(parameterDeclarations), nil, nil, body)
+		}
+
+		var callee *ast.Node = factory.NewIdentifier(functionName)
+		if isClassScope {
+			receiver := factory.NewKeywordExpression(ast.KindThisKeyword)
+			if isStatic {
+				receiver = factory.NewIdentifier(scope.Name().Text())
+			}
+			callee = factory.NewPropertyAccessExpression(receiver, nil, callee, ast.NodeFlagsNone)
+		}
+		call := factory.NewCallExpression(callee, nil, typeArgumentList, factory.NewNodeList(arguments), ast.NodeFlagsNone)
+		if r.facts&rangeFactsIsGenerator != 0 {
+			call = factory.NewYieldExpression(factory.NewToken(ast.KindAsteriskToken), call)
+		}
+		if r.facts&rangeFactsIsAsyncFunction != 0 {
+			call = factory.NewAwaitExpression(call)
+		}
+
+		if r.expression != nil {
+			if call.Kind != ast.KindCallExpression && needsParenthesesForAwaitOrYield(r.expression) {
+				call = factory.NewParenthesizedExpression(call)
+			}
+			ct.replaceNode(file, r.expression, call, nil)
+		} else {
+			var statement *ast.Node
+			switch {
+			case len(e.exposed) > 0:
+				var name *ast.Node
+				if len(e.exposed) == 1 {
+					name = factory.NewIdentifier(returnedNames[0])
+				} else {
+					name = factory.NewBindingPattern(ast.KindObjectBindingPattern, factory.NewNodeList(core.Map(returnedNames, func(name string) *ast.Node {
+						return factory.NewBindingElement(nil, nil, factory.NewIdentifier(name), nil)
+					})))
+				}
+				declaration := factory.NewVariableDeclaration(name, nil, nil, call)
+				flags := core.IfElse(e.exposedMutable, ast.NodeFlagsLet, ast.NodeFlagsConst)
+				statement = factory.NewVariableStatement(nil, factory.NewVariableDeclarationList(flags, factory.NewNodeList([]*ast.Node{declaration})))
+			case len(written) > 0:
+				assignment := factory.NewBinaryExpression(nil, createReturnedValue(factory, returnedNames), nil, factory.NewToken(ast.KindEqualsToken), call)
+				if len(written) > 1 {
+					assignment = factory.NewParenthesizedExpression(assignment)
+				}
+				statement = factory.NewExpressionStatement(assignment)
+			case r.facts&rangeFactsHasReturn != 0:
+				statement = factory.NewReturnStatement(call)
+			default:
+				statement = factory.NewExpressionStatement(call)
+			}
+			start := scanner.GetTokenPosOfNode(r.first(), file, false /*includeJSDoc*/)
+			ct.replaceRange(file, *e.ls.createLspRangeFromBounds(start, r.last().End(), file), statement, changeNodeOptions{})
+		}
+
+		e.insertExtractedFunction(ct, scope, newFunction)
+	})
+}
+
+// createReturnedValue creates `name` for a single name, and `{ name1, name2 }` otherwise.
+func createReturnedValue(factory *ast.NodeFactory, names []string) *ast.Node {
+	if len(names) == 1 {
+		return factory.NewIdentifier(names[0])
+	}
+	return factory.NewObjectLiteralExpression(factory.NewNodeList(core.Map(names, func(name string) *ast.Node {
+		return factory.NewShorthandPropertyAssignment(nil, factory.NewIdentifier(name), nil, nil, nil, nil)
+	})), false /*multiLine*/)
+}
+
+func needsParenthesesForAwaitOrYield(expression *ast.Node) bool {
+	parent := expression.Parent
+	switch parent.Kind {
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression, ast.KindCallExpression, ast.KindNewExpression:
+		return parent.Expression() == expression
+	case ast.KindTaggedTemplateExpression:
+		return parent.AsTaggedTemplateExpression().Tag == expression
+	case ast.KindBinaryExpression:
+		// `yield` has a lower precedence than any binary operator.
+		return true
+	}
+	return false
+}
+
+// insertExtractedFunction inserts the new function before the first function declared after the range in
+// scope, or at the end of scope if there is none.
+func (e *symbolExtractor) insertExtractedFunction(ct *changeTracker, scope *ast.Node, newFunction *ast.Node) {
+	minPos := e.r.first().Pos()
+	for _, child := range getStatementsOrClassElements(scope) {
+		if child.Pos() >= minPos && ast.IsFunctionLikeDeclaration(child) && !ast.IsConstructorDeclaration(child) {
+			ct.insertNodeBefore(e.file, child, newFunction, true /*blankLineBetween*/)
+			return
+		}
+	}
+	ct.insertNodeAtEndOfScope(e.file, scope, newFunction)
+}
+
+func getStatementsOrClassElements(scope *ast.Node) []*ast.Node {
+	switch {
+	case ast.IsSourceFile(scope), ast.IsModuleBlock(scope):
+		return scope.Statements()
+	case ast.IsClassLike(scope):
+		return scope.Members()
+	case ast.IsFunctionLikeDeclaration(scope):
+		return scope.Body().Statements()
+	}
+	return nil
+}
+
+func (e *symbolExtractor) extractConstantInScope(ctx context.Context, scope *ast.Node, innermost bool) *refactorAction {
+	r := e.r
+	expression := r.constantExpression()
+	if expression == nil {
+		return nil
+	}
+	if r.facts&(rangeFactsIsAsyncFunction|rangeFactsIsGenerator) != 0 && (!innermost || ast.IsClassLike(scope)) {
+		// `await` and `yield` are only valid in the function they already appear in.
+		return nil
+	}
+
+	for _, usage := range e.usages {
+		if usage.written {
+			// The constant is evaluated before the code it is extracted from, and only once.
+			return nil
+		}
+	}
+	var thisContainer *ast.Node
+	if r.facts&rangeFactsUsesThis != 0 {
+		thisContainer = ast.GetThisContainer(expression, false /*includeArrowFunctions*/, false /*includeClassComputedPropertyName*/)
+	}
+
+	file := e.file
+	isJS := ast.IsInJSFile(file.AsNode())
+	description := getExtractConstantDescription(scope, innermost)
+	if ast.IsClassLike(scope) {
+		for _, usage := range e.usages {
+			if !isDeclarationVisibleFrom(usage.symbol.ValueDeclaration, scope) {
+				return nil
+			}
+		}
+		if thisContainer != nil && thisContainer.Parent != scope {
+			// `this` only refers to the instance or the class in the members of the class.
+			return nil
+		}
+		member := ast.FindAncestor(expression, func(node *ast.Node) bool { return node.Parent == scope })
+		isStatic := member != nil && ast.IsStatic(member)
+		if isStatic && scope.Name() == nil {
+			return nil
+		}
+		propertyName := getUniqueName("newProperty", file)
+		return e.ls.createRefactorAction(ctx, refactorKindExtractConstant, description, func(ct *changeTracker) {
+			factory := ct.NodeFactory
+			var modifiers []*ast.Node
+			if !isJS {
+				modifiers = append(modifiers, factory.NewModifier(ast.KindPrivateKeyword))
+			}
+			if isStatic {
+				modifiers = append(modifiers, factory.NewModifier(ast.KindStaticKeyword))
+			}
+			if !isJS {
+				modifiers = append(modifiers, factory.NewModifier(ast.KindReadonlyKeyword))
+			}
+			var modifierList *ast.ModifierList
+			if len(modifiers) > 0 {
+				modifierList = factory.NewModifierList(modifiers)
+			}
+			property := factory.NewPropertyDeclaration(modifierList, factory.NewIdentifier(propertyName), nil, nil, factory.DeepCloneNode(expression))
+			receiver := factory.NewKeywordExpression(ast.KindThisKeyword)
+			if isStatic {
+				receiver = factory.NewIdentifier(scope.Name().Text())
+			}
+			reference := factory.NewPropertyAccessExpression(receiver, nil, factory.NewIdentifier(propertyName), ast.NodeFlagsNone)
+
+			ct.insertNodeBefore(file, getNodeToInsertPropertyBefore(expression.Pos(), scope), property, true /*blankLineBetween*/)
+			ct.replaceNode(file, expression, reference, nil)
+		})
+	}
+
+	insertBefore := getNodeToInsertConstantBefore(expression, scope)
+	for _, usage := range e.usages {
+		if !isDeclarationVisibleFrom(usage.symbol.ValueDeclaration, insertBefore.Parent) {
+			return nil
+		}
+	}
+	if thisContainer != nil && ast.GetThisContainer(insertBefore, false /*includeArrowFunctions*/, false /*includeClassComputedPropertyName*/) != thisContainer {
+		// `this` would refer to something else where the constant is declared.
+		return nil
+	}
+	localName := getUniqueName("newLocal", file)
+	return e.ls.createRefactorAction(ctx, refactorKindExtractConstant, description, func(ct *changeTracker) {
+		factory := ct.NodeFactory
+		declaration := factory.NewVariableDeclaration(factory.NewIdentifier(localName), nil, nil, factory.DeepCloneNode(expression))
+		statement := factory.NewVariableStatement(nil, factory.NewVariableDeclarationList(ast.NodeFlagsConst, factory.NewNodeList([]*ast.Node{declaration})))
+		if ast.IsExpressionStatement(expression.Parent) && insertBefore == expression.Parent {
+			// If the parent is an expression statement in the target scope, replace the statement with the declaration.
+			ct.replaceNode(file, expression.Parent, statement, nil)
+			return
+		}
+		ct.insertNodeBefore(file, insertBefore, statement, false /*blankLineBetween*/)
+		ct.replaceNode(file, expression, factory.NewIdentifier(localName), nil)
+	})
+}
+
+// getNodeToInsertConstantBefore returns the statement of the block nearest to node, within scope, that a
+// constant extracted from node should be declared before.
+func getNodeToInsertConstantBefore(node *ast.Node, scope *ast.Node) *ast.Node {
+	var prevScope *ast.Node
+	for current := node; current != scope; current = current.Parent {
+		if isExtractionScope(current) {
+			prevScope = current
+		}
+	}
+	for current := core.OrElse(prevScope, node).Parent; ; current = current.Parent {
+		if isBlockLike(current) {
+			var prevStatement *ast.Node
+			for _, statement := range current.Statements() {
+				if statement.Pos() > node.Pos() {
+					break
+				}
+				prevStatement = statement
+			}
+			if prevStatement == nil && ast.IsCaseClause(current) {
+				return current.Parent.Parent
+			}
+			// There must be at least one statement since we started in one.
+			return prevStatement
+		}
+	}
+}
+
+// getNodeToInsertPropertyBefore returns the member of a class that a property extracted from a position
+// should be declared before: after the leading properties of the class, but before the member containing
+// the position.
+func getNodeToInsertPropertyBefore(maxPos int, scope *ast.Node) *ast.Node {
+	members := scope.Members()
+	var prevMember *ast.Node
+	allProperties := true
+	for _, member := range members {
+		if member.Pos() > maxPos {
+			return core.OrElse(prevMember, members[0])
+		}
+		if allProperties && !ast.IsPropertyDeclaration(member) {
+			// If it is non-vacuously true that all preceding members are properties,
+			// insert before the current member (i.e. at the end of the list of properties).
+			if prevMember != nil {
+				return member
+			}
+			allProperties = false
+		}
+		prevMember = member
+	}
+	return prevMember
+}
+
+func getExtractFunctionDescription(scope *ast.Node) string {
+	functionKind := "function"
+	if ast.IsFunctionLikeDeclaration(scope) {
+		functionKind = "inner function"
+	} else if ast.IsClassLike(scope) {
+		functionKind = "method"
+	}
+	return formatExtractDescription(functionKind, scope)
+}
+
+func getExtractConstantDescription(scope *ast.Node, innermost bool) string {
+	if ast.IsClassLike(scope) {
+		return formatExtractDescription("readonly field", scope)
+	}
+	if innermost {
+		return diagnostics.Extract_to_0_in_enclosing_scope.Format("constant")
+	}
+	return formatExtractDescription("constant", scope)
+}
+
+func formatExtractDescription(extractedKind string, scope *ast.Node) string {
+	if ast.IsSourceFile(scope) {
+		return diagnostics.Extract_to_0_in_1_scope.Format(extractedKind, core.IfElse(ast.IsExternalModule(scope.AsSourceFile()), "module", "global"))
+	}
+	return diagnostics.Extract_to_0_in_1.Format(extractedKind, getExtractionScopeDescription(scope))
+}
+
+func getExtractionScopeDescription(scope *ast.Node) string {
+	switch scope.Kind {
+	case ast.KindConstructor:
+		return "constructor"
+	case ast.KindFunctionExpression, ast.KindFunctionDeclaration:
+		if scope.Name() != nil {
+			return fmt.Sprintf("function '%s'", scope.Name().Text())
+		}
+		return "anonymous function"
+	case ast.KindArrowFunction:
+		return "arrow function"
+	case ast.KindMethodDeclaration:
+		return fmt.Sprintf("method '%s'", scanner.GetTextOfNode(scope.Name()))
+	case ast.KindGetAccessor:
+		return fmt.Sprintf("'get %s'", scanner.GetTextOfNode(scope.Name()))
+	case ast.KindSetAccessor:
+		return fmt.Sprintf("'set %s'", scanner.GetTextOfNode(scope.Name()))
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		if scope.Name() != nil {
+			return fmt.Sprintf("class '%s'", scope.Name().Text())
+		}
+		return core.IfElse(scope.Kind == ast.KindClassDeclaration, "anonymous class declaration", "anonymous class expression")
+	case ast.KindModuleBlock:
+		return fmt.Sprintf("namespace '%s'", scanner.GetTextOfNode(scope.Parent.Name()))
+	}
+	return ""
+}
+
+// getUniqueName returns baseName, or baseName with the smallest numeric suffix that makes it a name not
+// already used in the file.
+func getUniqueName(baseName string, file *ast.SourceFile) string {
+	nameText := baseName
+	for i := 1; isIdentifierInFile(file, nameText); i++ {
+		nameText = fmt.Sprintf("%s_%d", baseName, i)
+	}
+	return nameText
+}
+
+func isIdentifierInFile(file *ast.SourceFile, name string) bool {
+	_, ok := file.Identifiers[name]
+	return ok
+}
@@ -0,0 +1,147 @@
+package ls
+
+import (
+	"context"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+)
+
+const refactorKindExtractType lsproto.CodeActionKind = "refactor.extract.type"
+
+var extractTypeProvider = &refactorProvider{
+	kinds:              []lsproto.CodeActionKind{refactorKindExtractType},
+	getRefactorActions: getRefactorActionsToExtractType,
+}
+
+func getRefactorActionsToExtractType(ctx context.Context, refactorContext *refactorContext) []*refactorAction {
+	file := refactorContext.sourceFile
+	if ast.IsInJSFile(file.AsNode()) {
+		return nil
+	}
+	selection := getTypeNodeToExtract(file, refactorContext.span)
+	if selection == nil {
+		return nil
+	}
+	c, done := refactorContext.program.GetTypeCheckerForFile(ctx, file)
+	typeParameters, ok := collectTypeParametersOfExtractedType(c, selection)
+	done()
+	if !ok {
+		return nil
+	}
+	enclosingStatement := ast.FindAncestor(selection, ast.IsStatement)
+	if enclosingStatement == nil {
+		return nil
+	}
+
+	name := getUniqueName("NewType", file)
+	action := refactorContext.ls.createRefactorAction(ctx, refactorKindExtractType, diagnostics.Extract_to_type_alias.Message(), func(ct *changeTracker) {
+		factory := ct.NodeFactory
+		typeParameterDeclarations := core.Map(typeParameters, func(typeParameter *ast.Node) *ast.Node {
+			var constraint *ast.Node
+			if typeParameter.AsTypeParameter().Constraint != nil {
+				constraint = factory.DeepCloneNode(typeParameter.AsTypeParameter().Constraint)
+			}
+			return factory.NewTypeParameterDeclaration(nil, factory.NewIdentifier(typeParameter.Name().Text()), constraint, nil)
+		})
+		typeArguments := core.Map(typeParameters, func(typeParameter *ast.Node) *ast.Node {
+			return factory.NewTypeReferenceNode(factory.NewIdentifier(typeParameter.Name().Text()), nil)
+		})
+		var typeParameterList, typeArgumentList *ast.NodeList
+		if len(typeParameters) > 0 {
+			typeParameterList = factory.NewNodeList(typeParameterDeclarations)
+			typeArgumentList = factory.NewNodeList(typeArguments)
+		}
+		typeAlias := factory.NewTypeAliasDeclaration(nil, factory.NewIdentifier(name), typeParameterList, factory.DeepCloneNode(selection))
+		ct.insertNodeBefore(file, enclosingStatement, typeAlias, true /*blankLineBetween*/)
+		ct.replaceNode(file, selection, factory.NewTypeReferenceNode(factory.NewIdentifier(name), typeArgumentList), nil)
+	})
+	if action == nil {
+		return nil
+	}
+	return []*refactorAction{action}
+}
+
+// getTypeNodeToExtract returns the outermost type node exactly covered by span, ignoring surrounding whitespace.
+func getTypeNodeToExtract(file *ast.SourceFile, span core.TextRange) *ast.Node {
+	text := file.Text()
+	start := scanner.SkipTrivia(text, span.Pos())
+	end := span.End()
+	for end > start && stringutil.IsWhiteSpaceLike(rune(text[end-1])) {
+		end--
+	}
+	if start >= end {
+		return nil
+	}
+	var selection *ast.Node
+	for node := astnav.GetTokenAtPosition(file, start); node != nil && !ast.IsSourceFile(node); node = node.Parent {
+		nodeStart := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/)
+		if nodeStart < start || node.End() > end {
+			break
+		}
+		if ast.IsTypeNode(node) {
+			selection = node
+		}
+	}
+	if selection == nil || scanner.GetTokenPosOfNode(selection, file, false /*includeJSDoc*/) != start || selection.End() != end {
+		return nil
+	}
+	// A type predicate or `this` type only means something in the signature it is written in.
+	if ast.IsTypePredicateNode(selection) || selection.Kind == ast.KindThisType {
+		return nil
+	}
+	return selection
+}
+
+// collectTypeParametersOfExtractedType finds the type parameters declared outside of a type node and
+// referenced from it. Returns false if the type refers to something that would not be in scope at the
+// declaration of the new type alias.
+func collectTypeParametersOfExtractedType(c *checker.Checker, selection *ast.Node) ([]*ast.Node, bool) {
+	var typeParameters []*ast.Node
+	ok := true
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		switch {
+		case ast.IsTypeReferenceNode(node):
+			typeName := node.AsTypeReferenceNode().TypeName
+			if ast.IsIdentifier(typeName) {
+				symbol := c.GetSymbolAtLocation(typeName)
+				if symbol != nil && len(symbol.Declarations) > 0 {
+					declaration := symbol.Declarations[0]
+					if ast.IsTypeParameterDeclaration(declaration) && !isNodeWithin(declaration, selection) && !slices.Contains(typeParameters, declaration) {
+						typeParameters = append(typeParameters, declaration)
+					}
+				}
+			}
+		case ast.IsTypeQueryNode(node):
+			exprName := node.AsTypeQueryNode().ExprName
+			symbol := c.GetSymbolAtLocation(ast.GetFirstIdentifier(exprName))
+			if symbol != nil && symbol.ValueDeclaration != nil && !isNodeWithin(symbol.ValueDeclaration, selection) && !isTopLevelDeclaration(symbol.ValueDeclaration) {
+				ok = false
+				return true
+			}
+		case ast.IsThisTypeNode(node):
+			ok = false
+			return true
+		}
+		return node.ForEachChild(visit)
+	}
+	visit(selection)
+	slices.SortFunc(typeParameters, func(a, b *ast.Node) int { return a.Pos() - b.Pos() })
+	return typeParameters, ok
+}
+
+func isNodeWithin(node *ast.Node, ancestor *ast.Node) bool {
+	return ancestor.Pos() <= node.Pos() && node.End() <= ancestor.End()
+}
+
+func isTopLevelDeclaration(declaration *ast.Node) bool {
+	return ast.GetEnclosingBlockScopeContainer(ast.GetRootDeclaration(declaration)) == ast.GetSourceFileOfNode(declaration).AsNode()
+}
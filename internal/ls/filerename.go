@@ -0,0 +1,158 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/tsoptions"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// GetEditsForFileRename returns the edits that keep module specifiers, `/// <reference path>` directives and
+// the file lists of tsconfig.json pointing at the same files when files or directories are renamed. The
+// program must not reflect the renames yet.
+func (l *LanguageService) GetEditsForFileRename(ctx context.Context, renames []*lsproto.FileRename, preferences *UserPreferences) *lsproto.WorkspaceEdit {
+	program := l.GetProgram()
+	oldToNew := getPathUpdater(renames, tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: program.UseCaseSensitiveFileNames(),
+		CurrentDirectory:          program.GetCurrentDirectory(),
+	})
+	ct := l.newChangeTracker(ctx)
+	l.updateTsconfigFiles(ct, program, oldToNew)
+	c, done := program.GetTypeChecker(ctx)
+	defer done()
+	l.updateImportsForFileRename(ct, program, c, oldToNew, preferences)
+	return toLSPWorkspaceEdit(ct.getChanges())
+}
+
+// pathUpdater returns the new name of a file or directory, or "" if it is not renamed.
+type pathUpdater func(fileName string) string
+
+// getPathUpdater creates a pathUpdater for a set of renames. Renaming a directory renames everything in it.
+func getPathUpdater(renames []*lsproto.FileRename, options tspath.ComparePathsOptions) pathUpdater {
+	return func(fileName string) string {
+		for _, rename := range renames {
+			oldPath := lsproto.DocumentUri(rename.OldUri).FileName()
+			newPath := lsproto.DocumentUri(rename.NewUri).FileName()
+			if tspath.ComparePaths(fileName, oldPath, options) == 0 {
+				return newPath
+			}
+			if tspath.ContainsPath(oldPath, fileName, options) {
+				return tspath.CombinePaths(newPath, tspath.GetRelativePathFromDirectory(oldPath, fileName, options))
+			}
+		}
+		return ""
+	}
+}
+
+// updateImportsForFileRename updates the module specifiers and reference directives of every file in the
+// program that refer to a renamed file, or that are relative and in a renamed file.
+func (l *LanguageService) updateImportsForFileRename(ct *changeTracker, program *compiler.Program, c *checker.Checker, oldToNew pathUpdater, preferences *UserPreferences) {
+	comparePathsOptions := tspath.ComparePathsOptions{UseCaseSensitiveFileNames: program.UseCaseSensitiveFileNames()}
+	for _, file := range program.GetSourceFiles() {
+		newImportingFileName := oldToNew(file.FileName())
+		importingFileMoved := newImportingFileName != ""
+		if !importingFileMoved {
+			newImportingFileName = file.FileName()
+		}
+		oldDirectory := tspath.GetDirectoryPath(file.FileName())
+		newDirectory := tspath.GetDirectoryPath(newImportingFileName)
+
+		for _, ref := range file.ReferencedFiles {
+			if !tspath.PathIsRelative(ref.FileName) {
+				continue
+			}
+			oldReferencedFileName := tspath.CombinePaths(oldDirectory, ref.FileName)
+			newReferencedFileName := oldToNew(oldReferencedFileName)
+			if newReferencedFileName == "" {
+				if !importingFileMoved {
+					continue
+				}
+				newReferencedFileName = oldReferencedFileName
+			}
+			updated := tspath.EnsurePathIsNonModuleName(tspath.GetRelativePathFromDirectory(newDirectory, newReferencedFileName, comparePathsOptions))
+			if updated != file.Text()[ref.Pos():ref.End()] {
+				ct.replaceRangeWithText(file, *l.createLspRangeFromBounds(ref.Pos(), ref.End(), file), updated)
+			}
+		}
+
+		for _, literal := range file.Imports() {
+			oldImportedFileName := getFileNameOfImportedModule(program, c, file, literal)
+			if oldImportedFileName == "" {
+				continue
+			}
+			newImportedFileName := oldToNew(oldImportedFileName)
+			if newImportedFileName == "" {
+				// The module specifier only needs to change if it is relative to a file that moved.
+				if !importingFileMoved || !tspath.PathIsRelative(literal.Text()) {
+					continue
+				}
+				newImportedFileName = oldImportedFileName
+			}
+			updated := modulespecifiers.UpdateModuleSpecifier(program.Options(), program, file, newImportingFileName, newImportedFileName, literal.Text(), preferences.ModuleSpecifierPreferences())
+			if updated != "" {
+				ct.replaceRangeWithText(file, l.getStringLiteralContentRange(file, literal), updated)
+			}
+		}
+	}
+}
+
+// getFileNameOfImportedModule returns the name of the file a module specifier resolves to, or "" if it
+// does not resolve to a file or refers to an ambient module.
+func getFileNameOfImportedModule(program *compiler.Program, c *checker.Checker, file *ast.SourceFile, literal *ast.Node) string {
+	if moduleSymbol := c.GetSymbolAtLocation(literal); moduleSymbol != nil {
+		if core.Some(moduleSymbol.Declarations, ast.IsAmbientModule) {
+			return ""
+		}
+		if sourceFile := core.Find(moduleSymbol.Declarations, ast.IsSourceFile); sourceFile != nil {
+			return sourceFile.AsSourceFile().FileName()
+		}
+	}
+	// Modules without a symbol, e.g. JSON or JS files that are not checked, are found through module resolution.
+	if resolved := program.GetResolvedModuleFromModuleSpecifier(file, literal); resolved != nil && resolved.IsResolved() {
+		return resolved.ResolvedFileName
+	}
+	return ""
+}
+
+// updateTsconfigFiles updates the entries of `files`, `include` and `exclude` in the tsconfig.json of the
+// program that name a renamed file or directory.
+func (l *LanguageService) updateTsconfigFiles(ct *changeTracker, program *compiler.Program, oldToNew pathUpdater) {
+	configFile := program.CommandLine().ConfigFile
+	if configFile == nil || configFile.SourceFile == nil {
+		return
+	}
+	file := configFile.SourceFile
+	configDirectory := tspath.GetDirectoryPath(file.FileName())
+	comparePathsOptions := tspath.ComparePathsOptions{UseCaseSensitiveFileNames: program.UseCaseSensitiveFileNames()}
+	// !!! add an include for a file moved out of the directories matched by wildcard includes
+	// !!! update file paths in compilerOptions
+	for _, propertyName := range []string{"files", "include", "exclude"} {
+		tsoptions.ForEachTsConfigPropArray(file, propertyName, func(property *ast.PropertyAssignment) *struct{} {
+			elements := []*ast.Node{property.Initializer}
+			if ast.IsArrayLiteralExpression(property.Initializer) {
+				elements = property.Initializer.AsArrayLiteralExpression().Elements.Nodes
+			}
+			for _, element := range elements {
+				if !ast.IsStringLiteral(element) {
+					continue
+				}
+				if updated := oldToNew(tspath.GetNormalizedAbsolutePath(element.Text(), configDirectory)); updated != "" {
+					ct.replaceRangeWithText(file, l.getStringLiteralContentRange(file, element), tspath.GetRelativePathFromDirectory(configDirectory, updated, comparePathsOptions))
+				}
+			}
+			return nil
+		})
+	}
+}
+
+// getStringLiteralContentRange returns the range of a string literal without its quotes.
+func (l *LanguageService) getStringLiteralContentRange(file *ast.SourceFile, literal *ast.Node) lsproto.Range {
+	return *l.createLspRangeFromBounds(scanner.GetTokenPosOfNode(literal, file, false /*includeJSDoc*/)+1, literal.End()-1, file)
+}
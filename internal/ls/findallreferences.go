@@ -928,6 +928,27 @@ func getPossibleSymbolReferenceNodes(sourceFile *ast.SourceFile, symbolName stri
 	})
 }
 
+// isSymbolReferencedInFile reports whether the symbol declared by the identifier is referenced anywhere else in
+// the file.
+func isSymbolReferencedInFile(definition *ast.Node, c *checker.Checker, sourceFile *ast.SourceFile) bool {
+	symbol := c.GetSymbolAtLocation(definition)
+	if symbol == nil {
+		return false
+	}
+	for _, token := range getPossibleSymbolReferenceNodes(sourceFile, symbol.Name, sourceFile.AsNode()) {
+		if !ast.IsIdentifier(token) || token == definition || token.Text() != definition.Text() {
+			continue
+		}
+		referenceSymbol := c.GetSymbolAtLocation(token)
+		if referenceSymbol == symbol ||
+			c.GetShorthandAssignmentValueSymbol(token.Parent) == symbol ||
+			ast.IsExportSpecifier(token.Parent) && getLocalSymbolForExportSpecifier(token.AsIdentifier(), referenceSymbol, token.Parent.AsExportSpecifier(), c) == symbol {
+			return true
+		}
+	}
+	return false
+}
+
 func getPossibleSymbolReferencePositions(sourceFile *ast.SourceFile, symbolName string, container *ast.Node) []int {
 	positions := []int{}
 
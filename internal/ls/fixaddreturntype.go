@@ -0,0 +1,133 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/nodebuilder"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	fixNameAddReturnType = "fixMissingTypeAnnotationOnExports"
+	fixIdAddReturnType   = "fixMissingTypeAnnotationOnExports"
+)
+
+const refactorKindInferReturnType lsproto.CodeActionKind = "refactor.rewrite.function.returnType"
+
+var addReturnTypeProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Function_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations.Code(),
+		diagnostics.Method_must_have_an_explicit_return_type_annotation_with_isolatedDeclarations.Code(),
+	},
+	fixIds:         []string{fixIdAddReturnType},
+	getCodeActions: getCodeActionsToAddReturnType,
+}
+
+var inferReturnTypeProvider = &refactorProvider{
+	kinds:              []lsproto.CodeActionKind{refactorKindInferReturnType},
+	getRefactorActions: getRefactorActionsToInferReturnType,
+}
+
+func getCodeActionsToAddReturnType(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	declaration := getFunctionWithoutReturnType(fixContext.sourceFile, fixContext.span.Pos())
+	if declaration == nil {
+		return nil
+	}
+	c, done := fixContext.program.GetTypeCheckerForFile(ctx, fixContext.sourceFile)
+	defer done()
+	signature := c.GetSignatureFromDeclaration(declaration)
+	if signature == nil {
+		return nil
+	}
+	description := diagnostics.Add_return_type_0.Format(c.TypeToString(c.GetReturnTypeOfSignature(signature)))
+	action := fixContext.ls.createCodeFixAction(ctx, fixNameAddReturnType, description, fixIdAddReturnType, func(ct *changeTracker) {
+		adder := fixContext.ls.newImportAdder(ctx, c, fixContext.sourceFile, fixContext.preferences)
+		if returnTypeNode := inferReturnTypeNode(ct, c, adder, declaration); returnTypeNode != nil {
+			ct.addReturnType(fixContext.sourceFile, declaration, returnTypeNode)
+			adder.writeFixes(ct)
+		}
+	})
+	if action == nil {
+		return nil
+	}
+	return []*codeFixAction{action}
+}
+
+func getRefactorActionsToInferReturnType(ctx context.Context, refactorContext *refactorContext) []*refactorAction {
+	declaration := getFunctionWithoutReturnType(refactorContext.sourceFile, refactorContext.span.Pos())
+	if declaration == nil {
+		return nil
+	}
+	c, done := refactorContext.program.GetTypeCheckerForFile(ctx, refactorContext.sourceFile)
+	defer done()
+	action := refactorContext.ls.createRefactorAction(ctx, refactorKindInferReturnType, diagnostics.Infer_function_return_type.Message(), func(ct *changeTracker) {
+		adder := refactorContext.ls.newImportAdder(ctx, c, refactorContext.sourceFile, refactorContext.preferences)
+		if returnTypeNode := inferReturnTypeNode(ct, c, adder, declaration); returnTypeNode != nil {
+			ct.addReturnType(refactorContext.sourceFile, declaration, returnTypeNode)
+			adder.writeFixes(ct)
+		}
+	})
+	if action == nil {
+		return nil
+	}
+	return []*refactorAction{action}
+}
+
+// getFunctionWithoutReturnType returns the function, function expression, arrow function or method whose
+// signature contains a position, if it has a body but no return type annotation.
+func getFunctionWithoutReturnType(sourceFile *ast.SourceFile, position int) *ast.Node {
+	if ast.IsInJSFile(sourceFile.AsNode()) {
+		return nil
+	}
+	token := astnav.GetTouchingPropertyName(sourceFile, position)
+	declaration := ast.FindAncestorOrQuit(token, func(node *ast.Node) ast.FindAncestorResult {
+		if ast.IsBlock(node) || node.Parent != nil && ast.IsArrowFunction(node.Parent) && (node.Kind == ast.KindEqualsGreaterThanToken || node.Parent.Body() == node) {
+			return ast.FindAncestorQuit
+		}
+		switch node.Kind {
+		case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindArrowFunction, ast.KindMethodDeclaration:
+			return ast.FindAncestorTrue
+		}
+		return ast.FindAncestorFalse
+	})
+	if declaration == nil || declaration.Body() == nil || declaration.Type() != nil {
+		return nil
+	}
+	return declaration
+}
+
+// inferReturnTypeNode returns a type node for the return type the checker infers for a function, or nil if the
+// type refers to a symbol that is not accessible in the function. The symbols of other modules that the type
+// refers to are imported with importAdder.
+func inferReturnTypeNode(ct *changeTracker, c *checker.Checker, importAdder *importAdder, declaration *ast.Node) *ast.Node {
+	signature := c.GetSignatureFromDeclaration(declaration)
+	if signature == nil {
+		return nil
+	}
+	nodeBuilder := checker.NewNodeBuilder(c, ct.EmitContext)
+	if typePredicate := c.GetTypePredicateOfSignature(signature); typePredicate != nil && typePredicate.Type() != nil {
+		tracker := newAccessibilityTracker(c, importAdder.ls.GetProgram())
+		typePredicateNode := nodeBuilder.TypePredicateToTypePredicateNode(typePredicate, declaration, nodebuilder.FlagsNoTruncation, nodebuilder.InternalFlagsNone, tracker)
+		if !tracker.accessible {
+			return nil
+		}
+		return makeAutoImportable(typePredicateNode, nodeBuilder, importAdder, ct.NodeFactory)
+	}
+	return typeToAutoImportableTypeNode(c, nodeBuilder, importAdder, ct.NodeFactory, c.GetReturnTypeOfSignature(signature), declaration)
+}
+
+// addReturnType inserts a return type annotation after the parameter list of a function, adding parentheses
+// around the parameter of an arrow function like `x => x`.
+func (ct *changeTracker) addReturnType(sourceFile *ast.SourceFile, declaration *ast.Node, returnTypeNode *ast.Node) {
+	if ast.IsArrowFunction(declaration) && findChildOfKind(declaration, ast.KindCloseParenToken, sourceFile) == nil {
+		parameter := declaration.Parameters()[0]
+		ct.insertText(sourceFile, ct.ls.createLspPosition(scanner.GetTokenPosOfNode(parameter, sourceFile, false /*includeJSDoc*/), sourceFile), "(")
+		ct.insertText(sourceFile, ct.ls.createLspPosition(parameter.End(), sourceFile), ")")
+	}
+	ct.tryInsertTypeAnnotation(sourceFile, declaration, returnTypeNode)
+}
@@ -0,0 +1,98 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	fixNameAwaitInSyncFunction = "fixAwaitInSyncFunction"
+	fixIdAwaitInSyncFunction   = "fixAwaitInSyncFunction"
+)
+
+var fixAwaitInSyncFunctionProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.X_await_expressions_are_only_allowed_within_async_functions_and_at_the_top_levels_of_modules.Code(),
+		diagnostics.X_await_using_statements_are_only_allowed_within_async_functions_and_at_the_top_levels_of_modules.Code(),
+		diagnostics.X_for_await_loops_are_only_allowed_within_async_functions_and_at_the_top_levels_of_modules.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_to_write_this_in_an_async_function.Code(),
+	},
+	fixIds:         []string{fixIdAwaitInSyncFunction},
+	getCodeActions: getCodeActionsToFixAwaitInSyncFunction,
+	fixAll: func(ct *changeTracker, fixContext *codeFixContext, seen *collections.Set[*ast.Node]) {
+		if containingFunction := getAwaitContainingFunction(fixContext.sourceFile, fixContext.span.Pos()); containingFunction != nil && seen.AddIfAbsent(containingFunction) {
+			ct.addAsyncModifier(fixContext.sourceFile, containingFunction)
+		}
+	},
+}
+
+func getCodeActionsToFixAwaitInSyncFunction(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	containingFunction := getAwaitContainingFunction(fixContext.sourceFile, fixContext.span.Pos())
+	if containingFunction == nil {
+		return nil
+	}
+	action := fixContext.ls.createCodeFixAction(ctx, fixNameAwaitInSyncFunction, diagnostics.Add_async_modifier_to_containing_function.Message(), fixIdAwaitInSyncFunction, func(ct *changeTracker) {
+		ct.addAsyncModifier(fixContext.sourceFile, containingFunction)
+	})
+	if action == nil {
+		return nil
+	}
+	return []*codeFixAction{action}
+}
+
+func getAwaitContainingFunction(sourceFile *ast.SourceFile, position int) *ast.Node {
+	token := astnav.GetTokenAtPosition(sourceFile, position)
+	containingFunction := ast.GetContainingFunction(token)
+	if containingFunction == nil || ast.HasSyntacticModifier(containingFunction, ast.ModifierFlagsAsync) {
+		return nil
+	}
+	switch containingFunction.Kind {
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindArrowFunction, ast.KindMethodDeclaration:
+		return containingFunction
+	}
+	return nil
+}
+
+// addAsyncModifier inserts an `async` modifier into a function and wraps its declared return type, if any, in `Promise<...>`.
+func (ct *changeTracker) addAsyncModifier(sourceFile *ast.SourceFile, fn *ast.Node) {
+	text := sourceFile.Text()
+	insertPos := fn.Pos()
+	if modifiers := fn.Modifiers(); modifiers != nil {
+		insertPos = modifiers.End()
+	}
+	insertPos = scanner.SkipTrivia(text, insertPos)
+	ct.insertText(sourceFile, ct.ls.createLspPosition(insertPos, sourceFile), "async ")
+
+	returnType := getDeclaredReturnType(fn)
+	if returnType == nil || isPromiseTypeReference(returnType) {
+		return
+	}
+	typeStart := scanner.GetTokenPosOfNode(returnType, sourceFile, false /*includeJSDoc*/)
+	ct.insertText(sourceFile, ct.ls.createLspPosition(typeStart, sourceFile), "Promise<")
+	ct.insertText(sourceFile, ct.ls.createLspPosition(returnType.End(), sourceFile), ">")
+}
+
+func getDeclaredReturnType(fn *ast.Node) *ast.TypeNode {
+	if returnType := fn.Type(); returnType != nil {
+		return returnType
+	}
+	if ast.IsVariableDeclaration(fn.Parent) {
+		if declaredType := fn.Parent.Type(); declaredType != nil && ast.IsFunctionTypeNode(declaredType) {
+			return declaredType.Type()
+		}
+	}
+	return nil
+}
+
+func isPromiseTypeReference(typeNode *ast.TypeNode) bool {
+	if !ast.IsTypeReferenceNode(typeNode) {
+		return false
+	}
+	typeName := typeNode.AsTypeReferenceNode().TypeName
+	return ast.IsIdentifier(typeName) && typeName.Text() == "Promise"
+}
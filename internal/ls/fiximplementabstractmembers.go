@@ -0,0 +1,88 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+)
+
+const (
+	fixNameImplementAbstractMembers = "fixClassDoesntImplementInheritedAbstractMember"
+	fixIdImplementAbstractMembers   = "fixClassDoesntImplementInheritedAbstractMember"
+)
+
+var implementAbstractMembersProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Non_abstract_class_0_does_not_implement_inherited_abstract_member_1_from_class_2.Code(),
+		diagnostics.Non_abstract_class_0_is_missing_implementations_for_the_following_members_of_1_Colon_2.Code(),
+		diagnostics.Non_abstract_class_0_is_missing_implementations_for_the_following_members_of_1_Colon_2_and_3_more.Code(),
+		diagnostics.Non_abstract_class_expression_does_not_implement_inherited_abstract_member_0_from_class_1.Code(),
+		diagnostics.Non_abstract_class_expression_is_missing_implementations_for_the_following_members_of_0_Colon_1.Code(),
+		diagnostics.Non_abstract_class_expression_is_missing_implementations_for_the_following_members_of_0_Colon_1_and_2_more.Code(),
+	},
+	fixIds:         []string{fixIdImplementAbstractMembers},
+	getCodeActions: getCodeActionsToImplementAbstractMembers,
+	fixAll: func(ct *changeTracker, fixContext *codeFixContext, seen *collections.Set[*ast.Node]) {
+		classDeclaration := getClassWithAbstractMembers(fixContext.sourceFile, fixContext.span.Pos())
+		if classDeclaration == nil || !seen.AddIfAbsent(classDeclaration) {
+			return
+		}
+		c, done := fixContext.program.GetTypeCheckerForFile(ct.ctx, fixContext.sourceFile)
+		defer done()
+		addMissingAbstractMembers(ct, c, fixContext.importAdder, classDeclaration, fixContext.sourceFile, fixContext.preferences)
+	},
+}
+
+func getCodeActionsToImplementAbstractMembers(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	classDeclaration := getClassWithAbstractMembers(fixContext.sourceFile, fixContext.span.Pos())
+	if classDeclaration == nil {
+		return nil
+	}
+	c, done := fixContext.program.GetTypeCheckerForFile(ctx, fixContext.sourceFile)
+	defer done()
+	action := fixContext.ls.createCodeFixAction(ctx, fixNameImplementAbstractMembers, diagnostics.Implement_inherited_abstract_class.Message(), fixIdImplementAbstractMembers, func(ct *changeTracker) {
+		adder := fixContext.ls.newImportAdder(ctx, c, fixContext.sourceFile, fixContext.preferences)
+		addMissingAbstractMembers(ct, c, adder, classDeclaration, fixContext.sourceFile, fixContext.preferences)
+		adder.writeFixes(ct)
+	})
+	if action == nil {
+		return nil
+	}
+	return []*codeFixAction{action}
+}
+
+// getClassWithAbstractMembers returns the class whose name, or `class` keyword for anonymous classes, is at a
+// position.
+func getClassWithAbstractMembers(sourceFile *ast.SourceFile, position int) *ast.Node {
+	token := astnav.GetTokenAtPosition(sourceFile, position)
+	if token.Parent == nil || !ast.IsClassLike(token.Parent) || ast.GetClassExtendsHeritageElement(token.Parent) == nil {
+		return nil
+	}
+	return token.Parent
+}
+
+// addMissingAbstractMembers adds the abstract members of the base class that a class does not implement. The
+// symbols the new members refer to are imported with adder.
+func addMissingAbstractMembers(ct *changeTracker, c *checker.Checker, adder *importAdder, classDeclaration *ast.Node, sourceFile *ast.SourceFile, preferences *UserPreferences) {
+	extendsNode := ast.GetClassExtendsHeritageElement(classDeclaration)
+	instantiatedExtendsType := c.GetTypeAtLocation(extendsNode)
+	abstractAndNonPrivateExtendsSymbols := core.Filter(c.GetPropertiesOfType(instantiatedExtendsType), symbolPointsToNonPrivateAndAbstractMember)
+	newMemberStubBuilder(ct, c, sourceFile, adder, preferences).createMissingMemberNodes(classDeclaration, abstractAndNonPrivateExtendsSymbols, func(member *ast.Node) {
+		ct.insertMemberAtStart(sourceFile, classDeclaration, member)
+	})
+}
+
+func symbolPointsToNonPrivateAndAbstractMember(symbol *ast.Symbol) bool {
+	// See `codeFixClassExtendAbstractProtectedProperty.ts` in https://github.com/Microsoft/TypeScript/pull/11547/files
+	// (now named `codeFixClassExtendAbstractPrivateProperty.ts`)
+	if len(symbol.Declarations) == 0 {
+		return false
+	}
+	flags := symbol.Declarations[0].ModifierFlags()
+	return flags&ast.ModifierFlagsPrivate == 0 && flags&ast.ModifierFlagsAbstract != 0
+}
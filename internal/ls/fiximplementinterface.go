@@ -0,0 +1,118 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	fixNameImplementInterface = "fixClassIncorrectlyImplementsInterface"
+	fixIdImplementInterface   = "fixClassIncorrectlyImplementsInterface"
+)
+
+var implementInterfaceProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Class_0_incorrectly_implements_interface_1.Code(),
+		diagnostics.Class_0_incorrectly_implements_class_1_Did_you_mean_to_extend_1_and_inherit_its_members_as_a_subclass.Code(),
+	},
+	fixIds:         []string{fixIdImplementInterface},
+	getCodeActions: getCodeActionsToImplementInterface,
+	fixAll: func(ct *changeTracker, fixContext *codeFixContext, seen *collections.Set[*ast.Node]) {
+		classDeclaration := getImplementingClass(fixContext.sourceFile, fixContext.span.Pos())
+		if classDeclaration == nil || !seen.AddIfAbsent(classDeclaration) {
+			return
+		}
+		c, done := fixContext.program.GetTypeCheckerForFile(ct.ctx, fixContext.sourceFile)
+		defer done()
+		for _, implementedTypeNode := range ast.GetImplementsTypeNodes(classDeclaration) {
+			addMissingDeclarations(ct, c, fixContext.importAdder, implementedTypeNode, fixContext.sourceFile, classDeclaration, fixContext.preferences)
+		}
+	},
+}
+
+func getCodeActionsToImplementInterface(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	classDeclaration := getImplementingClass(fixContext.sourceFile, fixContext.span.Pos())
+	if classDeclaration == nil {
+		return nil
+	}
+	c, done := fixContext.program.GetTypeCheckerForFile(ctx, fixContext.sourceFile)
+	defer done()
+	var actions []*codeFixAction
+	for _, implementedTypeNode := range ast.GetImplementsTypeNodes(classDeclaration) {
+		description := diagnostics.Implement_interface_0.Format(scanner.GetSourceTextOfNodeFromSourceFile(fixContext.sourceFile, implementedTypeNode, false /*includeTrivia*/))
+		action := fixContext.ls.createCodeFixAction(ctx, fixNameImplementInterface, description, fixIdImplementInterface, func(ct *changeTracker) {
+			adder := fixContext.ls.newImportAdder(ctx, c, fixContext.sourceFile, fixContext.preferences)
+			addMissingDeclarations(ct, c, adder, implementedTypeNode, fixContext.sourceFile, classDeclaration, fixContext.preferences)
+			adder.writeFixes(ct)
+		})
+		if action != nil {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+func getImplementingClass(sourceFile *ast.SourceFile, position int) *ast.Node {
+	return ast.GetContainingClass(astnav.GetTokenAtPosition(sourceFile, position))
+}
+
+// addMissingDeclarations adds the members and index signatures of an implemented type that a class lacks. Members
+// the class inherits from its base class are not added again. The symbols the new members refer to are imported
+// with adder.
+func addMissingDeclarations(ct *changeTracker, c *checker.Checker, adder *importAdder, implementedTypeNode *ast.Node, sourceFile *ast.SourceFile, classDeclaration *ast.Node, preferences *UserPreferences) {
+	inheritedMembers := getHeritageClauseSymbolTable(classDeclaration, c)
+	implementedType := c.GetTypeAtLocation(implementedTypeNode)
+	missingMembers := core.Filter(c.GetPropertiesOfType(implementedType), func(symbol *ast.Symbol) bool {
+		_, inherited := inheritedMembers[symbol.Name]
+		return symbolPointsToNonPrivateMember(symbol) && !inherited
+	})
+
+	classType := c.GetTypeAtLocation(classDeclaration)
+	constructor := core.Find(classDeclaration.Members(), ast.IsConstructorDeclaration)
+	builder := newMemberStubBuilder(ct, c, sourceFile, adder, preferences)
+	insertInterfaceMemberNode := func(member *ast.Node) {
+		if constructor != nil {
+			ct.insertMemberAfter(sourceFile, classDeclaration, constructor, member)
+		} else {
+			ct.insertMemberAtStart(sourceFile, classDeclaration, member)
+		}
+	}
+	createMissingIndexSignatureDeclaration := func(keyType *checker.Type) {
+		if info := c.GetIndexInfoOfType(implementedType, keyType); info != nil {
+			insertInterfaceMemberNode(builder.indexSignatureToDeclaration(info, classDeclaration))
+		}
+	}
+	if c.GetNumberIndexType(classType) == nil {
+		createMissingIndexSignatureDeclaration(c.GetNumberType())
+	}
+	if c.GetStringIndexType(classType) == nil {
+		createMissingIndexSignatureDeclaration(c.GetStringType())
+	}
+	builder.createMissingMemberNodes(classDeclaration, missingMembers, insertInterfaceMemberNode)
+}
+
+func getHeritageClauseSymbolTable(classDeclaration *ast.Node, c *checker.Checker) ast.SymbolTable {
+	heritageClauseNode := ast.GetClassExtendsHeritageElement(classDeclaration)
+	if heritageClauseNode == nil {
+		return nil
+	}
+	heritageClauseType := c.GetTypeAtLocation(heritageClauseNode)
+	symbols := make(ast.SymbolTable)
+	for _, symbol := range c.GetPropertiesOfType(heritageClauseType) {
+		if symbolPointsToNonPrivateMember(symbol) {
+			symbols[symbol.Name] = symbol
+		}
+	}
+	return symbols
+}
+
+func symbolPointsToNonPrivateMember(symbol *ast.Symbol) bool {
+	return symbol.ValueDeclaration == nil || symbol.ValueDeclaration.ModifierFlags()&ast.ModifierFlagsPrivate == 0
+}
@@ -0,0 +1,296 @@
+package ls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/format"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	fixNameInferFromUsage = "inferFromUsage"
+	fixIdInferFromUsage   = "inferFromUsage"
+)
+
+var inferFromUsageProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Parameter_0_implicitly_has_an_1_type.Code(),
+		diagnostics.Rest_parameter_0_implicitly_has_an_any_type.Code(),
+		diagnostics.Parameter_0_implicitly_has_an_1_type_but_a_better_type_may_be_inferred_from_usage.Code(),
+		diagnostics.Rest_parameter_0_implicitly_has_an_any_type_but_a_better_type_may_be_inferred_from_usage.Code(),
+	},
+	fixIds:         []string{fixIdInferFromUsage},
+	getCodeActions: getCodeActionsToInferFromUsage,
+}
+
+func getCodeActionsToInferFromUsage(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	containingFunction := getFunctionOfUntypedParameter(fixContext.sourceFile, fixContext.span.Pos())
+	if containingFunction == nil {
+		return nil
+	}
+	action := fixContext.ls.createCodeFixAction(ctx, fixNameInferFromUsage, diagnostics.Infer_parameter_types_from_usage.Message(), fixIdInferFromUsage, func(ct *changeTracker) {
+		fixContext.ls.annotateParameters(ctx, ct, fixContext.program, fixContext.sourceFile, containingFunction, fixContext.preferences)
+	})
+	if action == nil {
+		return nil
+	}
+	return []*codeFixAction{action}
+}
+
+// getFunctionOfUntypedParameter returns the function that declares the parameter at a position.
+func getFunctionOfUntypedParameter(sourceFile *ast.SourceFile, position int) *ast.Node {
+	parameter := ast.FindAncestor(astnav.GetTokenAtPosition(sourceFile, position), ast.IsParameter)
+	if parameter == nil || parameter.Type() != nil || !ast.IsIdentifier(parameter.Name()) {
+		return nil
+	}
+	return parameter.Parent
+}
+
+// annotateParameters adds a type annotation to each parameter of a function that has neither a type nor an
+// initializer, if a type can be inferred for it. In JavaScript files, the types are added as `@param` tags of a
+// JSDoc comment. The symbols of other modules that the types refer to are imported.
+func (l *LanguageService) annotateParameters(ctx context.Context, ct *changeTracker, program *compiler.Program, sourceFile *ast.SourceFile, containingFunction *ast.Node, preferences *UserPreferences) {
+	calls := l.getFunctionCalls(ctx, program, containingFunction)
+	c, done := program.GetTypeCheckerForFile(ctx, sourceFile)
+	defer done()
+	importAdder := l.newImportAdder(ctx, c, sourceFile, preferences)
+	defer importAdder.writeFixes(ct)
+	nodeBuilder := checker.NewNodeBuilder(c, ct.EmitContext)
+	isJS := ast.IsInJSFile(sourceFile.AsNode())
+	parameters := containingFunction.Parameters()
+
+	var annotations []*ast.Node
+	for i, parameter := range parameters {
+		annotations = append(annotations, nil)
+		if parameter.Type() != nil || parameter.Initializer() != nil || !ast.IsIdentifier(parameter.Name()) {
+			continue
+		}
+		if t := inferTypeForParameterFromUsage(c, containingFunction, parameter, i, calls); t != nil {
+			if isJS {
+				// JSDoc types refer to the symbols of other modules with import types.
+				annotations[i] = typeNodeIfAccessible(c, nodeBuilder, program, t, containingFunction)
+			} else {
+				annotations[i] = typeToAutoImportableTypeNode(c, nodeBuilder, importAdder, ct.NodeFactory, t, containingFunction)
+			}
+		}
+	}
+	if core.Every(annotations, func(annotation *ast.Node) bool { return annotation == nil }) {
+		return
+	}
+	if isJS {
+		ct.addJSDocParameterTags(sourceFile, containingFunction, annotations)
+		return
+	}
+
+	needParens := ast.IsArrowFunction(containingFunction) && findChildOfKind(containingFunction, ast.KindOpenParenToken, sourceFile) == nil
+	if needParens {
+		ct.insertText(sourceFile, ct.ls.createLspPosition(scanner.GetTokenPosOfNode(parameters[0], sourceFile, false /*includeJSDoc*/), sourceFile), "(")
+	}
+	for i, annotation := range annotations {
+		if annotation != nil {
+			ct.tryInsertTypeAnnotation(sourceFile, parameters[i], annotation)
+		}
+	}
+	if needParens {
+		ct.insertText(sourceFile, ct.ls.createLspPosition(parameters[len(parameters)-1].End(), sourceFile), ")")
+	}
+}
+
+// addJSDocParameterTags adds a `@param` tag for each parameter of a function that has a type in types to the JSDoc
+// comment of the declaration the comment of the function belongs to. A new comment is inserted if the declaration
+// has none or a comment on a single line.
+func (ct *changeTracker) addJSDocParameterTags(sourceFile *ast.SourceFile, fn *ast.Node, types []*ast.Node) {
+	host := fn
+	if (ast.IsFunctionExpression(fn) || ast.IsArrowFunction(fn)) && ast.IsVariableDeclaration(fn.Parent) && fn.Parent.Initializer() == fn &&
+		ast.IsVariableDeclarationList(fn.Parent.Parent) && len(fn.Parent.Parent.AsVariableDeclarationList().Declarations.Nodes) == 1 {
+		host = fn.Parent.Parent.Parent
+	}
+	text := sourceFile.Text()
+	start := scanner.GetTokenPosOfNode(host, sourceFile, false /*includeJSDoc*/)
+	lineStart := format.GetLineStartPositionForPosition(start, sourceFile)
+	indentation := text[lineStart:start]
+	if strings.TrimSpace(indentation) != "" {
+		return
+	}
+
+	p := printer.NewPrinter(printer.PrinterOptions{RemoveComments: true}, printer.PrintHandlers{}, ct.EmitContext)
+	var tags strings.Builder
+	for i, parameter := range fn.Parameters() {
+		if types[i] == nil {
+			continue
+		}
+		typeNode, prefix := types[i], ""
+		if parameter.AsParameterDeclaration().DotDotDotToken != nil && typeNode.Kind == ast.KindArrayType {
+			// A rest parameter is documented with the type of its elements.
+			typeNode, prefix = typeNode.AsArrayTypeNode().ElementType, "..."
+		}
+		ct.EmitContext.SetEmitFlags(typeNode, printer.EFSingleLine)
+		tags.WriteString(indentation + " * @param {" + prefix + p.Emit(typeNode, sourceFile) + "} " + parameter.Name().Text() + ct.newLine)
+	}
+
+	if jsDoc := host.JSDoc(sourceFile); len(jsDoc) > 0 {
+		lastJSDoc := jsDoc[len(jsDoc)-1]
+		// The tags go on new lines before the closing `*/` of the last comment.
+		closeStart := lastJSDoc.End() - len("*/")
+		closeLineStart := format.GetLineStartPositionForPosition(closeStart, sourceFile)
+		if closeLineStart > lastJSDoc.Pos() && strings.TrimSpace(text[closeLineStart:closeStart]) == "" {
+			ct.insertText(sourceFile, ct.ls.createLspPosition(closeLineStart, sourceFile), tags.String())
+			return
+		}
+	}
+	ct.insertText(sourceFile, ct.ls.createLspPosition(start, sourceFile), "/**"+ct.newLine+tags.String()+indentation+" */"+ct.newLine+indentation)
+}
+
+// getFunctionCalls returns the calls of a function, found by searching for references to its name. Calls of
+// constructors are `new` expressions of their class.
+func (l *LanguageService) getFunctionCalls(ctx context.Context, program *compiler.Program, fn *ast.Node) []*ast.Node {
+	var searchNode *ast.Node
+	switch fn.Kind {
+	case ast.KindFunctionDeclaration, ast.KindMethodDeclaration:
+		searchNode = fn.Name()
+	case ast.KindConstructor:
+		searchNode = fn
+	case ast.KindFunctionExpression, ast.KindArrowFunction:
+		if ast.IsVariableDeclaration(fn.Parent) && fn.Parent.Initializer() == fn {
+			searchNode = fn.Parent.Name()
+		} else {
+			searchNode = fn.Name()
+		}
+	}
+	if searchNode == nil || !ast.IsIdentifier(searchNode) && !ast.IsConstructorDeclaration(searchNode) {
+		return nil
+	}
+
+	var calls []*ast.Node
+	symbolsAndEntries := l.getReferencedSymbolsForNode(ctx, searchNode.Pos(), searchNode, program, program.GetSourceFiles(), refOptions{use: referenceUseReferences}, nil)
+	for _, symbolAndEntries := range symbolsAndEntries {
+		for _, entry := range symbolAndEntries.references {
+			if entry.node == nil {
+				continue
+			}
+			callee := climbPastPropertyAccess(entry.node)
+			if parent := callee.Parent; parent != nil && (ast.IsCallExpression(parent) || ast.IsNewExpression(parent)) && parent.Expression() == callee {
+				calls = append(calls, parent)
+			}
+		}
+	}
+	return calls
+}
+
+// inferTypeForParameterFromUsage returns the union of the types of the arguments passed for a parameter and
+// of the types its uses in the function body imply, or nil if neither implies a type.
+func inferTypeForParameterFromUsage(c *checker.Checker, fn *ast.Node, parameter *ast.Node, index int, calls []*ast.Node) *checker.Type {
+	var types []*checker.Type
+	addType := func(t *checker.Type) {
+		if t != nil && t.Flags()&(checker.TypeFlagsAnyOrUnknown|checker.TypeFlagsNever) == 0 {
+			types = append(types, c.GetBaseTypeOfLiteralType(t))
+		}
+	}
+
+	isRest := parameter.AsParameterDeclaration().DotDotDotToken != nil
+	for _, call := range calls {
+		arguments := call.Arguments()
+		if isRest {
+			for _, argument := range arguments[min(index, len(arguments)):] {
+				if !ast.IsSpreadElement(argument) {
+					addType(c.GetTypeAtLocation(argument))
+				}
+			}
+		} else if index < len(arguments) && !core.Some(arguments[:index+1], ast.IsSpreadElement) {
+			addType(c.GetTypeAtLocation(arguments[index]))
+		}
+	}
+	if !isRest {
+		parameterSymbol := parameter.Symbol()
+		var visit func(node *ast.Node) bool
+		visit = func(node *ast.Node) bool {
+			if ast.IsIdentifier(node) && node != parameter.Name() && c.GetSymbolAtLocation(node) == parameterSymbol {
+				addType(inferTypeFromReference(c, node))
+			}
+			node.ForEachChild(visit)
+			return false
+		}
+		if body := fn.Body(); body != nil {
+			visit(body)
+		}
+	}
+
+	if len(types) == 0 {
+		return nil
+	}
+	t := c.GetWidenedType(c.GetUnionType(types))
+	if isRest {
+		t = c.CreateArrayType(t)
+	}
+	return t
+}
+
+// inferTypeFromReference returns the type a reference to a parameter is used as, e.g. `number` for `x * 2` or
+// the type of the corresponding parameter for `f(x)`.
+func inferTypeFromReference(c *checker.Checker, reference *ast.Node) *checker.Type {
+	node := reference
+	for ast.IsParenthesizedExpression(node.Parent) {
+		node = node.Parent
+	}
+	parent := node.Parent
+	switch parent.Kind {
+	case ast.KindPrefixUnaryExpression, ast.KindPostfixUnaryExpression:
+		var operator ast.Kind
+		if ast.IsPrefixUnaryExpression(parent) {
+			operator = parent.AsPrefixUnaryExpression().Operator
+		} else {
+			operator = parent.AsPostfixUnaryExpression().Operator
+		}
+		switch operator {
+		case ast.KindPlusPlusToken, ast.KindMinusMinusToken, ast.KindMinusToken, ast.KindTildeToken:
+			return c.GetNumberType()
+		}
+	case ast.KindBinaryExpression:
+		binary := parent.AsBinaryExpression()
+		other := binary.Left
+		if other == node {
+			other = binary.Right
+		}
+		switch binary.OperatorToken.Kind {
+		case ast.KindAsteriskToken, ast.KindAsteriskAsteriskToken, ast.KindSlashToken, ast.KindPercentToken, ast.KindMinusToken,
+			ast.KindLessThanLessThanToken, ast.KindGreaterThanGreaterThanToken, ast.KindGreaterThanGreaterThanGreaterThanToken,
+			ast.KindAmpersandToken, ast.KindBarToken, ast.KindCaretToken,
+			ast.KindAsteriskEqualsToken, ast.KindAsteriskAsteriskEqualsToken, ast.KindSlashEqualsToken, ast.KindPercentEqualsToken, ast.KindMinusEqualsToken,
+			ast.KindLessThanLessThanEqualsToken, ast.KindGreaterThanGreaterThanEqualsToken, ast.KindGreaterThanGreaterThanGreaterThanEqualsToken,
+			ast.KindAmpersandEqualsToken, ast.KindBarEqualsToken, ast.KindCaretEqualsToken:
+			return c.GetNumberType()
+		case ast.KindPlusToken, ast.KindPlusEqualsToken:
+			otherType := c.GetTypeAtLocation(other)
+			switch {
+			case otherType.Flags()&checker.TypeFlagsStringLike != 0:
+				return c.GetStringType()
+			case otherType.Flags()&checker.TypeFlagsNumberLike != 0:
+				return c.GetNumberType()
+			}
+			return nil
+		case ast.KindEqualsEqualsEqualsToken, ast.KindExclamationEqualsEqualsToken, ast.KindEqualsEqualsToken, ast.KindExclamationEqualsToken:
+			otherType := c.GetTypeAtLocation(other)
+			if otherType.Flags()&checker.TypeFlagsNullable != 0 {
+				return nil
+			}
+			return otherType
+		case ast.KindEqualsToken:
+			if binary.Right == node {
+				return c.GetTypeAtLocation(binary.Left)
+			}
+			return nil
+		}
+	}
+	if ast.IsExpression(node) {
+		return c.GetContextualType(node, checker.ContextFlagsNone)
+	}
+	return nil
+}
@@ -0,0 +1,357 @@
+package ls
+
+import (
+	"context"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+)
+
+const (
+	fixNameMissingImport = "import"
+	fixIdMissingImport   = "fixMissingImport"
+)
+
+const CodeActionKindSourceAddMissingImports lsproto.CodeActionKind = "source.addMissingImports"
+
+var missingImportProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Cannot_find_name_0.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_the_instance_member_this_0.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_the_static_member_1_0.Code(),
+		diagnostics.Cannot_find_namespace_0.Code(),
+		diagnostics.Cannot_find_namespace_0_Did_you_mean_1.Code(),
+		diagnostics.X_0_only_refers_to_a_type_but_is_being_used_as_a_value_here.Code(),
+	},
+	fixIds:         []string{fixIdMissingImport},
+	getCodeActions: getCodeActionsToFixMissingImport,
+}
+
+func getCodeActionsToFixMissingImport(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	c, done := fixContext.program.GetTypeCheckerForFile(ctx, fixContext.sourceFile)
+	defer done()
+	symbolName, fixes := fixContext.ls.getImportFixesAtPosition(ctx, c, fixContext.sourceFile, fixContext.span.Pos(), fixContext.preferences)
+	var actions []*codeFixAction
+	for _, fix := range fixes {
+		action := fixContext.ls.codeActionForFix(ctx, fixContext.sourceFile, symbolName, fix, true /*includeSymbolNameInDescription*/, fixContext.preferences)
+		actions = append(actions, &codeFixAction{
+			fixName:     fixNameMissingImport,
+			description: action.description,
+			changes:     map[string][]*lsproto.TextEdit{fixContext.sourceFile.FileName(): action.changes},
+			fixId:       fixIdMissingImport,
+		})
+	}
+	return actions
+}
+
+// AddMissingImports returns the edits that import every name a file refers to but cannot find, if some module
+// exports it. Names imported from the same module are imported by the same declaration.
+func (l *LanguageService) AddMissingImports(ctx context.Context, uri lsproto.DocumentUri, preferences *UserPreferences) map[string][]*lsproto.TextEdit {
+	program, file := l.getProgramAndFile(uri)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+	adder := l.newImportAdder(ctx, c, file, preferences)
+	for _, diagnostic := range getCodeFixDiagnostics(ctx, program, file) {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if slices.Contains(missingImportProvider.errorCodes, diagnostic.Code()) {
+			adder.addImportFromDiagnostic(diagnostic)
+		}
+	}
+	ct := l.newChangeTracker(ctx)
+	adder.writeFixes(ct)
+	return ct.getChanges()
+}
+
+// getImportFixesAtPosition returns the name of the identifier at a position and the fixes that import it, with
+// the best fix first.
+func (l *LanguageService) getImportFixesAtPosition(ctx context.Context, c *checker.Checker, file *ast.SourceFile, position int, preferences *UserPreferences) (string, []*ImportFix) {
+	token := astnav.GetTokenAtPosition(file, position)
+	if !ast.IsIdentifier(token) {
+		return "", nil
+	}
+	symbolName := token.Text()
+	// Intrinsic JSX elements, e.g. `<div>`, are not imported.
+	if symbolName == ast.InternalSymbolNameDefault || ast.IsJsxTagName(token) && scanner.IsIntrinsicJsxName(symbolName) {
+		return "", nil
+	}
+
+	exportInfos := l.getExportInfosForSymbolName(ctx, c, file, symbolName, ast.IsJsxTagName(token), getMeaningFromLocation(token), preferences)
+	if len(exportInfos) == 0 {
+		return "", nil
+	}
+	usagePosition := l.converters.PositionToLineAndCharacter(file, core.TextPos(scanner.GetTokenPosOfNode(token, file, false /*includeJSDoc*/)))
+	return symbolName, l.getImportFixesForExportInfos(c, file, exportInfos, &usagePosition, ast.IsValidTypeOnlyAliasUseSite(token), preferences)
+}
+
+// getImportFixesForExportInfos returns the fixes that import one of the exports into a file, with the best fix first.
+// usagePosition is the position of the name to import, or nil if the name is not used in the file yet.
+func (l *LanguageService) getImportFixesForExportInfos(c *checker.Checker, file *ast.SourceFile, exportInfos []*SymbolExportInfo, usagePosition *lsproto.Position, isValidTypeOnlyUseSite bool, preferences *UserPreferences) []*ImportFix {
+	var userPreferences UserPreferences
+	if preferences != nil {
+		userPreferences = *preferences
+	}
+	useRequire := getShouldUseRequire(file, l.GetProgram())
+	_, fixes := l.getImportFixes(c, exportInfos, usagePosition, &isValidTypeOnlyUseSite, &useRequire, file, userPreferences, false /*fromCacheOnly*/)
+	// !!! JSDoc type imports and promoting type-only imports are not implemented
+	fixes = core.Filter(fixes, func(fix *ImportFix) bool {
+		return fix.kind == ImportFixKindUseNamespace || fix.kind == ImportFixKindAddToExisting || fix.kind == ImportFixKindAddNew
+	})
+	if best := l.getBestFix(fixes, file, l.createPackageJsonImportFilter(file, userPreferences).allowsImportingSpecifier, userPreferences); best != nil {
+		fixes = append([]*ImportFix{best}, core.Filter(fixes, func(fix *ImportFix) bool { return fix != best })...)
+	}
+	return fixes
+}
+
+// getExportInfosForSymbolName returns the exports of every module the file can import from that can be imported
+// with the given name and have the meaning of the identifier that refers to them.
+func (l *LanguageService) getExportInfosForSymbolName(ctx context.Context, c *checker.Checker, file *ast.SourceFile, symbolName string, isJsxTagName bool, meaning ast.SemanticMeaning, preferences *UserPreferences) []*SymbolExportInfo {
+	program := l.GetProgram()
+	target := program.Options().GetEmitScriptTarget()
+	var exportInfos []*SymbolExportInfo
+	addSymbol := func(moduleSymbol *ast.Symbol, moduleFile *ast.SourceFile, exportedSymbol *ast.Symbol, exportKind ExportKind) {
+		if !l.isImportable(file, moduleFile, moduleSymbol, preferences, nil /*packageJsonFilter*/) {
+			return
+		}
+		var moduleFileName string
+		if moduleFile != nil {
+			moduleFileName = moduleFile.FileName()
+		}
+		exportInfos = append(exportInfos, &SymbolExportInfo{
+			symbol:         exportedSymbol,
+			moduleSymbol:   moduleSymbol,
+			moduleFileName: moduleFileName,
+			exportKind:     exportKind,
+			targetFlags:    c.SkipAlias(exportedSymbol).Flags,
+		})
+	}
+	moduleCount := 0
+	forEachExternalModuleToImportFrom(c, program, preferences, func(moduleSymbol *ast.Symbol, moduleFile *ast.SourceFile, c *checker.Checker, isFromPackageJson bool) {
+		if moduleCount = moduleCount + 1; moduleCount%100 == 0 && ctx.Err() != nil {
+			return
+		}
+		if defaultInfo := getDefaultLikeExportInfo(moduleSymbol, c); defaultInfo != nil &&
+			symbolFlagsHaveMeaning(c.SkipAlias(defaultInfo.exportingModuleSymbol).Flags, meaning) &&
+			forEachNameOfDefaultExport(defaultInfo.exportingModuleSymbol, c, target, func(name string, capitalizedName string) string {
+				if isJsxTagName && capitalizedName != "" {
+					name = capitalizedName
+				}
+				return core.IfElse(name == symbolName, name, "")
+			}) != "" {
+			addSymbol(moduleSymbol, moduleFile, defaultInfo.exportingModuleSymbol, defaultInfo.exportKind)
+		}
+		if exported := c.TryGetMemberInModuleExportsAndProperties(symbolName, moduleSymbol); exported != nil && symbolFlagsHaveMeaning(c.SkipAlias(exported).Flags, meaning) {
+			addSymbol(moduleSymbol, moduleFile, exported, ExportKindNamed)
+		}
+	})
+	return exportInfos
+}
+
+func symbolFlagsHaveMeaning(flags ast.SymbolFlags, meaning ast.SemanticMeaning) bool {
+	return meaning == ast.SemanticMeaningAll ||
+		meaning&ast.SemanticMeaningValue != 0 && flags&ast.SymbolFlagsValue != 0 ||
+		meaning&ast.SemanticMeaningType != 0 && flags&ast.SymbolFlagsType != 0 ||
+		meaning&ast.SemanticMeaningNamespace != 0 && flags&ast.SymbolFlagsNamespace != 0
+}
+
+// importAdder collects the imports of several fixes, so that they can be written together. Names imported from
+// the same module are added to the same import declaration.
+type importAdder struct {
+	ls          *LanguageService
+	ctx         context.Context
+	checker     *checker.Checker
+	file        *ast.SourceFile
+	preferences *UserPreferences
+
+	addToNamespace []*ImportFix
+	addToExisting  []*existingImportAddition
+	newImports     []*newImportAddition
+}
+
+// existingImportAddition holds the names to add to an existing import declaration.
+type existingImportAddition struct {
+	clause        *ast.Node
+	defaultImport *Import
+	namedImports  []*Import
+}
+
+// newImportAddition holds the names to import from a module that is not imported yet.
+type newImportAddition struct {
+	moduleSpecifier     string
+	topLevelTypeOnly    bool
+	useRequire          bool
+	defaultImport       *Import
+	namedImports        []*Import
+	namespaceLikeImport *Import
+	qualifications      []*Qualification
+}
+
+func (l *LanguageService) newImportAdder(ctx context.Context, c *checker.Checker, file *ast.SourceFile, preferences *UserPreferences) *importAdder {
+	return &importAdder{ls: l, ctx: ctx, checker: c, file: file, preferences: preferences}
+}
+
+// addImportFromDiagnostic adds the best import of the name a diagnostic reports as missing, if there is one.
+func (a *importAdder) addImportFromDiagnostic(diagnostic *ast.Diagnostic) {
+	symbolName, fixes := a.ls.getImportFixesAtPosition(a.ctx, a.checker, a.file, diagnostic.Pos(), a.preferences)
+	if len(fixes) > 0 {
+		a.addImport(symbolName, fixes[0])
+	}
+}
+
+// addImportFromExportedSymbol adds the best import of a symbol that a module exports, e.g. a symbol that a node
+// builder referred to with an import type. Returns the name the symbol is imported with, or "" if it can not be
+// imported.
+func (a *importAdder) addImportFromExportedSymbol(exportedSymbol *ast.Symbol, isValidTypeOnlyUseSite bool) string {
+	symbolName := exportedSymbol.Name
+	if symbolName == ast.InternalSymbolNameDefault || symbolName == ast.InternalSymbolNameExportEquals {
+		symbolName = forEachNameOfDefaultExport(exportedSymbol, a.checker, a.ls.GetProgram().Options().GetEmitScriptTarget(), func(name string, _ string) string {
+			return name
+		})
+		if symbolName == "" {
+			return ""
+		}
+	}
+	symbol := a.checker.GetMergedSymbol(a.checker.SkipAlias(exportedSymbol))
+	exportInfos := core.Filter(a.ls.getExportInfosForSymbolName(a.ctx, a.checker, a.file, symbolName, false /*isJsxTagName*/, ast.SemanticMeaningAll, a.preferences), func(info *SymbolExportInfo) bool {
+		return a.checker.GetMergedSymbol(a.checker.SkipAlias(info.symbol)) == symbol
+	})
+	fixes := a.ls.getImportFixesForExportInfos(a.checker, a.file, exportInfos, nil /*usagePosition*/, isValidTypeOnlyUseSite, a.preferences)
+	if len(fixes) == 0 {
+		return ""
+	}
+	a.addImport(symbolName, fixes[0])
+	return symbolName
+}
+
+func (a *importAdder) addImport(symbolName string, fix *ImportFix) {
+	switch fix.kind {
+	case ImportFixKindUseNamespace:
+		a.addToNamespace = append(a.addToNamespace, fix)
+	case ImportFixKindAddToExisting:
+		index := slices.IndexFunc(a.addToExisting, func(addition *existingImportAddition) bool {
+			return addition.clause == fix.importClauseOrBindingPattern
+		})
+		if index < 0 {
+			index = len(a.addToExisting)
+			a.addToExisting = append(a.addToExisting, &existingImportAddition{clause: fix.importClauseOrBindingPattern})
+		}
+		addition := a.addToExisting[index]
+		if fix.importKind == ImportKindDefault {
+			addition.defaultImport = addImportName(addition.defaultImport, symbolName, fix.addAsTypeOnly)
+		} else {
+			addition.namedImports = addNamedImport(addition.namedImports, symbolName, fix.addAsTypeOnly)
+		}
+	case ImportFixKindAddNew:
+		addition := a.getNewImportAddition(fix)
+		switch fix.importKind {
+		case ImportKindDefault:
+			addition.defaultImport = addImportName(addition.defaultImport, symbolName, fix.addAsTypeOnly)
+		case ImportKindNamed:
+			addition.namedImports = addNamedImport(addition.namedImports, symbolName, fix.addAsTypeOnly)
+		case ImportKindNamespace, ImportKindCommonJS:
+			name := symbolName
+			if qualification := fix.qualification(); qualification != nil {
+				name = qualification.namespacePrefix
+				addition.qualifications = append(addition.qualifications, qualification)
+			}
+			if addition.namespaceLikeImport == nil {
+				addition.namespaceLikeImport = &Import{kind: fix.importKind, name: name, addAsTypeOnly: fix.addAsTypeOnly}
+			} else {
+				addition.namespaceLikeImport.addAsTypeOnly = reduceAddAsTypeOnlyValues(addition.namespaceLikeImport.addAsTypeOnly, fix.addAsTypeOnly)
+			}
+		}
+	}
+}
+
+// getNewImportAddition returns the new import a fix adds its name to. Type-only default imports can not be combined
+// with other imports, so they get a declaration of their own.
+func (a *importAdder) getNewImportAddition(fix *ImportFix) *newImportAddition {
+	find := func(topLevelTypeOnly bool) *newImportAddition {
+		index := slices.IndexFunc(a.newImports, func(addition *newImportAddition) bool {
+			return addition.moduleSpecifier == fix.moduleSpecifier && addition.topLevelTypeOnly == topLevelTypeOnly
+		})
+		if index < 0 {
+			return nil
+		}
+		return a.newImports[index]
+	}
+	add := func(topLevelTypeOnly bool) *newImportAddition {
+		addition := &newImportAddition{moduleSpecifier: fix.moduleSpecifier, topLevelTypeOnly: topLevelTypeOnly, useRequire: fix.useRequire}
+		a.newImports = append(a.newImports, addition)
+		return addition
+	}
+
+	typeOnlyAddition, addition := find(true), find(false)
+	if fix.importKind == ImportKindDefault && fix.addAsTypeOnly == AddAsTypeOnlyRequired {
+		if typeOnlyAddition != nil {
+			return typeOnlyAddition
+		}
+		return add(true)
+	}
+	if fix.addAsTypeOnly == AddAsTypeOnlyAllowed && typeOnlyAddition != nil {
+		return typeOnlyAddition
+	}
+	if addition != nil {
+		return addition
+	}
+	return add(false)
+}
+
+func addImportName(existing *Import, symbolName string, addAsTypeOnly AddAsTypeOnly) *Import {
+	if existing == nil {
+		return &Import{name: symbolName, addAsTypeOnly: addAsTypeOnly}
+	}
+	existing.addAsTypeOnly = reduceAddAsTypeOnlyValues(existing.addAsTypeOnly, addAsTypeOnly)
+	return existing
+}
+
+func addNamedImport(namedImports []*Import, symbolName string, addAsTypeOnly AddAsTypeOnly) []*Import {
+	if index := slices.IndexFunc(namedImports, func(i *Import) bool { return i.name == symbolName }); index >= 0 {
+		namedImports[index].addAsTypeOnly = reduceAddAsTypeOnlyValues(namedImports[index].addAsTypeOnly, addAsTypeOnly)
+		return namedImports
+	}
+	return append(namedImports, &Import{name: symbolName, addAsTypeOnly: addAsTypeOnly})
+}
+
+// reduceAddAsTypeOnlyValues combines the type-only requirements of the uses of a name. A name that is used as a
+// value anywhere can not be imported as type-only.
+func reduceAddAsTypeOnlyValues(a AddAsTypeOnly, b AddAsTypeOnly) AddAsTypeOnly {
+	return max(a, b)
+}
+
+// writeFixes writes the collected imports to the change tracker.
+func (a *importAdder) writeFixes(ct *changeTracker) {
+	for _, fix := range a.addToNamespace {
+		ct.addNamespaceQualifier(a.file, fix.qualification())
+	}
+	for _, addition := range a.addToExisting {
+		ct.doAddExistingFix(a.file, addition.clause, addition.defaultImport, addition.namedImports, a.preferences)
+	}
+	var declarations []*ast.Statement
+	quotePreference := getQuotePreference(a.file, a.preferences)
+	for _, addition := range a.newImports {
+		if addition.useRequire {
+			// !!! require
+			continue
+		}
+		slices.SortFunc(addition.namedImports, func(a, b *Import) int {
+			return stringutil.CompareStringsCaseInsensitiveThenSensitive(a.name, b.name)
+		})
+		declarations = append(declarations, ct.getNewImports(addition.moduleSpecifier, quotePreference, addition.defaultImport, addition.namedImports, addition.namespaceLikeImport, a.ls.GetProgram().Options(), a.preferences)...)
+		for _, qualification := range addition.qualifications {
+			ct.addNamespaceQualifier(a.file, qualification)
+		}
+	}
+	if len(declarations) > 0 {
+		ct.insertImports(a.file, declarations, true /*blankLineBetween*/, a.preferences)
+	}
+}
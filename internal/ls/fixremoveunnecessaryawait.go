@@ -0,0 +1,58 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const (
+	fixNameRemoveUnnecessaryAwait = "removeUnnecessaryAwait"
+	fixIdRemoveUnnecessaryAwait   = "removeUnnecessaryAwait"
+)
+
+var removeUnnecessaryAwaitProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.X_await_has_no_effect_on_the_type_of_this_expression.Code(),
+	},
+	fixIds:         []string{fixIdRemoveUnnecessaryAwait},
+	getCodeActions: getCodeActionsToRemoveUnnecessaryAwait,
+	fixAll: func(ct *changeTracker, fixContext *codeFixContext, seen *collections.Set[*ast.Node]) {
+		if awaitExpression := getUnnecessaryAwaitExpression(fixContext.sourceFile, fixContext.span.Pos()); awaitExpression != nil && seen.AddIfAbsent(awaitExpression) {
+			ct.removeAwaitKeyword(fixContext.sourceFile, awaitExpression)
+		}
+	},
+}
+
+func getCodeActionsToRemoveUnnecessaryAwait(ctx context.Context, fixContext *codeFixContext) []*codeFixAction {
+	awaitExpression := getUnnecessaryAwaitExpression(fixContext.sourceFile, fixContext.span.Pos())
+	if awaitExpression == nil {
+		return nil
+	}
+	action := fixContext.ls.createCodeFixAction(ctx, fixNameRemoveUnnecessaryAwait, diagnostics.Remove_unnecessary_await.Message(), fixIdRemoveUnnecessaryAwait, func(ct *changeTracker) {
+		ct.removeAwaitKeyword(fixContext.sourceFile, awaitExpression)
+	})
+	if action == nil {
+		return nil
+	}
+	return []*codeFixAction{action}
+}
+
+func getUnnecessaryAwaitExpression(sourceFile *ast.SourceFile, position int) *ast.Node {
+	token := astnav.GetTokenAtPosition(sourceFile, position)
+	if token.Kind != ast.KindAwaitKeyword || !ast.IsAwaitExpression(token.Parent) {
+		return nil
+	}
+	return token.Parent
+}
+
+// removeAwaitKeyword deletes the `await` keyword, and the whitespace after it, from an await expression.
+func (ct *changeTracker) removeAwaitKeyword(sourceFile *ast.SourceFile, awaitExpression *ast.Node) {
+	awaitStart := scanner.GetTokenPosOfNode(awaitExpression, sourceFile, false /*includeJSDoc*/)
+	operandStart := scanner.GetTokenPosOfNode(awaitExpression.Expression(), sourceFile, false /*includeJSDoc*/)
+	ct.replaceRangeWithText(sourceFile, *ct.ls.createLspRangeFromBounds(awaitStart, operandStart, sourceFile), "")
+}
@@ -0,0 +1,405 @@
+package ls
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+)
+
+type outliningSpanKind int
+
+const (
+	outliningSpanKindCode outliningSpanKind = iota
+	outliningSpanKindComment
+	outliningSpanKindRegion
+	outliningSpanKindImports
+)
+
+type outliningSpan struct {
+	textSpan core.TextRange
+	kind     outliningSpanKind
+}
+
+func (l *LanguageService) ProvideFoldingRange(ctx context.Context, documentURI lsproto.DocumentUri) (lsproto.FoldingRangeResponse, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	spans := collectOutliningSpans(ctx, file)
+	if ctx.Err() != nil {
+		return lsproto.FoldingRangesOrNull{}, ctx.Err()
+	}
+
+	ranges := make([]*lsproto.FoldingRange, 0, len(spans))
+	for _, span := range spans {
+		if foldingRange := l.convertOutliningSpan(file, span); foldingRange != nil {
+			ranges = append(ranges, foldingRange)
+		}
+	}
+	return lsproto.FoldingRangesOrNull{FoldingRanges: &ranges}, nil
+}
+
+// convertOutliningSpan converts an outlining span to a line-based folding range. Like editors do for
+// TypeScript outlining spans, a span ending in a closing bracket stops folding on the line before it,
+// so that the closing line stays visible.
+func (l *LanguageService) convertOutliningSpan(file *ast.SourceFile, span outliningSpan) *lsproto.FoldingRange {
+	text := file.Text()
+	start := l.converters.PositionToLineAndCharacter(file, core.TextPos(span.textSpan.Pos()))
+	end := l.converters.PositionToLineAndCharacter(file, core.TextPos(span.textSpan.End()))
+
+	if span.kind == outliningSpanKindComment {
+		lineText := text[scanner.GetECMALineStarts(file)[start.Line]:getLineEndOfPosition(file, span.textSpan.Pos())]
+		if endRegionCommentRegExp.MatchString(lineText) {
+			return nil
+		}
+	}
+
+	endLine := end.Line
+	if end.Character > 0 && span.textSpan.End() > 0 && strings.ContainsRune("}])`>", rune(text[span.textSpan.End()-1])) {
+		endLine = max(endLine-1, start.Line)
+	}
+	if start.Line >= endLine {
+		return nil
+	}
+
+	foldingRange := &lsproto.FoldingRange{
+		StartLine: start.Line,
+		EndLine:   endLine,
+	}
+	switch span.kind {
+	case outliningSpanKindComment:
+		foldingRange.Kind = ptrTo(lsproto.FoldingRangeKindComment)
+	case outliningSpanKindRegion:
+		foldingRange.Kind = ptrTo(lsproto.FoldingRangeKindRegion)
+	case outliningSpanKindImports:
+		foldingRange.Kind = ptrTo(lsproto.FoldingRangeKindImports)
+	}
+	return foldingRange
+}
+
+func collectOutliningSpans(ctx context.Context, file *ast.SourceFile) []outliningSpan {
+	var spans []outliningSpan
+	addNodeOutliningSpans(ctx, file, &spans)
+	addRegionOutliningSpans(file, &spans)
+	slices.SortStableFunc(spans, func(a, b outliningSpan) int {
+		return a.textSpan.Pos() - b.textSpan.Pos()
+	})
+	return spans
+}
+
+func addNodeOutliningSpans(ctx context.Context, file *ast.SourceFile, out *[]outliningSpan) {
+	depthRemaining := 40
+	var visit func(n *ast.Node) bool
+	visitNode := func(n *ast.Node) {
+		if depthRemaining == 0 || ctx.Err() != nil {
+			return
+		}
+
+		if ast.IsDeclaration(n) || ast.IsVariableStatement(n) || ast.IsReturnStatement(n) || ast.IsCallOrNewExpression(n) || n.Kind == ast.KindEndOfFile {
+			addOutliningForLeadingCommentsForNode(n, file, out)
+		}
+		if ast.IsFunctionLike(n) && n.Parent != nil && ast.IsBinaryExpression(n.Parent) && ast.IsPropertyAccessExpression(n.Parent.AsBinaryExpression().Left) {
+			addOutliningForLeadingCommentsForNode(n.Parent.AsBinaryExpression().Left, file, out)
+		}
+		if ast.IsBlock(n) || ast.IsModuleBlock(n) {
+			addOutliningForLeadingCommentsForPos(n.StatementList().End(), file, out)
+		}
+		if ast.IsClassLike(n) || ast.IsInterfaceDeclaration(n) {
+			addOutliningForLeadingCommentsForPos(n.MemberList().End(), file, out)
+		}
+
+		if span, ok := getOutliningSpanForNode(n, file); ok {
+			*out = append(*out, span)
+		}
+
+		depthRemaining--
+		switch {
+		case ast.IsCallExpression(n):
+			depthRemaining++
+			visit(n.Expression())
+			depthRemaining--
+			for _, argument := range n.Arguments() {
+				visit(argument)
+			}
+			for _, typeArgument := range n.TypeArguments() {
+				visit(typeArgument)
+			}
+		case ast.IsIfStatement(n) && n.AsIfStatement().ElseStatement != nil && ast.IsIfStatement(n.AsIfStatement().ElseStatement):
+			// Consider an 'else if' to be on the same depth as the 'if'.
+			ifStatement := n.AsIfStatement()
+			visit(ifStatement.Expression)
+			visit(ifStatement.ThenStatement)
+			depthRemaining++
+			visit(ifStatement.ElseStatement)
+			depthRemaining--
+		default:
+			n.ForEachChild(visit)
+		}
+		depthRemaining++
+	}
+	visit = func(n *ast.Node) bool {
+		visitNode(n)
+		return false
+	}
+
+	statements := append(slices.Clone(file.Statements.Nodes), file.EndOfFileToken)
+	current := 0
+	for current < len(statements) {
+		for current < len(statements) && !ast.IsAnyImportSyntax(statements[current]) {
+			visitNode(statements[current])
+			current++
+		}
+		if current == len(statements) {
+			break
+		}
+		firstImport := current
+		for current < len(statements) && ast.IsAnyImportSyntax(statements[current]) {
+			visitNode(statements[current])
+			current++
+		}
+		lastImport := current - 1
+		if lastImport != firstImport {
+			if importKeyword := findChildOfKind(statements[firstImport], ast.KindImportKeyword, file); importKeyword != nil {
+				start := scanner.GetTokenPosOfNode(importKeyword, file, false /*includeJSDoc*/)
+				*out = append(*out, createOutliningSpanFromBounds(start, statements[lastImport].End(), outliningSpanKindImports))
+			}
+		}
+	}
+}
+
+var (
+	regionDelimiterRegExp  = regexp.MustCompile(`^#(end)?region(.*)\r?$`)
+	endRegionCommentRegExp = regexp.MustCompile(`(?i)//\s*#endregion`)
+)
+
+func addRegionOutliningSpans(file *ast.SourceFile, out *[]outliningSpan) {
+	var regions []outliningSpan
+	text := file.Text()
+	for _, lineStart := range scanner.GetECMALineStarts(file) {
+		currentLineStart := int(lineStart)
+		lineEnd := getLineEndOfPosition(file, currentLineStart)
+		isStart, ok := parseRegionDelimiter(text[currentLineStart:lineEnd])
+		if !ok || isInComment(file, currentLineStart, astnav.GetTokenAtPosition(file, currentLineStart)) != nil {
+			continue
+		}
+		if isStart {
+			commentStart := currentLineStart + strings.Index(text[currentLineStart:], "//")
+			regions = append(regions, createOutliningSpanFromBounds(commentStart, lineEnd, outliningSpanKindRegion))
+		} else if len(regions) > 0 {
+			region := regions[len(regions)-1]
+			regions = regions[:len(regions)-1]
+			region.textSpan = core.NewTextRange(region.textSpan.Pos(), lineEnd)
+			*out = append(*out, region)
+		}
+	}
+}
+
+// parseRegionDelimiter reports whether lineText is a `// #region` or `// #endregion` comment,
+// and if so, whether it starts a region.
+func parseRegionDelimiter(lineText string) (isStart bool, ok bool) {
+	// We trim the leading whitespace and // without the regex since the
+	// multiple potential whitespace matches can make for some gnarly backtracking behavior
+	lineText = strings.TrimLeftFunc(lineText, stringutil.IsWhiteSpaceLike)
+	if !strings.HasPrefix(lineText, "//") {
+		return false, false
+	}
+	lineText = strings.TrimFunc(lineText[2:], stringutil.IsWhiteSpaceLike)
+	match := regionDelimiterRegExp.FindStringSubmatch(lineText)
+	if match == nil {
+		return false, false
+	}
+	return match[1] == "", true
+}
+
+func addOutliningForLeadingCommentsForNode(n *ast.Node, file *ast.SourceFile, out *[]outliningSpan) {
+	if ast.IsJsxText(n) {
+		return
+	}
+	addOutliningForLeadingCommentsForPos(n.Pos(), file, out)
+}
+
+func addOutliningForLeadingCommentsForPos(pos int, file *ast.SourceFile, out *[]outliningSpan) {
+	text := file.Text()
+	firstSingleLineCommentStart := -1
+	lastSingleLineCommentEnd := -1
+	singleLineCommentCount := 0
+	combineAndAddMultipleSingleLineComments := func() {
+		// Only outline spans of two or more consecutive single line comments
+		if singleLineCommentCount > 1 {
+			*out = append(*out, createOutliningSpanFromBounds(firstSingleLineCommentStart, lastSingleLineCommentEnd, outliningSpanKindComment))
+		}
+	}
+
+	for comment := range scanner.GetLeadingCommentRanges(&ast.NodeFactory{}, text, pos) {
+		switch comment.Kind {
+		case ast.KindSingleLineCommentTrivia:
+			// never fold region delimiters into single-line comment regions
+			if _, ok := parseRegionDelimiter(text[comment.Pos():comment.End()]); ok {
+				combineAndAddMultipleSingleLineComments()
+				singleLineCommentCount = 0
+				break
+			}
+			// For single line comments, combine consecutive ones (2 or more) into
+			// a single span from the start of the first till the end of the last
+			if singleLineCommentCount == 0 {
+				firstSingleLineCommentStart = comment.Pos()
+			}
+			lastSingleLineCommentEnd = comment.End()
+			singleLineCommentCount++
+		case ast.KindMultiLineCommentTrivia:
+			combineAndAddMultipleSingleLineComments()
+			*out = append(*out, createOutliningSpanFromBounds(comment.Pos(), comment.End(), outliningSpanKindComment))
+			singleLineCommentCount = 0
+		}
+	}
+	combineAndAddMultipleSingleLineComments()
+}
+
+func createOutliningSpanFromBounds(pos int, end int, kind outliningSpanKind) outliningSpan {
+	return outliningSpan{textSpan: core.NewTextRange(pos, end), kind: kind}
+}
+
+func getOutliningSpanForNode(n *ast.Node, file *ast.SourceFile) (outliningSpan, bool) {
+	switch n.Kind {
+	case ast.KindBlock:
+		if ast.IsFunctionLike(n.Parent) {
+			return functionOutliningSpan(n.Parent, n, file)
+		}
+		// Check if the block is standalone, or 'attached' to some parent statement.
+		// If the latter, we want to collapse the block, but consider its hint span
+		// to be the entire span of the parent.
+		switch n.Parent.Kind {
+		case ast.KindDoStatement, ast.KindForInStatement, ast.KindForOfStatement, ast.KindForStatement,
+			ast.KindIfStatement, ast.KindWhileStatement, ast.KindWithStatement, ast.KindCatchClause:
+			return spanForNode(n, file, true /*useFullStart*/, ast.KindOpenBraceToken)
+		case ast.KindTryStatement:
+			tryStatement := n.Parent.AsTryStatement()
+			if tryStatement.TryBlock == n || tryStatement.FinallyBlock == n {
+				return spanForNode(n, file, true /*useFullStart*/, ast.KindOpenBraceToken)
+			}
+		}
+		// Block was a standalone block. In this case we want to only collapse
+		// the span of the block, independent of any parent span.
+		return createOutliningSpanFromBounds(scanner.GetTokenPosOfNode(n, file, false /*includeJSDoc*/), n.End(), outliningSpanKindCode), true
+	case ast.KindModuleBlock:
+		return spanForNode(n, file, true /*useFullStart*/, ast.KindOpenBraceToken)
+	case ast.KindClassDeclaration, ast.KindClassExpression, ast.KindInterfaceDeclaration, ast.KindEnumDeclaration,
+		ast.KindCaseBlock, ast.KindTypeLiteral, ast.KindObjectBindingPattern:
+		return spanForNode(n, file, true /*useFullStart*/, ast.KindOpenBraceToken)
+	case ast.KindTupleType:
+		return spanForNode(n, file, !ast.IsTupleTypeNode(n.Parent) /*useFullStart*/, ast.KindOpenBracketToken)
+	case ast.KindCaseClause, ast.KindDefaultClause:
+		statements := n.AsCaseOrDefaultClause().Statements
+		if len(statements.Nodes) == 0 {
+			return outliningSpan{}, false
+		}
+		return createOutliningSpanFromBounds(statements.Pos(), statements.End(), outliningSpanKindCode), true
+	case ast.KindObjectLiteralExpression:
+		return spanForObjectOrArrayLiteral(n, file, ast.KindOpenBraceToken)
+	case ast.KindArrayLiteralExpression:
+		return spanForObjectOrArrayLiteral(n, file, ast.KindOpenBracketToken)
+	case ast.KindJsxElement:
+		element := n.AsJsxElement()
+		start := scanner.GetTokenPosOfNode(element.OpeningElement, file, false /*includeJSDoc*/)
+		return createOutliningSpanFromBounds(start, element.ClosingElement.End(), outliningSpanKindCode), true
+	case ast.KindJsxFragment:
+		fragment := n.AsJsxFragment()
+		start := scanner.GetTokenPosOfNode(fragment.OpeningFragment, file, false /*includeJSDoc*/)
+		return createOutliningSpanFromBounds(start, fragment.ClosingFragment.End(), outliningSpanKindCode), true
+	case ast.KindJsxSelfClosingElement, ast.KindJsxOpeningElement:
+		attributes := n.Attributes()
+		if len(attributes.Properties()) == 0 {
+			return outliningSpan{}, false
+		}
+		return createOutliningSpanFromBounds(scanner.GetTokenPosOfNode(attributes, file, false /*includeJSDoc*/), attributes.End(), outliningSpanKindCode), true
+	case ast.KindTemplateExpression, ast.KindNoSubstitutionTemplateLiteral:
+		if n.Kind == ast.KindNoSubstitutionTemplateLiteral && len(n.Text()) == 0 {
+			return outliningSpan{}, false
+		}
+		return createOutliningSpanFromBounds(scanner.GetTokenPosOfNode(n, file, false /*includeJSDoc*/), n.End(), outliningSpanKindCode), true
+	case ast.KindArrayBindingPattern:
+		return spanForNode(n, file, !ast.IsBindingElement(n.Parent) /*useFullStart*/, ast.KindOpenBracketToken)
+	case ast.KindArrowFunction:
+		body := n.Body()
+		if ast.IsBlock(body) || ast.IsParenthesizedExpression(body) || positionsAreOnSameLine(file, body.Pos(), body.End()) {
+			return outliningSpan{}, false
+		}
+		return createOutliningSpanFromBounds(body.Pos(), body.End(), outliningSpanKindCode), true
+	case ast.KindCallExpression:
+		if len(n.Arguments()) == 0 {
+			return outliningSpan{}, false
+		}
+		return spanBetweenChildTokens(n, file, ast.KindOpenParenToken, ast.KindCloseParenToken, true /*useFullStart*/)
+	case ast.KindParenthesizedExpression:
+		start := scanner.GetTokenPosOfNode(n, file, false /*includeJSDoc*/)
+		if positionsAreOnSameLine(file, start, n.End()) {
+			return outliningSpan{}, false
+		}
+		return createOutliningSpanFromBounds(start, n.End(), outliningSpanKindCode), true
+	case ast.KindNamedImports, ast.KindNamedExports, ast.KindImportAttributes:
+		if len(n.Elements()) == 0 {
+			return outliningSpan{}, false
+		}
+		return spanBetweenChildTokens(n, file, ast.KindOpenBraceToken, ast.KindCloseBraceToken, false /*useFullStart*/)
+	}
+	return outliningSpan{}, false
+}
+
+func spanForObjectOrArrayLiteral(n *ast.Node, file *ast.SourceFile, open ast.Kind) (outliningSpan, bool) {
+	// If the block has no leading keywords and is inside an array literal or call expression,
+	// we only want to collapse the span of the block.
+	// Otherwise, the collapsed section will include the end of the previous line.
+	useFullStart := !ast.IsArrayLiteralExpression(n.Parent) && !ast.IsCallExpression(n.Parent)
+	return spanForNode(n, file, useFullStart, open)
+}
+
+func spanForNode(n *ast.Node, file *ast.SourceFile, useFullStart bool, open ast.Kind) (outliningSpan, bool) {
+	closeKind := core.IfElse(open == ast.KindOpenBraceToken, ast.KindCloseBraceToken, ast.KindCloseBracketToken)
+	openToken := findChildOfKind(n, open, file)
+	closeToken := findChildOfKind(n, closeKind, file)
+	if openToken == nil || closeToken == nil {
+		return outliningSpan{}, false
+	}
+	return spanBetweenTokens(openToken, closeToken, file, useFullStart), true
+}
+
+func spanBetweenChildTokens(n *ast.Node, file *ast.SourceFile, open ast.Kind, close ast.Kind, useFullStart bool) (outliningSpan, bool) {
+	openToken := findChildOfKind(n, open, file)
+	closeToken := findChildOfKind(n, close, file)
+	if openToken == nil || closeToken == nil || positionsAreOnSameLine(file, openToken.Pos(), closeToken.Pos()) {
+		return outliningSpan{}, false
+	}
+	return spanBetweenTokens(openToken, closeToken, file, useFullStart), true
+}
+
+func functionOutliningSpan(n *ast.Node, body *ast.Node, file *ast.SourceFile) (outliningSpan, bool) {
+	var openToken *ast.Node
+	if parameters := n.ParameterList(); parameters != nil && len(parameters.Nodes) > 0 && !positionsAreOnSameLine(file, parameters.Pos(), parameters.End()) {
+		openToken = findChildOfKind(n, ast.KindOpenParenToken, file)
+	}
+	if openToken == nil {
+		openToken = findChildOfKind(body, ast.KindOpenBraceToken, file)
+	}
+	closeToken := findChildOfKind(body, ast.KindCloseBraceToken, file)
+	if openToken == nil || closeToken == nil {
+		return outliningSpan{}, false
+	}
+	return spanBetweenTokens(openToken, closeToken, file, true /*useFullStart*/), true
+}
+
+func spanBetweenTokens(openToken *ast.Node, closeToken *ast.Node, file *ast.SourceFile, useFullStart bool) outliningSpan {
+	start := openToken.Pos()
+	if !useFullStart {
+		start = scanner.GetTokenPosOfNode(openToken, file, false /*includeJSDoc*/)
+	}
+	return createOutliningSpanFromBounds(start, closeToken.End(), outliningSpanKindCode)
+}
+
+func positionsAreOnSameLine(file *ast.SourceFile, pos1 int, pos2 int) bool {
+	return getLineOfPosition(file, pos1) == getLineOfPosition(file, pos2)
+}
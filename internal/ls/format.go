@@ -12,17 +12,30 @@ import (
 	"github.com/microsoft/typescript-go/internal/scanner"
 )
 
-func toFormatCodeSettings(opt *lsproto.FormattingOptions, newLine string) *format.FormatCodeSettings {
-	initial := format.GetDefaultFormatCodeSettings(newLine)
+// getFormatCodeSettings returns a copy of the format settings the request context was configured with, or
+// the default settings if there are none.
+func getFormatCodeSettings(ctx context.Context, newLine string) *format.FormatCodeSettings {
+	configured := format.GetFormatCodeSettingsFromContext(ctx)
+	if configured == nil {
+		return format.GetDefaultFormatCodeSettings(newLine)
+	}
+	settings := *configured
+	if settings.NewLineCharacter == "" {
+		settings.NewLineCharacter = newLine
+	}
+	return &settings
+}
+
+// toFormatCodeSettings combines the configured format settings with the editor settings sent with a
+// formatting request.
+func toFormatCodeSettings(ctx context.Context, opt *lsproto.FormattingOptions, newLine string) *format.FormatCodeSettings {
+	initial := getFormatCodeSettings(ctx, newLine)
 	initial.TabSize = int(opt.TabSize)
 	initial.IndentSize = int(opt.TabSize)
 	initial.ConvertTabsToSpaces = opt.InsertSpaces
 	if opt.TrimTrailingWhitespace != nil {
 		initial.TrimTrailingWhitespace = *opt.TrimTrailingWhitespace
 	}
-
-	// !!! get format settings
-	// TODO: We support a _lot_ more options than this
 	return initial
 }
 
@@ -46,7 +59,7 @@ func (l *LanguageService) ProvideFormatDocument(
 	edits := l.toLSProtoTextEdits(file, l.getFormattingEditsForDocument(
 		ctx,
 		file,
-		toFormatCodeSettings(options, l.GetProgram().Options().NewLine.GetNewLineCharacter()),
+		toFormatCodeSettings(ctx, options, l.GetProgram().Options().NewLine.GetNewLineCharacter()),
 	))
 	return lsproto.TextEditsOrNull{TextEdits: &edits}, nil
 }
@@ -61,7 +74,7 @@ func (l *LanguageService) ProvideFormatDocumentRange(
 	edits := l.toLSProtoTextEdits(file, l.getFormattingEditsForRange(
 		ctx,
 		file,
-		toFormatCodeSettings(options, l.GetProgram().Options().NewLine.GetNewLineCharacter()),
+		toFormatCodeSettings(ctx, options, l.GetProgram().Options().NewLine.GetNewLineCharacter()),
 		l.converters.FromLSPRange(file, r),
 	))
 	return lsproto.TextEditsOrNull{TextEdits: &edits}, nil
@@ -78,7 +91,7 @@ func (l *LanguageService) ProvideFormatDocumentOnType(
 	edits := l.toLSProtoTextEdits(file, l.getFormattingEditsAfterKeystroke(
 		ctx,
 		file,
-		toFormatCodeSettings(options, l.GetProgram().Options().NewLine.GetNewLineCharacter()),
+		toFormatCodeSettings(ctx, options, l.GetProgram().Options().NewLine.GetNewLineCharacter()),
 		int(l.converters.LineAndCharacterToPosition(file, position)),
 		character,
 	))
@@ -716,8 +716,9 @@ func findModuleReferences(program *compiler.Program, sourceFiles []*ast.SourceFi
 					})
 				} else {
 					refs = append(refs, ModuleReference{
-						kind:    ModuleReferenceKindImport,
-						literal: moduleSpecifier,
+						kind:            ModuleReferenceKindImport,
+						literal:         moduleSpecifier,
+						referencingFile: referencingFile,
 					})
 				}
 			}
@@ -0,0 +1,349 @@
+package ls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/jsnum"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+const inlayHintTypeFormatFlags = checker.TypeFormatFlagsAllowUniqueESSymbolType | checker.TypeFormatFlagsUseAliasDefinedOutsideCurrentScope
+
+type inlayHintState struct {
+	ctx         context.Context
+	ls          *LanguageService
+	checker     *checker.Checker
+	sourceFile  *ast.SourceFile
+	span        core.TextRange
+	preferences *UserPreferences
+	hints       []*lsproto.InlayHint
+}
+
+func (l *LanguageService) ProvideInlayHint(ctx context.Context, params *lsproto.InlayHintParams, preferences *UserPreferences) (lsproto.InlayHintResponse, error) {
+	program, file := l.getProgramAndFile(params.TextDocument.Uri)
+	if !preferences.hasInlayHints() {
+		return lsproto.InlayHintsOrNull{}, nil
+	}
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+	state := &inlayHintState{
+		ctx:         ctx,
+		ls:          l,
+		checker:     c,
+		sourceFile:  file,
+		span:        l.converters.FromLSPRange(file, params.Range),
+		preferences: preferences,
+		hints:       []*lsproto.InlayHint{},
+	}
+	file.AsNode().ForEachChild(state.visit)
+	if ctx.Err() != nil {
+		return lsproto.InlayHintsOrNull{}, ctx.Err()
+	}
+	return lsproto.InlayHintsOrNull{InlayHints: &state.hints}, nil
+}
+
+func (p *UserPreferences) hasInlayHints() bool {
+	return p.IncludeInlayParameterNameHints != IncludeInlayParameterNameHintsNone ||
+		p.IncludeInlayFunctionParameterTypeHints ||
+		p.IncludeInlayVariableTypeHints ||
+		p.IncludeInlayPropertyDeclarationTypeHints ||
+		p.IncludeInlayFunctionLikeReturnTypeHints ||
+		p.IncludeInlayEnumMemberValueHints
+}
+
+func (s *inlayHintState) visit(node *ast.Node) bool {
+	if node.End()-node.Pos() == 0 {
+		return false
+	}
+	switch node.Kind {
+	case ast.KindModuleDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindFunctionDeclaration,
+		ast.KindClassExpression, ast.KindFunctionExpression, ast.KindMethodDeclaration, ast.KindArrowFunction:
+		if s.ctx.Err() != nil {
+			return true
+		}
+	}
+	if !s.span.Overlaps(node.Loc) {
+		return false
+	}
+	if ast.IsTypeNode(node) && !ast.IsExpressionWithTypeArguments(node) {
+		return false
+	}
+
+	switch {
+	case s.preferences.IncludeInlayVariableTypeHints && ast.IsVariableDeclaration(node),
+		s.preferences.IncludeInlayPropertyDeclarationTypeHints && ast.IsPropertyDeclaration(node):
+		s.visitVariableLikeDeclaration(node)
+	case s.preferences.IncludeInlayEnumMemberValueHints && ast.IsEnumMember(node):
+		s.visitEnumMember(node)
+	case s.preferences.IncludeInlayParameterNameHints != IncludeInlayParameterNameHintsNone && (ast.IsCallExpression(node) || ast.IsNewExpression(node)):
+		s.visitCallOrNewExpression(node)
+	default:
+		if s.preferences.IncludeInlayFunctionParameterTypeHints && ast.IsFunctionLikeDeclaration(node) && checker.HasContextSensitiveParameters(node) {
+			s.visitFunctionLikeForParameterType(node)
+		}
+		if s.preferences.IncludeInlayFunctionLikeReturnTypeHints && isSignatureSupportingReturnAnnotation(node) {
+			s.visitFunctionDeclarationLikeForReturnType(node)
+		}
+	}
+	node.ForEachChild(s.visit)
+	return false
+}
+
+func (s *inlayHintState) addParameterHint(name string, position int, isFirstVariadicArgument bool) {
+	label := name + ":"
+	if isFirstVariadicArgument {
+		label = "..." + label
+	}
+	s.hints = append(s.hints, &lsproto.InlayHint{
+		Position:     s.ls.createLspPosition(position, s.sourceFile),
+		Label:        lsproto.StringOrInlayHintLabelParts{String: &label},
+		Kind:         ptrTo(lsproto.InlayHintKindParameter),
+		PaddingRight: ptrTo(true),
+	})
+}
+
+func (s *inlayHintState) addTypeHint(text string, position int) {
+	label := ": " + text
+	s.hints = append(s.hints, &lsproto.InlayHint{
+		Position:    s.ls.createLspPosition(position, s.sourceFile),
+		Label:       lsproto.StringOrInlayHintLabelParts{String: &label},
+		Kind:        ptrTo(lsproto.InlayHintKindType),
+		PaddingLeft: ptrTo(true),
+	})
+}
+
+func (s *inlayHintState) addEnumMemberValueHint(text string, position int) {
+	label := "= " + text
+	s.hints = append(s.hints, &lsproto.InlayHint{
+		Position:    s.ls.createLspPosition(position, s.sourceFile),
+		Label:       lsproto.StringOrInlayHintLabelParts{String: &label},
+		PaddingLeft: ptrTo(true),
+	})
+}
+
+func (s *inlayHintState) visitEnumMember(member *ast.Node) {
+	if member.Initializer() != nil {
+		return
+	}
+	switch value := s.checker.GetConstantValue(member).(type) {
+	case string:
+		quoted, _ := core.StringifyJson(value, "" /*prefix*/, "" /*indent*/)
+		s.addEnumMemberValueHint(quoted, member.End())
+	case jsnum.Number:
+		s.addEnumMemberValueHint(value.String(), member.End())
+	}
+}
+
+func (s *inlayHintState) visitVariableLikeDeclaration(decl *ast.Node) {
+	if decl.Initializer() == nil || ast.IsBindingPattern(decl.Name()) || ast.IsVariableDeclaration(decl) && !isHintableDeclaration(decl) {
+		return
+	}
+	if decl.Type() != nil {
+		return
+	}
+	declarationType := s.checker.GetTypeAtLocation(decl)
+	if isModuleReferenceType(declarationType) {
+		return
+	}
+	hintText := s.typeToString(declarationType)
+	// Only identifiers are compared with the type; the text of other names, e.g. computed property names, is not stored.
+	if !s.preferences.IncludeInlayVariableTypeHintsWhenTypeMatchesName && ast.IsIdentifier(decl.Name()) && strings.EqualFold(decl.Name().Text(), hintText) {
+		return
+	}
+	s.addTypeHint(hintText, decl.Name().End())
+}
+
+func (s *inlayHintState) visitCallOrNewExpression(expr *ast.Node) {
+	args := expr.Arguments()
+	if len(args) == 0 {
+		return
+	}
+	signature := s.checker.GetResolvedSignature(expr)
+	if signature == nil {
+		return
+	}
+	signatureParamPos := 0
+	for _, originalArg := range args {
+		arg := ast.SkipParentheses(originalArg)
+		if s.preferences.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsLiterals && !isHintableLiteral(arg) {
+			signatureParamPos++
+			continue
+		}
+		spreadArgs := 0
+		if ast.IsSpreadElement(arg) {
+			spreadType := s.checker.GetTypeAtLocation(arg.Expression())
+			if checker.IsTupleType(spreadType) {
+				tupleType := spreadType.TargetTupleType()
+				if tupleType.FixedLength() == 0 {
+					continue
+				}
+				spreadArgs = tupleType.FixedLength()
+				for i, flags := range tupleType.ElementFlags() {
+					if flags&checker.ElementFlagsRequired == 0 {
+						spreadArgs = i
+						break
+					}
+				}
+			}
+		}
+		identifierInfo := s.checker.GetParameterIdentifierInfoAtPosition(signature, signatureParamPos)
+		signatureParamPos += max(spreadArgs, 1)
+		if identifierInfo == nil {
+			continue
+		}
+		if !s.preferences.IncludeInlayParameterNameHintsWhenArgumentMatchesName && identifierOrAccessExpressionPostfixMatchesParameterName(arg, identifierInfo.ParameterName) && !identifierInfo.IsRestParameter {
+			continue
+		}
+		if s.leadingCommentsContainsParameterName(originalArg, identifierInfo.ParameterName) {
+			continue
+		}
+		s.addParameterHint(identifierInfo.ParameterName, astnav.GetStartOfNode(originalArg, s.sourceFile, false /*includeJSDoc*/), identifierInfo.IsRestParameter)
+	}
+}
+
+func (s *inlayHintState) visitFunctionDeclarationLikeForReturnType(decl *ast.Node) {
+	if ast.IsArrowFunction(decl) && findChildOfKind(decl, ast.KindOpenParenToken, s.sourceFile) == nil {
+		return
+	}
+	if decl.Type() != nil || decl.Body() == nil {
+		return
+	}
+	signature := s.checker.GetSignatureFromDeclaration(decl)
+	if signature == nil {
+		return
+	}
+	position := s.getTypeAnnotationPosition(decl)
+	if typePredicate := s.checker.GetTypePredicateOfSignature(signature); typePredicate != nil && typePredicate.Type() != nil {
+		s.addTypeHint(s.checker.TypePredicateToString(typePredicate), position)
+		return
+	}
+	returnType := s.checker.GetReturnTypeOfSignature(signature)
+	if isModuleReferenceType(returnType) {
+		return
+	}
+	s.addTypeHint(s.typeToString(returnType), position)
+}
+
+func (s *inlayHintState) getTypeAnnotationPosition(decl *ast.Node) int {
+	if closeParenToken := findChildOfKind(decl, ast.KindCloseParenToken, s.sourceFile); closeParenToken != nil {
+		return closeParenToken.End()
+	}
+	return decl.ParameterList().End()
+}
+
+func (s *inlayHintState) visitFunctionLikeForParameterType(node *ast.Node) {
+	signature := s.checker.GetSignatureFromDeclaration(node)
+	if signature == nil {
+		return
+	}
+	parameters := node.Parameters()
+	pos := 0
+	for _, param := range parameters {
+		if ast.IsThisParameter(param) {
+			continue
+		}
+		index := pos
+		pos++
+		if !isHintableDeclaration(param) || param.Type() != nil || index >= len(signature.Parameters()) {
+			continue
+		}
+		typeHint := s.getParameterDeclarationTypeHint(signature.Parameters()[index])
+		if typeHint == "" {
+			continue
+		}
+		position := param.Name().End()
+		if questionToken := param.AsParameterDeclaration().QuestionToken; questionToken != nil {
+			position = questionToken.End()
+		}
+		s.addTypeHint(typeHint, position)
+	}
+}
+
+func (s *inlayHintState) getParameterDeclarationTypeHint(symbol *ast.Symbol) string {
+	if symbol.ValueDeclaration == nil || !ast.IsParameter(symbol.ValueDeclaration) {
+		return ""
+	}
+	valueType := s.checker.GetTypeOfSymbolAtLocation(symbol, symbol.ValueDeclaration)
+	if isModuleReferenceType(valueType) {
+		return ""
+	}
+	return s.typeToString(valueType)
+}
+
+func (s *inlayHintState) typeToString(t *checker.Type) string {
+	return s.checker.TypeToStringEx(t, nil /*enclosingDeclaration*/, inlayHintTypeFormatFlags)
+}
+
+// leadingCommentsContainsParameterName reports whether an argument is already annotated with
+// a comment naming its parameter, e.g. `foo(/*x*/ 1)` or `foo(/* x= */ 1)`.
+func (s *inlayHintState) leadingCommentsContainsParameterName(node *ast.Node, name string) bool {
+	if !scanner.IsIdentifierText(name, core.LanguageVariantStandard) {
+		return false
+	}
+	text := s.sourceFile.Text()
+	for comment := range scanner.GetLeadingCommentRanges(&ast.NodeFactory{}, text, node.Pos()) {
+		commentText := text[comment.Pos():comment.End()]
+		if comment.Kind == ast.KindMultiLineCommentTrivia {
+			commentText = strings.TrimSuffix(strings.TrimPrefix(commentText, "/*"), "*/")
+			commentText = strings.TrimPrefix(commentText, "*")
+		} else {
+			commentText = strings.TrimPrefix(commentText, "//")
+		}
+		commentText = strings.TrimSpace(commentText)
+		commentText = strings.TrimSpace(strings.TrimRight(commentText, ":="))
+		if commentText == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isSignatureSupportingReturnAnnotation(node *ast.Node) bool {
+	return ast.IsArrowFunction(node) || ast.IsFunctionExpression(node) || ast.IsFunctionDeclaration(node) || ast.IsMethodDeclaration(node) || ast.IsGetAccessorDeclaration(node)
+}
+
+func isHintableDeclaration(node *ast.Node) bool {
+	if (ast.IsPartOfParameterDeclaration(node) || ast.IsVariableDeclaration(node) && ast.IsVarConst(node)) && node.Initializer() != nil {
+		initializer := ast.SkipParentheses(node.Initializer())
+		return !(isHintableLiteral(initializer) || ast.IsNewExpression(initializer) || ast.IsObjectLiteralExpression(initializer) || ast.IsAssertionExpression(initializer))
+	}
+	return true
+}
+
+func isHintableLiteral(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindPrefixUnaryExpression:
+		operand := node.AsPrefixUnaryExpression().Operand
+		return ast.IsLiteralExpression(operand) || ast.IsIdentifier(operand) && isInfinityOrNaNString(operand.Text())
+	case ast.KindTrueKeyword, ast.KindFalseKeyword, ast.KindNullKeyword, ast.KindNoSubstitutionTemplateLiteral, ast.KindTemplateExpression:
+		return true
+	case ast.KindIdentifier:
+		name := node.Text()
+		return name == "undefined" || isInfinityOrNaNString(name)
+	}
+	return ast.IsLiteralExpression(node)
+}
+
+func isInfinityOrNaNString(name string) bool {
+	return name == "Infinity" || name == "-Infinity" || name == "NaN"
+}
+
+func isModuleReferenceType(t *checker.Type) bool {
+	return t.Symbol() != nil && t.Symbol().Flags&ast.SymbolFlagsModule != 0
+}
+
+func identifierOrAccessExpressionPostfixMatchesParameterName(expr *ast.Node, parameterName string) bool {
+	if ast.IsIdentifier(expr) {
+		return expr.Text() == parameterName
+	}
+	if ast.IsPropertyAccessExpression(expr) {
+		return expr.Name().Text() == parameterName
+	}
+	return false
+}
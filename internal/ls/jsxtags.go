@@ -0,0 +1,138 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+// jsxTagWordPattern matches the text clients allow in linked JSX tag names.
+const jsxTagWordPattern = `[a-zA-Z0-9:\-\._$]*`
+
+// ProvideLinkedEditingRange returns the tag names of the opening and closing tags of the JSX element at a position,
+// so that editing one edits the other. For fragments, the empty ranges after `<` and `</` are returned when the
+// position is at one of them.
+func (l *LanguageService) ProvideLinkedEditingRange(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (lsproto.LinkedEditingRangeResponse, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	pos := int(l.converters.LineAndCharacterToPosition(file, position))
+	token := astnav.FindPrecedingToken(file, pos)
+	if token == nil || token.Parent == nil || token.Parent.Kind == ast.KindSourceFile {
+		return lsproto.LinkedEditingRangesOrNull{}, nil
+	}
+
+	result := func(openStart, openEnd, closeStart, closeEnd int) lsproto.LinkedEditingRangeResponse {
+		return lsproto.LinkedEditingRangesOrNull{
+			LinkedEditingRanges: &lsproto.LinkedEditingRanges{
+				Ranges: []lsproto.Range{
+					*l.createLspRangeFromBounds(openStart, openEnd, file),
+					*l.createLspRangeFromBounds(closeStart, closeEnd, file),
+				},
+				WordPattern: ptrTo(jsxTagWordPattern),
+			},
+		}
+	}
+
+	if token.Parent.Parent != nil && ast.IsJsxFragment(token.Parent.Parent) {
+		fragment := token.Parent.Parent.AsJsxFragment()
+		if containsParseError(fragment.OpeningFragment) || containsParseError(fragment.ClosingFragment) {
+			return lsproto.LinkedEditingRangesOrNull{}, nil
+		}
+		openPos := scanner.GetTokenPosOfNode(fragment.OpeningFragment, file, false /*includeJSDoc*/) + len("<")
+		closePos := scanner.GetTokenPosOfNode(fragment.ClosingFragment, file, false /*includeJSDoc*/) + len("</")
+		// Only link right after the brackets, i.e. `<|></|>`.
+		if pos != openPos && pos != closePos {
+			return lsproto.LinkedEditingRangesOrNull{}, nil
+		}
+		return result(openPos, openPos, closePos, closePos), nil
+	}
+
+	tag := ast.FindAncestor(token.Parent, func(node *ast.Node) bool {
+		return ast.IsJsxOpeningElement(node) || ast.IsJsxClosingElement(node)
+	})
+	if tag == nil || !ast.IsJsxElement(tag.Parent) {
+		return lsproto.LinkedEditingRangesOrNull{}, nil
+	}
+	element := tag.Parent.AsJsxElement()
+	openTag, closeTag := element.OpeningElement, element.ClosingElement
+	openTagName, closeTagName := openTag.TagName(), closeTag.TagName()
+	openTagNameStart := scanner.GetTokenPosOfNode(openTagName, file, false /*includeJSDoc*/)
+	closeTagNameStart := scanner.GetTokenPosOfNode(closeTagName, file, false /*includeJSDoc*/)
+	// Tags that are not well-formed are not linked.
+	if openTagNameStart == scanner.GetTokenPosOfNode(openTag, file, false /*includeJSDoc*/) ||
+		closeTagNameStart == scanner.GetTokenPosOfNode(closeTag, file, false /*includeJSDoc*/) ||
+		openTagName.End() == openTag.End() ||
+		closeTagName.End() == closeTag.End() {
+		return lsproto.LinkedEditingRangesOrNull{}, nil
+	}
+	if !(openTagNameStart <= pos && pos <= openTagName.End() || closeTagNameStart <= pos && pos <= closeTagName.End()) {
+		return lsproto.LinkedEditingRangesOrNull{}, nil
+	}
+	if scanner.GetSourceTextOfNodeFromSourceFile(file, openTagName, false /*includeTrivia*/) != scanner.GetSourceTextOfNodeFromSourceFile(file, closeTagName, false /*includeTrivia*/) {
+		return lsproto.LinkedEditingRangesOrNull{}, nil
+	}
+	return result(openTagNameStart, openTagName.End(), closeTagNameStart, closeTagName.End()), nil
+}
+
+// ProvideJsxClosingTag returns the closing tag to insert at a position right after the `>` of an opening JSX tag or
+// fragment, or in the text following it, if the element or fragment is not closed.
+func (l *LanguageService) ProvideJsxClosingTag(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (*lsproto.JsxClosingTag, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	token := astnav.FindPrecedingToken(file, int(l.converters.LineAndCharacterToPosition(file, position)))
+	if token == nil || token.Parent == nil {
+		return nil, nil
+	}
+
+	var element *ast.Node
+	if token.Kind == ast.KindGreaterThanToken && ast.IsJsxOpeningElement(token.Parent) {
+		element = token.Parent.Parent
+	} else if ast.IsJsxText(token) && ast.IsJsxElement(token.Parent) {
+		element = token.Parent
+	}
+	if element != nil && isUnclosedJsxElement(element) {
+		tagName := element.AsJsxElement().OpeningElement.TagName()
+		return &lsproto.JsxClosingTag{NewText: "</" + scanner.GetSourceTextOfNodeFromSourceFile(file, tagName, false /*includeTrivia*/) + ">"}, nil
+	}
+
+	var fragment *ast.Node
+	if token.Kind == ast.KindGreaterThanToken && ast.IsJsxOpeningFragment(token.Parent) {
+		fragment = token.Parent.Parent
+	} else if ast.IsJsxText(token) && ast.IsJsxFragment(token.Parent) {
+		fragment = token.Parent
+	}
+	if fragment != nil && isUnclosedJsxFragment(fragment) {
+		return &lsproto.JsxClosingTag{NewText: "</>"}, nil
+	}
+	return nil, nil
+}
+
+// isUnclosedJsxElement reports whether the closing tag the parser matched with an element belongs to another
+// element, either because the tag names differ or because the element is nested in an unclosed element of the same
+// name, which then took its closing tag.
+func isUnclosedJsxElement(node *ast.Node) bool {
+	element := node.AsJsxElement()
+	tagName := element.OpeningElement.TagName()
+	if !ast.TagNamesAreEquivalent(tagName, element.ClosingElement.TagName()) {
+		return true
+	}
+	return ast.IsJsxElement(node.Parent) && ast.TagNamesAreEquivalent(tagName, node.Parent.AsJsxElement().OpeningElement.TagName()) && isUnclosedJsxElement(node.Parent)
+}
+
+// isUnclosedJsxFragment is like isUnclosedJsxElement for fragments, whose missing closing fragments are reported by
+// the parser.
+func isUnclosedJsxFragment(node *ast.Node) bool {
+	if node.AsJsxFragment().ClosingFragment.Flags&ast.NodeFlagsThisNodeHasError != 0 {
+		return true
+	}
+	return ast.IsJsxFragment(node.Parent) && isUnclosedJsxFragment(node.Parent)
+}
+
+// containsParseError reports whether the parser reported an error for a node or any of its descendants.
+func containsParseError(node *ast.Node) bool {
+	if node.Flags&ast.NodeFlagsThisNodeHasError != 0 {
+		return true
+	}
+	return node.ForEachChild(containsParseError)
+}
@@ -0,0 +1,726 @@
+package ls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+const (
+	refactorKindMoveToNewFile lsproto.CodeActionKind = "refactor.move.newFile"
+	refactorKindMoveToFile    lsproto.CodeActionKind = "refactor.move.file"
+)
+
+// MoveToFileCommand is the command of the "Move to file" and "Move to a new file" code actions. For "Move to file",
+// the client is expected to ask the user for a destination and execute the command with MoveToFileArguments.TargetFile set.
+const MoveToFileCommand = "typescript.moveToFile"
+
+// MoveToFileArguments is the argument of MoveToFileCommand.
+type MoveToFileArguments struct {
+	TextDocument lsproto.TextDocumentIdentifier `json:"textDocument"`
+	Range        lsproto.Range                  `json:"range"`
+	// The file to move the selected statements to. It is created if it does not exist.
+	TargetFile lsproto.DocumentUri `json:"targetFile,omitzero"`
+}
+
+var (
+	ErrNoStatementsToMove = errors.New("no statements to move")
+	ErrInvalidMoveTarget  = errors.New("cannot move to file, selected file is invalid")
+)
+
+var moveToFileProvider = &refactorProvider{
+	kinds:              []lsproto.CodeActionKind{refactorKindMoveToNewFile, refactorKindMoveToFile},
+	getRefactorActions: getRefactorActionsToMoveToFile,
+}
+
+func getRefactorActionsToMoveToFile(ctx context.Context, refactorContext *refactorContext) []*refactorAction {
+	file := refactorContext.sourceFile
+	span := refactorContext.span
+	// Any position in a module is within some top-level statement, so only offer to move the statement at
+	// the cursor when asked to.
+	if span.Len() == 0 && !refactorContext.invoked {
+		return nil
+	}
+	toMove := getStatementsToMove(file, span)
+	if toMove == nil {
+		return nil
+	}
+	l := refactorContext.ls
+	program := refactorContext.program
+
+	// The edits are only computed when the command is executed, as they require checking the whole program.
+	textDocument := lsproto.TextDocumentIdentifier{Uri: FileNameToDocumentURI(file.FileName())}
+	lspRange := *l.createLspRangeFromBounds(span.Pos(), span.End(), file)
+	newFileName := getNewFileNameForMovedStatements(program, file, toMove)
+	return []*refactorAction{
+		newMoveToFileAction(refactorKindMoveToNewFile, diagnostics.Move_to_a_new_file.Message(), &MoveToFileArguments{
+			TextDocument: textDocument,
+			Range:        lspRange,
+			TargetFile:   FileNameToDocumentURI(newFileName),
+		}),
+		newMoveToFileAction(refactorKindMoveToFile, diagnostics.Move_to_file.Message(), &MoveToFileArguments{
+			TextDocument: textDocument,
+			Range:        lspRange,
+		}),
+	}
+}
+
+func newMoveToFileAction(kind lsproto.CodeActionKind, description string, arguments *MoveToFileArguments) *refactorAction {
+	commandArguments := []any{arguments}
+	return &refactorAction{
+		kind:        kind,
+		description: description,
+		command: &lsproto.Command{
+			Title:     description,
+			Command:   MoveToFileCommand,
+			Arguments: &commandArguments,
+		},
+	}
+}
+
+// GetMoveToFileEdits returns the edits that move the top-level statements in a range to another file,
+// creating the file if it does not exist.
+func (l *LanguageService) GetMoveToFileEdits(ctx context.Context, arguments *MoveToFileArguments) (*lsproto.WorkspaceEdit, error) {
+	program, file := l.getProgramAndFile(arguments.TextDocument.Uri)
+	toMove := getStatementsToMove(file, l.converters.FromLSPRange(file, arguments.Range))
+	if toMove == nil {
+		return nil, ErrNoStatementsToMove
+	}
+	if arguments.TargetFile == "" {
+		return nil, ErrInvalidMoveTarget
+	}
+	targetFileName := arguments.TargetFile.FileName()
+	targetFile := program.GetSourceFile(targetFileName)
+	if !isValidMoveTarget(program, file, targetFileName, targetFile) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidMoveTarget, targetFileName)
+	}
+	action := l.createRefactorAction(ctx, refactorKindMoveToFile, diagnostics.Move_to_file.Message(), func(ct *changeTracker) {
+		c, done := program.GetTypeChecker(ctx)
+		defer done()
+		doMoveToFile(ct, program, c, file, toMove, targetFileName, targetFile)
+	})
+	if action == nil {
+		return &lsproto.WorkspaceEdit{}, nil
+	}
+	return toLSPWorkspaceEditWithNewFiles(action.changes, action.newFiles), nil
+}
+
+// isValidMoveTarget reports whether statements of oldFile can be moved to targetFileName. targetFile is nil if
+// the target is not part of the program, in which case it is created and must not exist yet.
+func isValidMoveTarget(program *compiler.Program, oldFile *ast.SourceFile, targetFileName string, targetFile *ast.SourceFile) bool {
+	if targetFile == nil {
+		if program.FileExists(targetFileName) {
+			return false
+		}
+	} else if targetFile == oldFile || targetFile.IsDeclarationFile {
+		return false
+	}
+	// Keep TypeScript code in TypeScript files and JavaScript code in JavaScript files.
+	return !tspath.IsDeclarationFileName(targetFileName) &&
+		tspath.HasTSFileExtension(targetFileName) == tspath.HasTSFileExtension(oldFile.FileName()) &&
+		tspath.HasJSFileExtension(targetFileName) == tspath.HasJSFileExtension(oldFile.FileName())
+}
+
+// getStatementsToMove returns the top-level statements overlapping span, or nil if they cannot be moved.
+func getStatementsToMove(file *ast.SourceFile, span core.TextRange) []*ast.Statement {
+	if file.IsDeclarationFile || !ast.IsExternalModule(file) {
+		return nil
+	}
+	statements := file.Statements.Nodes
+	start := slices.IndexFunc(statements, func(statement *ast.Node) bool { return statement.End() > span.Pos() })
+	if start < 0 || span.End() < scanner.GetTokenPosOfNode(statements[start], file, true /*includeJSDoc*/) {
+		return nil
+	}
+	end := start
+	for end+1 < len(statements) && scanner.GetTokenPosOfNode(statements[end+1], file, false /*includeJSDoc*/) < span.End() {
+		end++
+	}
+	toMove := statements[start : end+1]
+	if !core.Every(toMove, isAllowedStatementToMove) {
+		return nil
+	}
+	return toMove
+}
+
+// isAllowedStatementToMove reports whether a statement can be moved to another module. Imports are recreated
+// in the destination as needed instead, and export lists and augmentations only make sense where they are.
+func isAllowedStatementToMove(statement *ast.Node) bool {
+	switch statement.Kind {
+	case ast.KindImportDeclaration, ast.KindExportDeclaration, ast.KindExportAssignment, ast.KindJSExportAssignment,
+		ast.KindNamespaceExportDeclaration:
+		return false
+	case ast.KindImportEqualsDeclaration:
+		return !ast.IsExternalModuleReference(statement.AsImportEqualsDeclaration().ModuleReference)
+	case ast.KindModuleDeclaration:
+		return ast.IsIdentifier(statement.Name()) && !ast.IsGlobalScopeAugmentation(statement)
+	}
+	return !ast.IsPrologueDirective(statement) && !ast.IsRequireVariableStatement(statement)
+}
+
+// getNewFileNameForMovedStatements names the new file after the first declaration being moved, next to the
+// old file and with the same extension.
+func getNewFileNameForMovedStatements(program *compiler.Program, oldFile *ast.SourceFile, toMove []*ast.Statement) string {
+	baseName := "newFile"
+	for _, statement := range toMove {
+		if name := getFirstTopLevelDeclarationName(statement); name != nil {
+			baseName = name.Text()
+			break
+		}
+	}
+	directory := tspath.GetDirectoryPath(oldFile.FileName())
+	extension := tspath.TryGetExtensionFromPath(oldFile.FileName())
+	fileName := tspath.CombinePaths(directory, baseName+extension)
+	for i := 1; program.FileExists(fileName) || program.GetSourceFile(fileName) != nil; i++ {
+		fileName = tspath.CombinePaths(directory, fmt.Sprintf("%s.%d%s", baseName, i, extension))
+	}
+	return fileName
+}
+
+// doMoveToFile moves statements of oldFile to targetFileName, which is created if targetFile is nil. Imports
+// the moved statements need are added to the target, declarations that stay behind are imported back into
+// the old file, and imports of the moved declarations in other files are pointed at the target.
+func doMoveToFile(ct *changeTracker, program *compiler.Program, c *checker.Checker, oldFile *ast.SourceFile, toMove []*ast.Statement, targetFileName string, targetFile *ast.SourceFile) {
+	usage := getMoveToFileUsage(c, oldFile, toMove)
+	options := program.Options()
+	// The file whose imports are consulted when computing module specifiers for the target.
+	importingFile := oldFile
+	if targetFile != nil {
+		importingFile = targetFile
+	}
+	isNameAvailableInTarget := func(name string) bool {
+		if targetFile == nil {
+			return true
+		}
+		_, ok := targetFile.Locals[name]
+		return !ok
+	}
+
+	// Declarations staying behind that the moved statements use must be exported from the old file.
+	var exportedStatements collections.Set[*ast.Node]
+	for _, name := range usage.oldDeclarationsUsedByMovedStatements {
+		statement := getTopLevelStatementOfDeclaration(name.Parent)
+		if !ast.HasSyntacticModifier(statement, ast.ModifierFlagsExport) && exportedStatements.AddIfAbsent(statement) {
+			ct.insertText(oldFile, ct.ls.converters.PositionToLineAndCharacter(oldFile, core.TextPos(scanner.GetTokenPosOfNode(statement, oldFile, false /*includeJSDoc*/))), "export ")
+		}
+	}
+
+	// Moved declarations that the old file still uses must be exported from the target.
+	var statementsToExport collections.Set[*ast.Node]
+	for _, name := range usage.movedDeclarationsUsedByOldFile {
+		if statement := getTopLevelStatementOfDeclaration(name.Parent); !ast.HasSyntacticModifier(statement, ast.ModifierFlagsExport) {
+			statementsToExport.Add(statement)
+		}
+	}
+	body := getTextOfMovedStatements(ct, oldFile, toMove, &statementsToExport)
+	ct.deleteNodeRange(oldFile, toMove[0], toMove[len(toMove)-1], leadingTriviaOptionIncludeAll, trailingTriviaOptionInclude)
+
+	// Imports of the old file that only the moved statements used are removed.
+	unusedImports := core.Filter(usage.importsUsedByMovedStatements, func(binding *ast.Node) bool {
+		return !usage.importsUsedByOldFile.Has(binding)
+	})
+	deletedOldImports := ct.deleteImportBindings(oldFile, unusedImports)
+	if len(usage.movedDeclarationsUsedByOldFile) > 0 {
+		moduleSpecifier := getModuleSpecifierForMove(program, oldFile, oldFile.FileName(), targetFileName, "")
+		importDeclaration := ct.makeImportOfDeclarations(usage.movedDeclarationsUsedByOldFile, moduleSpecifier, options)
+		ct.insertImportsAfterExistingImports(oldFile, []*ast.Statement{importDeclaration}, deletedOldImports)
+	}
+
+	deletedTargetImports := updateImportsOfMovedDeclarations(ct, program, c, oldFile, getMovedExportNames(toMove), targetFileName, targetFile)
+
+	var targetImports []*ast.Statement
+	for _, statement := range oldFile.Statements.Nodes {
+		bindings := core.Filter(usage.importsUsedByMovedStatements, func(binding *ast.Node) bool {
+			return getImportStatementOfBinding(binding) == statement && isNameAvailableInTarget(binding.Name().Text())
+		})
+		if len(bindings) == 0 {
+			continue
+		}
+		moduleSpecifier := getModuleSpecifierOfImport(statement)
+		var moduleFileName string
+		if moduleSymbol := c.GetSymbolAtLocation(moduleSpecifier); moduleSymbol != nil && moduleSymbol.ValueDeclaration != nil && ast.IsSourceFile(moduleSymbol.ValueDeclaration) {
+			moduleFileName = moduleSymbol.ValueDeclaration.AsSourceFile().FileName()
+		}
+		if moduleFileName == targetFileName {
+			// The bindings refer to declarations of the target itself.
+			continue
+		}
+		newModuleSpecifier := ct.NodeFactory.DeepCloneNode(moduleSpecifier)
+		if moduleFileName != "" && tspath.PathIsRelative(moduleSpecifier.Text()) && tspath.GetDirectoryPath(oldFile.FileName()) != tspath.GetDirectoryPath(targetFileName) {
+			if specifier := getModuleSpecifierForMove(program, importingFile, targetFileName, moduleFileName, moduleSpecifier.Text()); specifier != "" {
+				newModuleSpecifier = ct.NodeFactory.NewStringLiteral(specifier)
+			}
+		}
+		targetImports = append(targetImports, ct.makeImportOfBindings(statement, bindings, newModuleSpecifier))
+	}
+	if names := core.Filter(usage.oldDeclarationsUsedByMovedStatements, func(name *ast.Node) bool { return isNameAvailableInTarget(name.Text()) }); len(names) > 0 {
+		moduleSpecifier := getModuleSpecifierForMove(program, importingFile, targetFileName, oldFile.FileName(), "")
+		targetImports = append(targetImports, ct.makeImportOfDeclarations(names, moduleSpecifier, options))
+	}
+
+	if targetFile == nil {
+		ct.createNewFile(oldFile, targetFileName, targetImports, body)
+		return
+	}
+	ct.insertImportsAfterExistingImports(targetFile, targetImports, deletedTargetImports)
+	text := targetFile.Text()
+	prefix := ""
+	if len(text) > 0 {
+		prefix = ct.newLine
+		if !stringutil.IsLineBreak(rune(text[len(text)-1])) {
+			prefix += ct.newLine
+		}
+	}
+	ct.insertText(targetFile, ct.ls.converters.PositionToLineAndCharacter(targetFile, core.TextPos(len(text))), prefix+body)
+}
+
+// getTextOfMovedStatements copies the moved statements with their comments, adding an export modifier to the
+// given statements.
+func getTextOfMovedStatements(ct *changeTracker, oldFile *ast.SourceFile, toMove []*ast.Statement, statementsToExport *collections.Set[*ast.Node]) string {
+	text := oldFile.Text()
+	pos := ct.getAdjustedStartPosition(oldFile, toMove[0], leadingTriviaOptionIncludeAll, false)
+	end := ct.getAdjustedEndPosition(oldFile, toMove[len(toMove)-1], trailingTriviaOptionInclude)
+	var sb strings.Builder
+	for _, statement := range toMove {
+		if statementsToExport.Has(statement) {
+			exportPos := scanner.GetTokenPosOfNode(statement, oldFile, false /*includeJSDoc*/)
+			sb.WriteString(text[pos:exportPos])
+			sb.WriteString("export ")
+			pos = exportPos
+		}
+	}
+	sb.WriteString(text[pos:end])
+	if end == 0 || !stringutil.IsLineBreak(rune(text[end-1])) {
+		sb.WriteString(ct.newLine)
+	}
+	return sb.String()
+}
+
+// moveToFileUsage describes how the moved statements and the statements staying in the old file refer to
+// each other.
+type moveToFileUsage struct {
+	// Import clauses (for default imports), namespace imports, import specifiers and import equals
+	// declarations of the old file that the moved statements use, in order of first use.
+	importsUsedByMovedStatements []*ast.Node
+	// Imports of the old file that the statements staying behind use.
+	importsUsedByOldFile collections.Set[*ast.Node]
+	// Names of top-level declarations staying in the old file that the moved statements use.
+	oldDeclarationsUsedByMovedStatements []*ast.Node
+	// Names of moved declarations that the statements staying in the old file use.
+	movedDeclarationsUsedByOldFile []*ast.Node
+}
+
+func getMoveToFileUsage(c *checker.Checker, oldFile *ast.SourceFile, toMove []*ast.Statement) *moveToFileUsage {
+	usage := &moveToFileUsage{}
+	movedRange := core.NewTextRange(toMove[0].Pos(), toMove[len(toMove)-1].End())
+	var seen collections.Set[*ast.Node]
+	for _, statement := range toMove {
+		forEachReferencedSymbol(c, statement, func(symbol *ast.Symbol) {
+			if binding := getImportBindingOfSymbol(symbol, oldFile); binding != nil {
+				if seen.AddIfAbsent(binding) {
+					usage.importsUsedByMovedStatements = append(usage.importsUsedByMovedStatements, binding)
+				}
+			} else if name := getTopLevelDeclarationNameOfSymbol(symbol, oldFile); name != nil && !name.Loc.ContainedBy(movedRange) && seen.AddIfAbsent(name) {
+				usage.oldDeclarationsUsedByMovedStatements = append(usage.oldDeclarationsUsedByMovedStatements, name)
+			}
+		})
+	}
+	for _, statement := range oldFile.Statements.Nodes {
+		if statement.Loc.ContainedBy(movedRange) || isImportOfModule(statement) {
+			continue
+		}
+		forEachReferencedSymbol(c, statement, func(symbol *ast.Symbol) {
+			if binding := getImportBindingOfSymbol(symbol, oldFile); binding != nil {
+				usage.importsUsedByOldFile.Add(binding)
+			} else if name := getTopLevelDeclarationNameOfSymbol(symbol, oldFile); name != nil && name.Loc.ContainedBy(movedRange) && seen.AddIfAbsent(name) {
+				usage.movedDeclarationsUsedByOldFile = append(usage.movedDeclarationsUsedByOldFile, name)
+			}
+		})
+	}
+	return usage
+}
+
+// forEachReferencedSymbol calls cb with the symbol each identifier within node refers to.
+func forEachReferencedSymbol(c *checker.Checker, node *ast.Node, cb func(symbol *ast.Symbol)) {
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if !ast.IsIdentifier(node) {
+			return node.ForEachChild(visit)
+		}
+		var symbol *ast.Symbol
+		switch parent := node.Parent; {
+		case ast.IsShorthandPropertyAssignment(parent) && parent.Name() == node:
+			symbol = c.GetShorthandAssignmentValueSymbol(parent)
+		case ast.IsExportSpecifier(parent) && parent.Parent.Parent.ModuleSpecifier() == nil:
+			symbol = c.GetExportSpecifierLocalTargetSymbol(parent)
+		default:
+			symbol = c.GetSymbolAtLocation(node)
+		}
+		if symbol != nil {
+			cb(symbol)
+		}
+		return false
+	}
+	visit(node)
+}
+
+func isImportOfModule(statement *ast.Node) bool {
+	return ast.IsImportDeclaration(statement) ||
+		ast.IsImportEqualsDeclaration(statement) && ast.IsExternalModuleReference(statement.AsImportEqualsDeclaration().ModuleReference)
+}
+
+// getImportBindingOfSymbol returns the import clause, namespace import, import specifier or import equals
+// declaration of file that declares an alias symbol.
+func getImportBindingOfSymbol(symbol *ast.Symbol, file *ast.SourceFile) *ast.Node {
+	if symbol.Flags&ast.SymbolFlagsAlias == 0 {
+		return nil
+	}
+	for _, declaration := range symbol.Declarations {
+		if ast.GetSourceFileOfNode(declaration) != file {
+			continue
+		}
+		switch declaration.Kind {
+		case ast.KindImportClause, ast.KindNamespaceImport, ast.KindImportSpecifier:
+			return declaration
+		case ast.KindImportEqualsDeclaration:
+			if isImportOfModule(declaration) {
+				return declaration
+			}
+		}
+	}
+	return nil
+}
+
+func getImportStatementOfBinding(binding *ast.Node) *ast.Node {
+	return ast.FindAncestor(binding, isImportOfModule)
+}
+
+func getModuleSpecifierOfImport(statement *ast.Node) *ast.Node {
+	if ast.IsImportEqualsDeclaration(statement) {
+		return statement.AsImportEqualsDeclaration().ModuleReference.Expression()
+	}
+	return statement.ModuleSpecifier()
+}
+
+// getTopLevelDeclarationNameOfSymbol returns the name of the declaration of a symbol in a top-level statement
+// of file.
+func getTopLevelDeclarationNameOfSymbol(symbol *ast.Symbol, file *ast.SourceFile) *ast.Node {
+	for _, declaration := range symbol.Declarations {
+		if ast.GetSourceFileOfNode(declaration) != file || getTopLevelStatementOfDeclaration(declaration) == nil {
+			continue
+		}
+		if name := declaration.Name(); name != nil && ast.IsIdentifier(name) {
+			return name
+		}
+	}
+	return nil
+}
+
+// getTopLevelStatementOfDeclaration returns the top-level statement that a declaration is part of, or nil if
+// the declaration is nested in another declaration or block.
+func getTopLevelStatementOfDeclaration(declaration *ast.Node) *ast.Node {
+	statement := declaration
+	if ast.IsVariableDeclaration(declaration) || ast.IsBindingElement(declaration) {
+		root := ast.GetRootDeclaration(declaration)
+		if !ast.IsVariableDeclaration(root) || !ast.IsVariableStatement(root.Parent.Parent) {
+			return nil
+		}
+		statement = root.Parent.Parent
+	}
+	if statement.Parent == nil || !ast.IsSourceFile(statement.Parent) {
+		return nil
+	}
+	return statement
+}
+
+// forEachTopLevelDeclarationName calls cb with the name of each declaration in a top-level statement.
+func forEachTopLevelDeclarationName(statement *ast.Node, cb func(name *ast.Node)) {
+	switch statement.Kind {
+	case ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration,
+		ast.KindEnumDeclaration, ast.KindModuleDeclaration, ast.KindImportEqualsDeclaration:
+		if name := statement.Name(); name != nil && ast.IsIdentifier(name) {
+			cb(name)
+		}
+	case ast.KindVariableStatement:
+		for _, declaration := range statement.AsVariableStatement().DeclarationList.AsVariableDeclarationList().Declarations.Nodes {
+			forEachBindingIdentifier(declaration.Name(), cb)
+		}
+	}
+}
+
+func getFirstTopLevelDeclarationName(statement *ast.Node) *ast.Node {
+	var first *ast.Node
+	forEachTopLevelDeclarationName(statement, func(name *ast.Node) {
+		if first == nil {
+			first = name
+		}
+	})
+	return first
+}
+
+// getMovedExportNames returns the names under which the moved statements are exported from the old file.
+func getMovedExportNames(toMove []*ast.Statement) *collections.Set[string] {
+	names := &collections.Set[string]{}
+	for _, statement := range toMove {
+		if !ast.HasSyntacticModifier(statement, ast.ModifierFlagsExport) {
+			continue
+		}
+		if ast.HasSyntacticModifier(statement, ast.ModifierFlagsDefault) {
+			names.Add(ast.InternalSymbolNameDefault)
+			continue
+		}
+		forEachTopLevelDeclarationName(statement, func(name *ast.Node) {
+			names.Add(name.Text())
+		})
+	}
+	return names
+}
+
+func getModuleSpecifierForMove(program *compiler.Program, importingFile *ast.SourceFile, importingFileName string, toFileName string, oldSpecifier string) string {
+	return modulespecifiers.GetModuleSpecifier(program.Options(), program, importingFile, importingFileName, oldSpecifier, toFileName, modulespecifiers.ModuleSpecifierOptions{})
+}
+
+// makeImportOfDeclarations creates an import of the given top-level declarations, under their own names,
+// from the module they are exported from.
+func (ct *changeTracker) makeImportOfDeclarations(names []*ast.Node, moduleSpecifier string, options *core.CompilerOptions) *ast.Statement {
+	var defaultImport *ast.Node
+	var namedImports []*ast.Node
+	for _, name := range names {
+		statement := getTopLevelStatementOfDeclaration(name.Parent)
+		if ast.HasSyntacticModifier(statement, ast.ModifierFlagsDefault) {
+			defaultImport = ct.NodeFactory.NewIdentifier(name.Text())
+			continue
+		}
+		isTypeOnly := options.VerbatimModuleSyntax.IsTrue() && (ast.IsInterfaceDeclaration(statement) || ast.IsTypeAliasDeclaration(statement))
+		namedImports = append(namedImports, ct.NodeFactory.NewImportSpecifier(isTypeOnly, nil /*propertyName*/, ct.NodeFactory.NewIdentifier(name.Text())))
+	}
+	return ct.makeImport(defaultImport, namedImports, ct.NodeFactory.NewStringLiteral(moduleSpecifier), false /*isTypeOnly*/)
+}
+
+// makeImportOfBindings creates an import with some of the bindings of an existing import statement.
+func (ct *changeTracker) makeImportOfBindings(statement *ast.Node, bindings []*ast.Node, moduleSpecifier *ast.Node) *ast.Statement {
+	factory := ct.NodeFactory
+	if ast.IsImportEqualsDeclaration(statement) {
+		importEquals := statement.AsImportEqualsDeclaration()
+		return factory.NewImportEqualsDeclaration(nil /*modifiers*/, importEquals.IsTypeOnly, factory.DeepCloneNode(importEquals.Name()), factory.NewExternalModuleReference(moduleSpecifier))
+	}
+	var name, namedBindings *ast.Node
+	var specifiers []*ast.Node
+	for _, binding := range slices.SortedFunc(slices.Values(bindings), func(a, b *ast.Node) int { return a.Pos() - b.Pos() }) {
+		switch binding.Kind {
+		case ast.KindImportClause:
+			name = factory.DeepCloneNode(binding.Name())
+		case ast.KindNamespaceImport:
+			namedBindings = factory.DeepCloneNode(binding)
+		case ast.KindImportSpecifier:
+			specifiers = append(specifiers, factory.DeepCloneNode(binding))
+		}
+	}
+	if len(specifiers) > 0 {
+		namedBindings = factory.NewNamedImports(factory.NewNodeList(specifiers))
+	}
+	importDeclaration := statement.AsImportDeclaration()
+	var attributes *ast.Node
+	if importDeclaration.Attributes != nil {
+		attributes = factory.DeepCloneNode(importDeclaration.Attributes)
+	}
+	importClause := factory.NewImportClause(importDeclaration.ImportClause.AsImportClause().PhaseModifier, name, namedBindings)
+	return factory.NewImportDeclaration(nil /*modifiers*/, importClause, moduleSpecifier, attributes)
+}
+
+// deleteImportBindings removes bindings from the import statements of file, deleting statements that are left
+// without bindings. Returns the deleted statements.
+func (ct *changeTracker) deleteImportBindings(file *ast.SourceFile, bindings []*ast.Node) *collections.Set[*ast.Node] {
+	deleted := &collections.Set[*ast.Node]{}
+	for _, statement := range file.Statements.Nodes {
+		if !core.Some(bindings, func(binding *ast.Node) bool { return getImportStatementOfBinding(binding) == statement }) {
+			continue
+		}
+		if ast.IsImportDeclaration(statement) {
+			clause := statement.AsImportDeclaration().ImportClause
+			var name, namedBindings *ast.Node
+			if clause.Name() != nil && !slices.Contains(bindings, clause) {
+				name = ct.NodeFactory.DeepCloneNode(clause.Name())
+			}
+			if existing := clause.AsImportClause().NamedBindings; existing != nil && !slices.Contains(bindings, existing) {
+				if ast.IsNamespaceImport(existing) {
+					namedBindings = ct.NodeFactory.DeepCloneNode(existing)
+				} else if kept := core.Filter(existing.Elements(), func(element *ast.Node) bool { return !slices.Contains(bindings, element) }); len(kept) > 0 {
+					namedBindings = ct.NodeFactory.NewNamedImports(ct.NodeFactory.NewNodeList(core.Map(kept, ct.NodeFactory.DeepCloneNode)))
+				}
+			}
+			if name != nil || namedBindings != nil {
+				ct.replaceNode(file, clause, ct.NodeFactory.NewImportClause(clause.AsImportClause().PhaseModifier, name, namedBindings), nil)
+				continue
+			}
+		}
+		ct.deleteNodeRange(file, statement, statement, leadingTriviaOptionIncludeAll, trailingTriviaOptionInclude)
+		deleted.Add(statement)
+	}
+	return deleted
+}
+
+// insertImportsAfterExistingImports inserts import statements after the last import of file that is not
+// being deleted, or at the top of the file if there is none.
+func (ct *changeTracker) insertImportsAfterExistingImports(file *ast.SourceFile, imports []*ast.Statement, deletedStatements *collections.Set[*ast.Node]) {
+	if len(imports) == 0 {
+		return
+	}
+	var lastImport *ast.Node
+	for _, statement := range file.Statements.Nodes {
+		if ast.IsAnyImportSyntax(statement) && !deletedStatements.Has(statement) {
+			lastImport = statement
+		}
+	}
+	if lastImport != nil {
+		ct.insertNodesAfter(file, lastImport, imports)
+	} else {
+		ct.insertAtTopOfFile(file, imports, true /*blankLineBetween*/)
+	}
+}
+
+// updateImportsOfMovedDeclarations points imports and re-exports of the moved declarations in the other files
+// of the program at the target file. Imports in the target file itself are removed, since the declarations
+// become local to it. Returns the import statements deleted from the target file.
+func updateImportsOfMovedDeclarations(ct *changeTracker, program *compiler.Program, c *checker.Checker, oldFile *ast.SourceFile, movedExportNames *collections.Set[string], targetFileName string, targetFile *ast.SourceFile) *collections.Set[*ast.Node] {
+	deletedTargetImports := &collections.Set[*ast.Node]{}
+	if movedExportNames.Len() == 0 {
+		return deletedTargetImports
+	}
+	for _, reference := range findModuleReferences(program, program.GetSourceFiles(), c.GetMergedSymbol(oldFile.Symbol), c) {
+		file := reference.referencingFile
+		if reference.kind != ModuleReferenceKindImport || file == oldFile {
+			continue
+		}
+		getModuleSpecifier := func() *ast.Node {
+			return ct.NodeFactory.NewStringLiteral(getModuleSpecifierForMove(program, file, file.FileName(), targetFileName, reference.literal.Text()))
+		}
+		// !!! require calls, dynamic imports and import types of the old file are left unchanged
+		switch statement := reference.literal.Parent; {
+		case ast.IsImportDeclaration(statement) && statement.AsImportDeclaration().ImportClause != nil:
+			clause := statement.AsImportDeclaration().ImportClause
+			namedBindings := clause.AsImportClause().NamedBindings
+			if namedBindings != nil && ast.IsNamespaceImport(namedBindings) {
+				if clause.Name() == nil && file != targetFile {
+					updateNamespaceImportOfMovedDeclarations(ct, c, file, statement, movedExportNames, getModuleSpecifier, program.Options().GetEmitScriptTarget())
+				}
+				continue
+			}
+			var moved []*ast.Node
+			bindingCount := 0
+			if clause.Name() != nil {
+				bindingCount++
+				if movedExportNames.Has(ast.InternalSymbolNameDefault) {
+					moved = append(moved, clause)
+				}
+			}
+			if namedBindings != nil {
+				for _, specifier := range namedBindings.Elements() {
+					bindingCount++
+					if movedExportNames.Has(specifier.PropertyNameOrName().Text()) {
+						moved = append(moved, specifier)
+					}
+				}
+			}
+			switch {
+			case len(moved) == 0:
+			case file == targetFile:
+				for statement := range ct.deleteImportBindings(file, moved).Keys() {
+					deletedTargetImports.Add(statement)
+				}
+			case len(moved) == bindingCount:
+				ct.replaceNode(file, reference.literal, getModuleSpecifier(), nil)
+			default:
+				ct.deleteImportBindings(file, moved)
+				ct.insertNodeAfter(file, statement, ct.makeImportOfBindings(statement, moved, getModuleSpecifier()))
+			}
+		case ast.IsExportDeclaration(statement):
+			exportDeclaration := statement.AsExportDeclaration()
+			if exportDeclaration.ExportClause == nil {
+				// `export *` does not re-export default exports.
+				if movedExportNames.Len() > 1 || !movedExportNames.Has(ast.InternalSymbolNameDefault) {
+					ct.insertNodeAfter(file, statement, ct.NodeFactory.NewExportDeclaration(nil /*modifiers*/, exportDeclaration.IsTypeOnly, nil /*exportClause*/, getModuleSpecifier(), nil /*attributes*/))
+				}
+				continue
+			}
+			if !ast.IsNamedExports(exportDeclaration.ExportClause) {
+				continue
+			}
+			elements := exportDeclaration.ExportClause.Elements()
+			moved := core.Filter(elements, func(element *ast.Node) bool { return movedExportNames.Has(element.PropertyNameOrName().Text()) })
+			switch {
+			case len(moved) == 0:
+			case len(moved) == len(elements):
+				ct.replaceNode(file, reference.literal, getModuleSpecifier(), nil)
+			default:
+				kept := core.Filter(elements, func(element *ast.Node) bool { return !slices.Contains(moved, element) })
+				ct.replaceNode(file, exportDeclaration.ExportClause, ct.NodeFactory.NewNamedExports(ct.NodeFactory.NewNodeList(core.Map(kept, ct.NodeFactory.DeepCloneNode))), nil)
+				ct.insertNodeAfter(file, statement, ct.NodeFactory.NewExportDeclaration(nil /*modifiers*/, exportDeclaration.IsTypeOnly, ct.NodeFactory.NewNamedExports(ct.NodeFactory.NewNodeList(core.Map(moved, ct.NodeFactory.DeepCloneNode))), getModuleSpecifier(), nil /*attributes*/))
+			}
+		}
+	}
+	return deletedTargetImports
+}
+
+// updateNamespaceImportOfMovedDeclarations updates uses of moved declarations through a namespace import of the
+// old file. If nothing else is used through the namespace, the import itself is pointed at the target;
+// otherwise a namespace import of the target is added for the moved declarations.
+func updateNamespaceImportOfMovedDeclarations(ct *changeTracker, c *checker.Checker, file *ast.SourceFile, statement *ast.Node, movedExportNames *collections.Set[string], getModuleSpecifier func() *ast.Node, target core.ScriptTarget) {
+	clause := statement.AsImportDeclaration().ImportClause
+	namespaceName := clause.AsImportClause().NamedBindings.Name()
+	namespaceSymbol := c.GetSymbolAtLocation(namespaceName)
+	if namespaceSymbol == nil {
+		return
+	}
+	var movedUses []*ast.Node
+	otherUses := 0
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if !ast.IsIdentifier(node) || node.Text() != namespaceName.Text() {
+			return node.ForEachChild(visit)
+		}
+		if c.GetSymbolAtLocation(node) != namespaceSymbol {
+			return false
+		}
+		switch parent := node.Parent; {
+		case ast.IsPropertyAccessExpression(parent) && parent.Expression() == node && movedExportNames.Has(parent.Name().Text()),
+			ast.IsQualifiedName(parent) && parent.AsQualifiedName().Left == node && movedExportNames.Has(parent.AsQualifiedName().Right.Text()):
+			movedUses = append(movedUses, node)
+		default:
+			otherUses++
+		}
+		return false
+	}
+	for _, other := range file.Statements.Nodes {
+		if other != statement {
+			visit(other)
+		}
+	}
+	if len(movedUses) == 0 {
+		return
+	}
+	moduleSpecifier := getModuleSpecifier()
+	if otherUses == 0 {
+		ct.replaceNode(file, statement.ModuleSpecifier(), moduleSpecifier, nil)
+		return
+	}
+	newName := getUniqueName(moduleSpecifierToValidIdentifier(moduleSpecifier.Text(), target, false /*forceCapitalize*/), file)
+	namespaceImport := ct.NodeFactory.NewNamespaceImport(ct.NodeFactory.NewIdentifier(newName))
+	ct.insertNodeAfter(file, statement, ct.NodeFactory.NewImportDeclaration(nil /*modifiers*/, ct.NodeFactory.NewImportClause(clause.AsImportClause().PhaseModifier, nil /*name*/, namespaceImport), moduleSpecifier, nil /*attributes*/))
+	for _, use := range movedUses {
+		ct.replaceNode(file, use, ct.NodeFactory.NewIdentifier(newName), nil)
+	}
+}
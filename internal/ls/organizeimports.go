@@ -2,13 +2,25 @@ package ls
 
 import (
 	"cmp"
+	"context"
+	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
 	"github.com/microsoft/typescript-go/internal/compiler"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/format"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
 	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"github.com/microsoft/typescript-go/internal/printer"
+	"github.com/microsoft/typescript-go/internal/scanner"
+	"github.com/microsoft/typescript-go/internal/stringutil"
 	"github.com/microsoft/typescript-go/internal/tspath"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
 )
 
 // statement = anyImportOrRequireStatement
@@ -126,3 +138,780 @@ func isIndexFileName(fileName string) bool {
 	}
 	return fileName == "index"
 }
+
+// OrganizeImportsMode selects what organizing the imports of a file does.
+type OrganizeImportsMode int
+
+const (
+	// OrganizeImportsModeAll removes unused imports, then coalesces and sorts imports and exports.
+	OrganizeImportsModeAll OrganizeImportsMode = iota
+	// OrganizeImportsModeSortAndCombine coalesces and sorts imports and exports without removing any.
+	OrganizeImportsModeSortAndCombine
+	// OrganizeImportsModeRemoveUnused only removes unused imports.
+	OrganizeImportsModeRemoveUnused
+)
+
+const (
+	CodeActionKindSourceSortImports         lsproto.CodeActionKind = "source.sortImports"
+	CodeActionKindSourceRemoveUnusedImports lsproto.CodeActionKind = "source.removeUnusedImports"
+)
+
+// OrganizeImports returns the edits that organize the imports and exports of a file. Declarations are only
+// moved within groups that are not separated by blank lines. Comments on their own lines before a declaration,
+// and on the line it ends on, move with it; the comments before the first declaration of a group stay in place,
+// since they are probably the header of the file.
+func (l *LanguageService) OrganizeImports(ctx context.Context, uri lsproto.DocumentUri, mode OrganizeImportsMode, preferences *UserPreferences) map[string][]*lsproto.TextEdit {
+	program, file := l.getProgramAndFile(uri)
+	ct := l.newChangeTracker(ctx)
+	o := &importOrganizer{
+		ct:            ct,
+		program:       program,
+		file:          file,
+		preferences:   preferences,
+		shouldSort:    mode == OrganizeImportsModeSortAndCombine || mode == OrganizeImportsModeAll,
+		shouldCombine: mode == OrganizeImportsModeSortAndCombine || mode == OrganizeImportsModeAll,
+		shouldRemove:  mode == OrganizeImportsModeRemoveUnused || mode == OrganizeImportsModeAll,
+	}
+	if o.shouldRemove {
+		c, done := program.GetTypeCheckerForFile(ctx, file)
+		defer done()
+		o.checker = c
+	}
+
+	topLevelImportGroups := groupByNewlineContiguous(file, core.Filter(file.Statements.Nodes, ast.IsImportDeclaration))
+	ignoreCase := preferences.OrganizeImportsIgnoreCase.IsTrue()
+	if preferences.OrganizeImportsIgnoreCase.IsUnknown() && o.shouldSort {
+		ignoreCase = detectModuleSpecifierCaseInsensitivity(topLevelImportGroups, getOrganizeImportsStringComparer(ctx, preferences, true /*ignoreCase*/), getOrganizeImportsStringComparer(ctx, preferences, false /*ignoreCase*/))
+	}
+	o.compareStrings = getOrganizeImportsStringComparer(ctx, preferences, ignoreCase)
+
+	for _, group := range topLevelImportGroups {
+		o.organizeImports(group)
+	}
+	// Exports are always used.
+	if mode != OrganizeImportsModeRemoveUnused {
+		for _, group := range getTopLevelExportGroups(file) {
+			o.organizeExports(group)
+		}
+	}
+	for _, statement := range file.Statements.Nodes {
+		if !ast.IsAmbientModule(statement) || statement.Body() == nil || !ast.IsModuleBlock(statement.Body()) {
+			continue
+		}
+		statements := statement.Body().AsModuleBlock().Statements.Nodes
+		for _, group := range groupByNewlineContiguous(file, core.Filter(statements, ast.IsImportDeclaration)) {
+			o.organizeImports(group)
+		}
+		if mode != OrganizeImportsModeRemoveUnused {
+			o.organizeExports(core.Filter(statements, ast.IsExportDeclaration))
+		}
+	}
+	return ct.getChanges()
+}
+
+// importOrganizer organizes the import and export declarations of a file.
+type importOrganizer struct {
+	ct          *changeTracker
+	program     *compiler.Program
+	file        *ast.SourceFile
+	checker     *checker.Checker
+	preferences *UserPreferences
+
+	shouldSort    bool
+	shouldCombine bool
+	shouldRemove  bool
+
+	// compareStrings orders module specifiers and the names of imports and exports.
+	compareStrings func(a, b string) int
+}
+
+// organizedDeclaration describes an import or export declaration being organized. The bindings are nodes of
+// the original declarations; a declaration is only printed anew if they differ from the bindings of the
+// declaration it is based on.
+type organizedDeclaration struct {
+	// declaration is the original declaration this one is based on. Its comments, module specifier and
+	// attributes are kept.
+	declaration *ast.Node
+	// name is the default import.
+	name *ast.Node
+	// namespace is the namespace import or export.
+	namespace *ast.Node
+	// elements are the import or export specifiers between braces, if hasElements is set.
+	elements    []*ast.Node
+	hasElements bool
+	multiLine   bool
+}
+
+func newOrganizedDeclaration(file *ast.SourceFile, declaration *ast.Node) *organizedDeclaration {
+	d := &organizedDeclaration{declaration: declaration}
+	var bindings *ast.Node
+	if ast.IsImportDeclaration(declaration) {
+		if clause := declaration.AsImportDeclaration().ImportClause; clause != nil {
+			d.name = clause.Name()
+			bindings = clause.AsImportClause().NamedBindings
+		}
+	} else {
+		bindings = declaration.AsExportDeclaration().ExportClause
+	}
+	switch {
+	case bindings == nil:
+	case ast.IsNamespaceImport(bindings) || ast.IsNamespaceExport(bindings):
+		d.namespace = bindings
+	default:
+		d.elements = bindings.Elements()
+		d.hasElements = true
+		d.multiLine = !positionsAreOnSameLine(file, scanner.GetTokenPosOfNode(bindings, file, false /*includeJSDoc*/), bindings.End())
+	}
+	return d
+}
+
+// withBindings returns a declaration based on the same original declaration with other bindings.
+func (d *organizedDeclaration) withBindings(name *ast.Node, namespace *ast.Node, elements []*ast.Node, hasElements bool) *organizedDeclaration {
+	return &organizedDeclaration{
+		declaration: d.declaration,
+		name:        name,
+		namespace:   namespace,
+		elements:    elements,
+		hasElements: hasElements,
+		multiLine:   d.multiLine,
+	}
+}
+
+func (d *organizedDeclaration) isTypeOnly() bool {
+	if ast.IsImportDeclaration(d.declaration) {
+		clause := d.declaration.AsImportDeclaration().ImportClause
+		return clause != nil && clause.IsTypeOnly()
+	}
+	return d.declaration.IsTypeOnly()
+}
+
+// hasBindings reports whether the declaration imports or exports any names, as opposed to `import "m"` and
+// `export * from "m"`.
+func (d *organizedDeclaration) hasBindings() bool {
+	return d.name != nil || d.namespace != nil || d.hasElements
+}
+
+func (d *organizedDeclaration) moduleSpecifier() *ast.Node {
+	return ast.GetExternalModuleName(d.declaration)
+}
+
+func (d *organizedDeclaration) isUnchanged(file *ast.SourceFile) bool {
+	original := newOrganizedDeclaration(file, d.declaration)
+	return d.name == original.name &&
+		d.namespace == original.namespace &&
+		d.hasElements == original.hasElements &&
+		slices.Equal(d.elements, original.elements) &&
+		!core.Some(d.elements, isRedundantlyRenamedSpecifier)
+}
+
+// isRedundantlyRenamedSpecifier reports whether a specifier is of the form `a as a`.
+func isRedundantlyRenamedSpecifier(specifier *ast.Node) bool {
+	propertyName := specifier.PropertyName()
+	return propertyName != nil && propertyName.Text() == specifier.Name().Text()
+}
+
+func (o *importOrganizer) organizeImports(declarations []*ast.Node) {
+	imports := core.Map(declarations, func(declaration *ast.Node) *organizedDeclaration {
+		return newOrganizedDeclaration(o.file, declaration)
+	})
+	o.replaceDeclarations(declarations, o.organizeDeclarations(imports, func(group []*organizedDeclaration) []*organizedDeclaration {
+		if o.shouldRemove {
+			group = o.removeUnusedImports(group)
+		}
+		if o.shouldCombine {
+			group = o.coalesceImports(group)
+		}
+		if o.shouldSort {
+			slices.SortStableFunc(group, o.compareImports)
+		}
+		return group
+	}))
+}
+
+func (o *importOrganizer) organizeExports(declarations []*ast.Node) {
+	exports := core.Map(declarations, func(declaration *ast.Node) *organizedDeclaration {
+		return newOrganizedDeclaration(o.file, declaration)
+	})
+	o.replaceDeclarations(declarations, o.organizeDeclarations(exports, o.coalesceExports))
+}
+
+// organizeDeclarations groups declarations by module specifier, if they are combined, sorts the groups and
+// processes the declarations of each group.
+func (o *importOrganizer) organizeDeclarations(declarations []*organizedDeclaration, process func(group []*organizedDeclaration) []*organizedDeclaration) []*organizedDeclaration {
+	if len(declarations) == 0 {
+		return nil
+	}
+	groups := [][]*organizedDeclaration{declarations}
+	if o.shouldCombine {
+		groups = groupByModuleSpecifier(declarations)
+	}
+	if o.shouldSort {
+		slices.SortStableFunc(groups, func(a, b []*organizedDeclaration) int {
+			return o.compareModuleSpecifiers(a[0].moduleSpecifier(), b[0].moduleSpecifier())
+		})
+	}
+	var result []*organizedDeclaration
+	for _, group := range groups {
+		if specifier := group[0].moduleSpecifier(); specifier != nil && !ast.IsStringLiteralLike(specifier) {
+			result = append(result, group...)
+			continue
+		}
+		result = append(result, process(group)...)
+	}
+	return result
+}
+
+// groupByModuleSpecifier groups declarations with the same module specifier, in the order of their first
+// declarations. Declarations without a module specifier form one group.
+func groupByModuleSpecifier(declarations []*organizedDeclaration) [][]*organizedDeclaration {
+	var groups [][]*organizedDeclaration
+	groupIndexes := make(map[string]int)
+	for _, d := range declarations {
+		key := "local"
+		if specifier := d.moduleSpecifier(); specifier != nil {
+			if !ast.IsStringLiteralLike(specifier) {
+				groups = append(groups, []*organizedDeclaration{d})
+				continue
+			}
+			key = "module:" + specifier.Text()
+		}
+		if index, ok := groupIndexes[key]; ok {
+			groups[index] = append(groups[index], d)
+		} else {
+			groupIndexes[key] = len(groups)
+			groups = append(groups, []*organizedDeclaration{d})
+		}
+	}
+	return groups
+}
+
+func (o *importOrganizer) removeUnusedImports(imports []*organizedDeclaration) []*organizedDeclaration {
+	jsxElementsPresent := o.file.AsNode().SubtreeFacts()&ast.SubtreeContainsJsx != 0 && jsxModeNeedsExplicitImport(o.program.Options().Jsx)
+	jsxNamespace := o.checker.GetJsxNamespace(o.file.AsNode())
+	jsxFragmentFactory := o.checker.GetJsxFragmentFactory(o.file.AsNode())
+	isDeclarationUsed := func(identifier *ast.Node) bool {
+		// The JSX factory symbol is always used if JSX elements are present - even if they are not allowed.
+		if jsxElementsPresent && (identifier.Text() == jsxNamespace || jsxFragmentFactory != "" && identifier.Text() == jsxFragmentFactory) {
+			return true
+		}
+		return isSymbolReferencedInFile(identifier, o.checker, o.file)
+	}
+
+	var usedImports []*organizedDeclaration
+	for _, d := range imports {
+		if !d.hasBindings() {
+			// Imports without bindings are assumed to be included for their side effects and are not removed.
+			usedImports = append(usedImports, d)
+			continue
+		}
+		name := d.name
+		if name != nil && !isDeclarationUsed(name) {
+			name = nil
+		}
+		namespace := d.namespace
+		if namespace != nil && !isDeclarationUsed(namespace.Name()) {
+			namespace = nil
+		}
+		elements, hasElements := d.elements, d.hasElements
+		if hasElements {
+			elements = core.Filter(elements, func(specifier *ast.Node) bool { return isDeclarationUsed(specifier.Name()) })
+			hasElements = len(elements) > 0 || len(d.elements) == 0
+		}
+		if name != nil || namespace != nil || hasElements {
+			usedImports = append(usedImports, d.withBindings(name, namespace, elements, hasElements))
+		} else if hasModuleDeclarationMatchingSpecifier(o.file, d.moduleSpecifier()) {
+			// A module that is imported to be augmented is used. Removing the bindings outside of declaration
+			// files would make the import look like it has side effects, so that it is preserved in the JS emit.
+			if o.file.IsDeclarationFile {
+				usedImports = append(usedImports, d.withBindings(nil, nil, nil, false))
+			} else {
+				usedImports = append(usedImports, d)
+			}
+		}
+	}
+	return usedImports
+}
+
+func jsxModeNeedsExplicitImport(jsx core.JsxEmit) bool {
+	switch jsx {
+	case core.JsxEmitPreserve, core.JsxEmitReactNative, core.JsxEmitReact:
+		return true
+	}
+	return false
+}
+
+func hasModuleDeclarationMatchingSpecifier(file *ast.SourceFile, moduleSpecifier *ast.Node) bool {
+	if moduleSpecifier == nil || !ast.IsStringLiteral(moduleSpecifier) {
+		return false
+	}
+	return core.Some(file.ModuleAugmentations, func(moduleName *ast.Node) bool {
+		return ast.IsStringLiteral(moduleName) && moduleName.Text() == moduleSpecifier.Text()
+	})
+}
+
+// coalesceImports combines imports of the same module. Default and namespace imports are kept separate,
+// unless a module only has one of each, and type-only imports are kept apart from the others.
+func (o *importOrganizer) coalesceImports(imports []*organizedDeclaration) []*organizedDeclaration {
+	var coalesced []*organizedDeclaration
+	for _, sameAttributes := range groupByImportAttributes(imports) {
+		type importsOfKind struct {
+			defaultImports   []*organizedDeclaration
+			namespaceImports []*organizedDeclaration
+			namedImports     []*organizedDeclaration
+		}
+		var importWithoutBindings *organizedDeclaration
+		var regularImports, typeOnlyImports importsOfKind
+		for _, d := range sameAttributes {
+			if !d.hasBindings() {
+				// Only the first such import is interesting - the others are redundant.
+				if importWithoutBindings == nil {
+					importWithoutBindings = d
+				}
+				continue
+			}
+			group := core.IfElse(d.isTypeOnly(), &typeOnlyImports, &regularImports)
+			if d.name != nil {
+				group.defaultImports = append(group.defaultImports, d)
+			}
+			if d.namespace != nil {
+				group.namespaceImports = append(group.namespaceImports, d)
+			} else if d.hasElements {
+				group.namedImports = append(group.namedImports, d)
+			}
+		}
+		if importWithoutBindings != nil {
+			coalesced = append(coalesced, importWithoutBindings)
+		}
+
+		for _, group := range []*importsOfKind{&regularImports, &typeOnlyImports} {
+			isTypeOnly := group == &typeOnlyImports
+			// Normally, we don't combine default and namespace imports, but it would be silly to
+			// produce two import declarations in this special case.
+			if !isTypeOnly && len(group.defaultImports) == 1 && len(group.namespaceImports) == 1 && len(group.namedImports) == 0 {
+				defaultImport := group.defaultImports[0]
+				coalesced = append(coalesced, defaultImport.withBindings(defaultImport.name, group.namespaceImports[0].namespace, nil, false))
+				continue
+			}
+
+			namespaceImports := slices.Clone(group.namespaceImports)
+			slices.SortStableFunc(namespaceImports, func(a, b *organizedDeclaration) int {
+				return o.compareStrings(a.namespace.Name().Text(), b.namespace.Name().Text())
+			})
+			for _, namespaceImport := range namespaceImports {
+				coalesced = append(coalesced, namespaceImport.withBindings(nil, namespaceImport.namespace, nil, false))
+			}
+
+			if len(group.defaultImports) == 0 && len(group.namedImports) == 0 {
+				continue
+			}
+			var newDefaultImport *ast.Node
+			var newElements []*ast.Node
+			if len(group.defaultImports) == 1 {
+				newDefaultImport = group.defaultImports[0].name
+			} else {
+				for _, defaultImport := range group.defaultImports {
+					newElements = append(newElements, o.ct.NodeFactory.NewImportSpecifier(false /*isTypeOnly*/, o.ct.NodeFactory.NewIdentifier("default"), o.ct.NodeFactory.DeepCloneNode(defaultImport.name)))
+				}
+			}
+			for _, namedImport := range group.namedImports {
+				newElements = append(newElements, namedImport.elements...)
+			}
+			slices.SortStableFunc(newElements, o.compareSpecifiers)
+
+			var firstNamedImport *organizedDeclaration
+			if len(group.namedImports) > 0 {
+				firstNamedImport = group.namedImports[0]
+			}
+			importDeclaration := firstNamedImport
+			if len(group.defaultImports) > 0 {
+				importDeclaration = group.defaultImports[0]
+			}
+			hasElements := len(newElements) > 0 || newDefaultImport == nil
+			// Type-only imports are not allowed to mix default, namespace, and named imports in any combination.
+			// We could rewrite a default import as a named import (`import { default as name }`), but we currently
+			// choose not to as a stylistic preference.
+			if isTypeOnly && newDefaultImport != nil && hasElements {
+				coalesced = append(coalesced, importDeclaration.withBindings(newDefaultImport, nil, nil, false))
+				importDeclaration = core.OrElse(firstNamedImport, importDeclaration)
+				newDefaultImport = nil
+			}
+			newImport := importDeclaration.withBindings(newDefaultImport, nil, newElements, hasElements)
+			if firstNamedImport != nil {
+				newImport.multiLine = firstNamedImport.multiLine
+			}
+			coalesced = append(coalesced, newImport)
+		}
+	}
+	return coalesced
+}
+
+// groupByImportAttributes groups imports with the same attributes, in the order of their first imports.
+func groupByImportAttributes(imports []*organizedDeclaration) [][]*organizedDeclaration {
+	var groups [][]*organizedDeclaration
+	groupIndexes := make(map[string]int)
+	for _, d := range imports {
+		var key strings.Builder
+		if attributes := d.declaration.AsImportDeclaration().Attributes; attributes != nil {
+			key.WriteString(scanner.TokenToString(attributes.AsImportAttributes().Token))
+			elements := slices.Clone(attributes.AsImportAttributes().Attributes.Nodes)
+			slices.SortFunc(elements, func(a, b *ast.Node) int { return strings.Compare(a.Name().Text(), b.Name().Text()) })
+			for _, element := range elements {
+				key.WriteString(" " + element.Name().Text() + ":")
+				if value := element.AsImportAttribute().Value; ast.IsStringLiteralLike(value) {
+					key.WriteString(strconv.Quote(value.Text()))
+				} else {
+					key.WriteString(scanner.GetTextOfNode(value))
+				}
+			}
+		}
+		if index, ok := groupIndexes[key.String()]; ok {
+			groups[index] = append(groups[index], d)
+		} else {
+			groupIndexes[key.String()] = len(groups)
+			groups = append(groups, []*organizedDeclaration{d})
+		}
+	}
+	return groups
+}
+
+// coalesceExports combines the named exports of the same module, or the local named exports. Type-only exports
+// are kept apart from the others.
+func (o *importOrganizer) coalesceExports(exports []*organizedDeclaration) []*organizedDeclaration {
+	var exportWithoutBindings *organizedDeclaration
+	var namespaceExports, namedExports, typeOnlyExports []*organizedDeclaration
+	for _, d := range exports {
+		switch {
+		case !d.hasBindings():
+			// Only the first such export is interesting - the others are redundant.
+			if exportWithoutBindings == nil {
+				exportWithoutBindings = d
+			}
+		case d.namespace != nil:
+			namespaceExports = append(namespaceExports, d)
+		case d.isTypeOnly():
+			typeOnlyExports = append(typeOnlyExports, d)
+		default:
+			namedExports = append(namedExports, d)
+		}
+	}
+
+	var coalesced []*organizedDeclaration
+	if exportWithoutBindings != nil {
+		coalesced = append(coalesced, exportWithoutBindings)
+	}
+	coalesced = append(coalesced, namespaceExports...)
+	for _, group := range [][]*organizedDeclaration{namedExports, typeOnlyExports} {
+		if len(group) == 0 {
+			continue
+		}
+		var newElements []*ast.Node
+		for _, d := range group {
+			newElements = append(newElements, d.elements...)
+		}
+		slices.SortStableFunc(newElements, o.compareSpecifiers)
+		coalesced = append(coalesced, group[0].withBindings(nil, nil, newElements, true))
+	}
+	return coalesced
+}
+
+func (o *importOrganizer) compareImports(a, b *organizedDeclaration) int {
+	if c := o.compareModuleSpecifiers(a.moduleSpecifier(), b.moduleSpecifier()); c != 0 {
+		return c
+	}
+	return cmp.Compare(getImportKindOrder(a), getImportKindOrder(b))
+}
+
+func getImportKindOrder(d *organizedDeclaration) int {
+	switch {
+	case !d.hasBindings():
+		return 0
+	case d.isTypeOnly():
+		return 1
+	case d.namespace != nil:
+		return 2
+	case d.name != nil:
+		return 3
+	}
+	return 4
+}
+
+// compareModuleSpecifiers sorts modules that are not relative before relative modules, and declarations
+// without a module specifier last.
+func (o *importOrganizer) compareModuleSpecifiers(a, b *ast.Node) int {
+	aIsString := a != nil && ast.IsStringLiteralLike(a)
+	bIsString := b != nil && ast.IsStringLiteralLike(b)
+	if c := compareBooleans(aIsString, bIsString); c != 0 || !aIsString {
+		return c
+	}
+	if c := compareBooleans(!tspath.IsExternalModuleNameRelative(a.Text()), !tspath.IsExternalModuleNameRelative(b.Text())); c != 0 {
+		return c
+	}
+	return o.compareStrings(a.Text(), b.Text())
+}
+
+func (o *importOrganizer) compareSpecifiers(a, b *ast.Node) int {
+	switch o.preferences.OrganizeImportsTypeOrder {
+	case OrganizeImportsTypeOrderFirst:
+		if c := compareBooleans(a.IsTypeOnly(), b.IsTypeOnly()); c != 0 {
+			return c
+		}
+	case OrganizeImportsTypeOrderLast:
+		if c := compareBooleans(!a.IsTypeOnly(), !b.IsTypeOnly()); c != 0 {
+			return c
+		}
+	}
+	return o.compareStrings(a.Name().Text(), b.Name().Text())
+}
+
+// getOrganizeImportsStringComparer returns the comparer of module specifiers and names selected by the
+// collation preferences.
+func getOrganizeImportsStringComparer(ctx context.Context, preferences *UserPreferences, ignoreCase bool) func(a, b string) int {
+	if preferences.OrganizeImportsCollation != OrganizeImportsCollationUnicode {
+		return stringutil.GetStringComparer(ignoreCase)
+	}
+
+	locale := preferences.OrganizeImportsLocale
+	if locale == "auto" {
+		locale = core.GetLocale(ctx).String()
+	}
+	tag, err := language.Parse(locale)
+	if err != nil || tag == language.Und {
+		tag = language.English
+	}
+	var options []collate.Option
+	if ignoreCase {
+		options = append(options, collate.IgnoreCase)
+	}
+	if preferences.OrganizeImportsAccentCollation == OrganizeImportsAccentCollationFalse {
+		options = append(options, collate.IgnoreDiacritics)
+	}
+	if preferences.OrganizeImportsNumericCollation {
+		options = append(options, collate.Numeric)
+	}
+	collator := collate.New(tag, options...)
+	if ignoreCase || preferences.OrganizeImportsCaseFirst == OrganizeImportsCaseFirstFalse {
+		return collator.CompareString
+	}
+
+	// The collator has no option for the case that sorts first, so strings that only differ in case are
+	// ordered by the first letter that differs.
+	caseInsensitiveCollator := collate.New(tag, append(options, collate.IgnoreCase)...)
+	upperFirst := preferences.OrganizeImportsCaseFirst == OrganizeImportsCaseFirstUpper
+	return func(a, b string) int {
+		if c := caseInsensitiveCollator.CompareString(a, b); c != 0 {
+			return c
+		}
+		for ra, rb := []rune(a), []rune(b); len(ra) > 0 && len(rb) > 0; ra, rb = ra[1:], rb[1:] {
+			if ra[0] != rb[0] && unicode.ToLower(ra[0]) == unicode.ToLower(rb[0]) {
+				return core.IfElse(unicode.IsUpper(ra[0]) == upperFirst, -1, 1)
+			}
+		}
+		return collator.CompareString(a, b)
+	}
+}
+
+// detectModuleSpecifierCaseInsensitivity reports whether the module specifiers of the import groups are sorted
+// at least as well case-insensitively as they are case-sensitively.
+func detectModuleSpecifierCaseInsensitivity(groups [][]*ast.Node, caseInsensitive func(a, b string) int, caseSensitive func(a, b string) int) bool {
+	countUnsorted := func(compare func(a, b string) int) int {
+		count := 0
+		for _, group := range groups {
+			moduleNames := core.Map(group, func(declaration *ast.Node) string {
+				if specifier := ast.GetExternalModuleName(declaration); specifier != nil && ast.IsStringLiteralLike(specifier) {
+					return specifier.Text()
+				}
+				return ""
+			})
+			sorted := slices.Clone(moduleNames)
+			slices.SortStableFunc(sorted, compare)
+			for i := range moduleNames {
+				if moduleNames[i] != sorted[i] {
+					count++
+				}
+			}
+		}
+		return count
+	}
+	return countUnsorted(caseInsensitive) <= countUnsorted(caseSensitive)
+}
+
+// groupByNewlineContiguous splits declarations into groups that are separated by blank lines.
+func groupByNewlineContiguous(file *ast.SourceFile, declarations []*ast.Node) [][]*ast.Node {
+	var groups [][]*ast.Node
+	for _, declaration := range declarations {
+		if len(groups) == 0 || isNewImportGroup(file, declaration) {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], declaration)
+	}
+	return groups
+}
+
+// isNewImportGroup reports whether a blank line precedes a declaration. Comments on their own lines belong to
+// the declaration that follows them, so only a blank line counts, not the line breaks around comments.
+func isNewImportGroup(file *ast.SourceFile, declaration *ast.Node) bool {
+	text := file.Text()
+	start := scanner.GetTokenPosOfNode(declaration, file, false /*includeJSDoc*/)
+	newLines := 0
+	for pos := declaration.Pos(); pos < start; pos++ {
+		switch {
+		case strings.HasPrefix(text[pos:], "//"):
+			pos += strings.IndexAny(text[pos:], "\r\n") - 1
+			newLines = 0
+		case strings.HasPrefix(text[pos:], "/*"):
+			pos += strings.Index(text[pos:], "*/") + 1
+			newLines = 0
+		case text[pos] == '\n' || text[pos] == '\r' && !strings.HasPrefix(text[pos:], "\r\n"):
+			newLines++
+			if newLines >= 2 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getTopLevelExportGroups returns the groups of export declarations to organize. Re-exports are grouped by
+// blank lines, and each run of local exports forms a group of its own.
+func getTopLevelExportGroups(file *ast.SourceFile) [][]*ast.Node {
+	var exportGroups [][]*ast.Node
+	var current []*ast.Node
+	statements := file.Statements.Nodes
+	for i := 0; i < len(statements); {
+		if !ast.IsExportDeclaration(statements[i]) {
+			i++
+			continue
+		}
+		if statements[i].AsExportDeclaration().ModuleSpecifier != nil {
+			current = append(current, statements[i])
+			i++
+			continue
+		}
+		for i < len(statements) && ast.IsExportDeclaration(statements[i]) {
+			current = append(current, statements[i])
+			i++
+		}
+		exportGroups = append(exportGroups, current)
+		current = nil
+	}
+	if current != nil {
+		exportGroups = append(exportGroups, current)
+	}
+	var result [][]*ast.Node
+	for _, group := range exportGroups {
+		result = append(result, groupByNewlineContiguous(file, group)...)
+	}
+	return result
+}
+
+// replaceDeclarations replaces a group of declarations with the organized ones, which are inserted where the
+// first declaration was. Other statements between the declarations follow them.
+func (o *importOrganizer) replaceDeclarations(oldDeclarations []*ast.Node, newDeclarations []*organizedDeclaration) {
+	if len(oldDeclarations) == 0 {
+		return
+	}
+	text := o.file.Text()
+	ranges := make([]core.TextRange, len(oldDeclarations))
+	for i, declaration := range oldDeclarations {
+		var start int
+		if i == 0 {
+			// Leave the header comment of the file in place.
+			start = o.ct.getAdjustedStartPosition(o.file, declaration, leadingTriviaOptionExclude, false /*hasTrailingComment*/)
+		} else {
+			start = max(o.ct.getAdjustedStartPosition(o.file, declaration, leadingTriviaOptionIncludeAll, false /*hasTrailingComment*/), ranges[i-1].End())
+		}
+		ranges[i] = core.NewTextRange(start, o.ct.getAdjustedEndPosition(o.file, declaration, trailingTriviaOptionInclude))
+	}
+
+	start, end := ranges[0].Pos(), ranges[len(ranges)-1].End()
+	indentation := text[format.GetLineStartPositionForPosition(start, o.file):start]
+	if strings.TrimSpace(indentation) != "" {
+		indentation = ""
+	}
+	var pieces []string
+	for _, d := range newDeclarations {
+		index := slices.Index(oldDeclarations, d.declaration)
+		pieces = append(pieces, o.getTextOfDeclaration(d, ranges[index], indentation))
+	}
+	for i := 1; i < len(ranges); i++ {
+		if between := strings.TrimSpace(text[ranges[i-1].End():ranges[i].Pos()]); between != "" {
+			pieces = append(pieces, between)
+		}
+	}
+	newText := strings.Join(pieces, o.ct.newLine+indentation)
+	if len(pieces) > 0 && stringutil.IsLineBreak(rune(text[end-1])) {
+		newText += o.ct.newLine
+	}
+	if newText != text[start:end] {
+		o.ct.replaceRangeWithText(o.file, *o.ct.ls.createLspRangeFromBounds(start, end, o.file), newText)
+	}
+}
+
+// getTextOfDeclaration returns the text of an organized declaration with the comments of its original
+// declaration. The original text is kept if the bindings did not change.
+func (o *importOrganizer) getTextOfDeclaration(d *organizedDeclaration, originalRange core.TextRange, indentation string) string {
+	text := o.file.Text()
+	start := scanner.GetTokenPosOfNode(d.declaration, o.file, false /*includeJSDoc*/)
+	leading := strings.TrimLeft(text[originalRange.Pos():start], " \t")
+	trailing := text[d.declaration.End():originalRange.End()]
+	var declarationText string
+	if d.isUnchanged(o.file) {
+		declarationText = text[start:d.declaration.End()]
+	} else {
+		declarationText = o.ct.getFormattedTextOfNode(o.makeDeclaration(d), o.file, o.file, start, changeNodeOptions{indentation: ptrTo(0), delta: ptrTo(0)})
+		declarationText = strings.ReplaceAll(strings.TrimRightFunc(declarationText, unicode.IsSpace), o.ct.newLine, o.ct.newLine+indentation)
+	}
+	return strings.TrimRightFunc(leading+declarationText+trailing, unicode.IsSpace)
+}
+
+// makeDeclaration creates a new import or export declaration with the bindings of an organized declaration.
+func (o *importOrganizer) makeDeclaration(d *organizedDeclaration) *ast.Node {
+	factory := o.ct.NodeFactory
+	clone := func(node *ast.Node) *ast.Node {
+		if node == nil {
+			return nil
+		}
+		return factory.DeepCloneNode(node)
+	}
+	elements := core.Map(d.elements, func(specifier *ast.Node) *ast.Node {
+		if !isRedundantlyRenamedSpecifier(specifier) {
+			return clone(specifier)
+		}
+		if ast.IsImportSpecifier(specifier) {
+			return factory.NewImportSpecifier(specifier.IsTypeOnly(), nil /*propertyName*/, clone(specifier.Name()))
+		}
+		return factory.NewExportSpecifier(specifier.IsTypeOnly(), nil /*propertyName*/, clone(specifier.Name()))
+	})
+	var moduleSpecifier *ast.Node
+	if specifier := d.moduleSpecifier(); specifier != nil && ast.IsStringLiteral(specifier) {
+		moduleSpecifier = o.ct.makeStringLiteral(specifier.Text(), quotePreferenceFromString(specifier, o.file))
+	} else {
+		moduleSpecifier = clone(specifier)
+	}
+
+	if ast.IsImportDeclaration(d.declaration) {
+		importDeclaration := d.declaration.AsImportDeclaration()
+		namedBindings := clone(d.namespace)
+		if d.hasElements {
+			namedBindings = factory.NewNamedImports(factory.NewNodeList(elements))
+			if d.multiLine {
+				o.ct.AddEmitFlags(namedBindings, printer.EFMultiLine)
+			}
+		}
+		var importClause *ast.Node
+		if d.name != nil || namedBindings != nil {
+			importClause = factory.NewImportClause(importDeclaration.ImportClause.AsImportClause().PhaseModifier, clone(d.name), namedBindings)
+		}
+		return factory.NewImportDeclaration(nil /*modifiers*/, importClause, moduleSpecifier, clone(importDeclaration.Attributes))
+	}
+
+	exportDeclaration := d.declaration.AsExportDeclaration()
+	exportClause := clone(d.namespace)
+	if d.hasElements {
+		exportClause = factory.NewNamedExports(factory.NewNodeList(elements))
+		if d.multiLine {
+			o.ct.AddEmitFlags(exportClause, printer.EFMultiLine)
+		}
+	}
+	return factory.NewExportDeclaration(nil /*modifiers*/, exportDeclaration.IsTypeOnly, exportClause, moduleSpecifier, clone(exportDeclaration.Attributes))
+}
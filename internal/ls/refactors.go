@@ -0,0 +1,82 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+)
+
+// refactorProvider describes a refactoring offered for a selected range, independent of any diagnostic.
+type refactorProvider struct {
+	// Kinds of the code actions this provider may return. Used to skip providers the client did not ask for.
+	kinds              []lsproto.CodeActionKind
+	getRefactorActions func(ctx context.Context, refactorContext *refactorContext) []*refactorAction
+}
+
+type refactorContext struct {
+	ls          *LanguageService
+	program     *compiler.Program
+	sourceFile  *ast.SourceFile
+	span        core.TextRange
+	preferences *UserPreferences
+	// Whether the user asked for code actions, rather than the client asking on its own, e.g. when the
+	// cursor moves. Some refactorings are only offered for an empty selection when invoked.
+	invoked bool
+}
+
+type refactorAction struct {
+	kind        lsproto.CodeActionKind
+	description string
+	// Text changes to apply to each file as part of the refactoring
+	changes map[string][]*lsproto.TextEdit
+	// Text of the files the refactoring creates, keyed by file name
+	newFiles map[string]string
+	// If present, the client runs this command instead of applying changes, e.g. to ask the user for input
+	command *lsproto.Command
+}
+
+// refactorProviders is the registry of all refactorings known to the language service.
+var refactorProviders = []*refactorProvider{
+	extractSymbolProvider,
+	extractTypeProvider,
+	moveToFileProvider,
+	inferReturnTypeProvider,
+}
+
+func (l *LanguageService) getApplicableRefactors(ctx context.Context, refactorContext *refactorContext, only *[]lsproto.CodeActionKind) []*refactorAction {
+	var actions []*refactorAction
+	for _, provider := range refactorProviders {
+		if ctx.Err() != nil {
+			return nil
+		}
+		if !core.Some(provider.kinds, func(kind lsproto.CodeActionKind) bool { return codeActionKindIsRequested(only, kind) }) {
+			continue
+		}
+		for _, action := range provider.getRefactorActions(ctx, refactorContext) {
+			if codeActionKindIsRequested(only, action.kind) {
+				actions = append(actions, action)
+			}
+		}
+	}
+	return actions
+}
+
+// createRefactorAction runs a change function against a fresh change tracker and packages the result.
+// Returns nil when the change function produced no edits.
+func (l *LanguageService) createRefactorAction(ctx context.Context, kind lsproto.CodeActionKind, description string, fn func(ct *changeTracker)) *refactorAction {
+	tracker := l.newChangeTracker(ctx)
+	fn(tracker)
+	changes := tracker.getChanges()
+	if len(changes) == 0 && len(tracker.newFiles) == 0 {
+		return nil
+	}
+	return &refactorAction{
+		kind:        kind,
+		description: description,
+		changes:     changes,
+		newFiles:    tracker.newFiles,
+	}
+}
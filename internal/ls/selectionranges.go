@@ -0,0 +1,114 @@
+package ls
+
+import (
+	"context"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+func (l *LanguageService) ProvideSelectionRanges(ctx context.Context, params *lsproto.SelectionRangeParams) (lsproto.SelectionRangeResponse, error) {
+	_, file := l.getProgramAndFile(params.TextDocument.Uri)
+	selectionRanges := make([]*lsproto.SelectionRange, 0, len(params.Positions))
+	for _, position := range params.Positions {
+		if ctx.Err() != nil {
+			return lsproto.SelectionRangesOrNull{}, ctx.Err()
+		}
+		selectionRanges = append(selectionRanges, l.getSmartSelectionRange(file, int(l.converters.LineAndCharacterToPosition(file, position))))
+	}
+	return lsproto.SelectionRangesOrNull{SelectionRanges: &selectionRanges}, nil
+}
+
+// getSmartSelectionRange returns the innermost selection range at pos, linked through its parents to a
+// range spanning the whole file. Each enclosing node contributes a range, as do the contents of string
+// and template literals and the contents of bracketed lists whose brackets are on different lines.
+func (l *LanguageService) getSmartSelectionRange(file *ast.SourceFile, pos int) *lsproto.SelectionRange {
+	var spans []core.TextRange
+	pushSelectionRange := func(start int, end int) {
+		// Skip empty ranges, ranges not containing pos, and ranges identical to the previous one.
+		if start == end || pos < start || pos > end {
+			return
+		}
+		span := core.NewTextRange(start, end)
+		if len(spans) == 0 || spans[len(spans)-1] != span {
+			spans = append(spans, span)
+		}
+	}
+
+	pushSelectionRange(0, file.End())
+
+	var ancestors []*ast.Node
+	for node := astnav.GetTokenAtPosition(file, pos); node != nil && node.Kind != ast.KindSourceFile; node = node.Parent {
+		ancestors = append(ancestors, node)
+	}
+	slices.Reverse(ancestors)
+
+	for _, node := range ancestors {
+		start := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/)
+		end := node.End()
+		// Select a declaration together with its JSDoc before selecting the declaration alone.
+		if jsDocs := node.JSDoc(file); len(jsDocs) > 0 {
+			pushSelectionRange(scanner.GetTokenPosOfNode(jsDocs[0], file, false /*includeJSDoc*/), end)
+		}
+		pushSelectionRange(start, end)
+
+		switch node.Kind {
+		case ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral, ast.KindTemplateExpression:
+			// Select the contents of a literal without its quotes.
+			pushSelectionRange(start+1, end-1)
+		default:
+			if openKind, closeKind, ok := getSelectionListBookends(node); ok {
+				pushSelectionRangeBetweenBookends(node, file, openKind, closeKind, pushSelectionRange)
+			}
+		}
+	}
+
+	var selectionRange *lsproto.SelectionRange
+	for _, span := range spans {
+		selectionRange = &lsproto.SelectionRange{
+			Range:  *l.createLspRangeFromBounds(span.Pos(), span.End(), file),
+			Parent: selectionRange,
+		}
+	}
+	return selectionRange
+}
+
+// getSelectionListBookends returns the tokens that open and close the list of children of node.
+func getSelectionListBookends(node *ast.Node) (openKind ast.Kind, closeKind ast.Kind, ok bool) {
+	switch node.Kind {
+	case ast.KindBlock, ast.KindModuleBlock, ast.KindCaseBlock, ast.KindObjectLiteralExpression,
+		ast.KindClassDeclaration, ast.KindClassExpression, ast.KindInterfaceDeclaration, ast.KindTypeLiteral,
+		ast.KindEnumDeclaration, ast.KindNamedImports, ast.KindNamedExports, ast.KindObjectBindingPattern:
+		return ast.KindOpenBraceToken, ast.KindCloseBraceToken, true
+	case ast.KindArrayLiteralExpression, ast.KindArrayBindingPattern, ast.KindTupleType:
+		return ast.KindOpenBracketToken, ast.KindCloseBracketToken, true
+	case ast.KindCallExpression, ast.KindNewExpression:
+		return ast.KindOpenParenToken, ast.KindCloseParenToken, true
+	}
+	if ast.IsFunctionLike(node) {
+		return ast.KindOpenParenToken, ast.KindCloseParenToken, true
+	}
+	return ast.KindUnknown, ast.KindUnknown, false
+}
+
+// pushSelectionRangeBetweenBookends selects the contents of a bracketed list, including surrounding
+// whitespace but not the brackets themselves, when the brackets are on separate lines.
+func pushSelectionRangeBetweenBookends(node *ast.Node, file *ast.SourceFile, openKind ast.Kind, closeKind ast.Kind, pushSelectionRange func(start int, end int)) {
+	openToken := findChildOfKind(node, openKind, file)
+	if openToken == nil {
+		return
+	}
+	closeToken := findChildOfKind(node, closeKind, file)
+	if closeToken == nil {
+		return
+	}
+	closeStart := scanner.GetTokenPosOfNode(closeToken, file, false /*includeJSDoc*/)
+	if positionsAreOnSameLine(file, openToken.End(), closeStart) {
+		return
+	}
+	pushSelectionRange(openToken.End(), closeStart)
+}
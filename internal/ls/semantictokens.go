@@ -0,0 +1,349 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+type semanticTokenType uint32
+
+const (
+	semanticTokenTypeClass semanticTokenType = iota
+	semanticTokenTypeEnum
+	semanticTokenTypeInterface
+	semanticTokenTypeNamespace
+	semanticTokenTypeTypeParameter
+	semanticTokenTypeType
+	semanticTokenTypeParameter
+	semanticTokenTypeVariable
+	semanticTokenTypeEnumMember
+	semanticTokenTypeProperty
+	semanticTokenTypeFunction
+	semanticTokenTypeMethod
+)
+
+type semanticTokenModifier uint32
+
+const (
+	semanticTokenModifierDeclaration semanticTokenModifier = 1 << iota
+	semanticTokenModifierStatic
+	semanticTokenModifierAsync
+	semanticTokenModifierReadonly
+	semanticTokenModifierDefaultLibrary
+	semanticTokenModifierLocal
+)
+
+// SemanticTokensLegend is the legend advertised in the server capabilities. Token types and
+// modifiers are listed in the same order as the semanticTokenType and semanticTokenModifier constants.
+var SemanticTokensLegend = lsproto.SemanticTokensLegend{
+	TokenTypes: []string{
+		string(lsproto.SemanticTokenTypesclass),
+		string(lsproto.SemanticTokenTypesenum),
+		string(lsproto.SemanticTokenTypesinterface),
+		string(lsproto.SemanticTokenTypesnamespace),
+		string(lsproto.SemanticTokenTypestypeParameter),
+		string(lsproto.SemanticTokenTypestype),
+		string(lsproto.SemanticTokenTypesparameter),
+		string(lsproto.SemanticTokenTypesvariable),
+		string(lsproto.SemanticTokenTypesenumMember),
+		string(lsproto.SemanticTokenTypesproperty),
+		string(lsproto.SemanticTokenTypesfunction),
+		string(lsproto.SemanticTokenTypesmethod),
+	},
+	TokenModifiers: []string{
+		string(lsproto.SemanticTokenModifiersdeclaration),
+		string(lsproto.SemanticTokenModifiersstatic),
+		string(lsproto.SemanticTokenModifiersasync),
+		string(lsproto.SemanticTokenModifiersreadonly),
+		string(lsproto.SemanticTokenModifiersdefaultLibrary),
+		"local",
+	},
+}
+
+var semanticTokenTypeFromDeclarationKind = map[ast.Kind]semanticTokenType{
+	ast.KindVariableDeclaration:         semanticTokenTypeVariable,
+	ast.KindParameter:                   semanticTokenTypeParameter,
+	ast.KindPropertyDeclaration:         semanticTokenTypeProperty,
+	ast.KindModuleDeclaration:           semanticTokenTypeNamespace,
+	ast.KindEnumDeclaration:             semanticTokenTypeEnum,
+	ast.KindEnumMember:                  semanticTokenTypeEnumMember,
+	ast.KindClassDeclaration:            semanticTokenTypeClass,
+	ast.KindMethodDeclaration:           semanticTokenTypeMethod,
+	ast.KindFunctionDeclaration:         semanticTokenTypeFunction,
+	ast.KindFunctionExpression:          semanticTokenTypeFunction,
+	ast.KindMethodSignature:             semanticTokenTypeMethod,
+	ast.KindGetAccessor:                 semanticTokenTypeProperty,
+	ast.KindSetAccessor:                 semanticTokenTypeProperty,
+	ast.KindPropertySignature:           semanticTokenTypeProperty,
+	ast.KindInterfaceDeclaration:        semanticTokenTypeInterface,
+	ast.KindTypeAliasDeclaration:        semanticTokenTypeType,
+	ast.KindTypeParameter:               semanticTokenTypeTypeParameter,
+	ast.KindPropertyAssignment:          semanticTokenTypeProperty,
+	ast.KindShorthandPropertyAssignment: semanticTokenTypeProperty,
+}
+
+type semanticTokensState struct {
+	ctx          context.Context
+	program      *compiler.Program
+	checker      *checker.Checker
+	sourceFile   *ast.SourceFile
+	span         core.TextRange
+	inJsxElement bool
+	tokens       []semanticToken
+}
+
+type semanticToken struct {
+	node        *ast.Node
+	tokenType   semanticTokenType
+	modifierSet semanticTokenModifier
+}
+
+func (l *LanguageService) ProvideSemanticTokens(ctx context.Context, documentURI lsproto.DocumentUri) (lsproto.SemanticTokensResponse, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	return l.provideSemanticTokensInSpan(ctx, documentURI, core.NewTextRange(0, file.End()))
+}
+
+func (l *LanguageService) ProvideSemanticTokensRange(ctx context.Context, documentURI lsproto.DocumentUri, lspRange lsproto.Range) (lsproto.SemanticTokensRangeResponse, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	return l.provideSemanticTokensInSpan(ctx, documentURI, l.converters.FromLSPRange(file, lspRange))
+}
+
+func (l *LanguageService) provideSemanticTokensInSpan(ctx context.Context, documentURI lsproto.DocumentUri, span core.TextRange) (lsproto.SemanticTokensOrNull, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+	state := &semanticTokensState{
+		ctx:        ctx,
+		program:    program,
+		checker:    c,
+		sourceFile: file,
+		span:       span,
+	}
+	state.visit(file.AsNode())
+	if ctx.Err() != nil {
+		return lsproto.SemanticTokensOrNull{}, ctx.Err()
+	}
+	return lsproto.SemanticTokensOrNull{
+		SemanticTokens: &lsproto.SemanticTokens{Data: l.encodeSemanticTokens(file, state.tokens)},
+	}, nil
+}
+
+// encodeSemanticTokens converts tokens, which must be sorted by position, to the relative
+// five-integer encoding described by the LSP specification.
+func (l *LanguageService) encodeSemanticTokens(file *ast.SourceFile, tokens []semanticToken) []uint32 {
+	data := make([]uint32, 0, len(tokens)*5)
+	var prevLine, prevCharacter uint32
+	for _, token := range tokens {
+		start := l.createLspPosition(scanner.GetTokenPosOfNode(token.node, file, false /*includeJSDoc*/), file)
+		end := l.createLspPosition(token.node.End(), file)
+		deltaLine := start.Line - prevLine
+		deltaCharacter := start.Character
+		if deltaLine == 0 {
+			deltaCharacter -= prevCharacter
+		}
+		data = append(data, deltaLine, deltaCharacter, end.Character-start.Character, uint32(token.tokenType), uint32(token.modifierSet))
+		prevLine, prevCharacter = start.Line, start.Character
+	}
+	return data
+}
+
+func (s *semanticTokensState) visit(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindModuleDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindFunctionDeclaration,
+		ast.KindClassExpression, ast.KindFunctionExpression, ast.KindArrowFunction:
+		if s.ctx.Err() != nil {
+			return true
+		}
+	}
+	if node.End() == node.Pos() || !s.span.Overlaps(node.Loc) {
+		return false
+	}
+
+	prevInJsxElement := s.inJsxElement
+	if ast.IsJsxElement(node) || ast.IsJsxSelfClosingElement(node) {
+		s.inJsxElement = true
+	}
+	if ast.IsJsxExpression(node) {
+		s.inJsxElement = false
+	}
+
+	if ast.IsIdentifier(node) && !s.inJsxElement && !isInImportClause(node) && !isInfinityOrNaNString(node.Text()) {
+		s.classifyIdentifier(node)
+	}
+	node.ForEachChild(s.visit)
+
+	s.inJsxElement = prevInJsxElement
+	return false
+}
+
+func (s *semanticTokensState) classifyIdentifier(node *ast.Node) {
+	symbol := s.checker.GetSymbolAtLocation(node)
+	if symbol == nil {
+		return
+	}
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		symbol = s.checker.GetAliasedSymbol(symbol)
+	}
+	tokenType, ok := classifySymbol(symbol, getMeaningFromLocation(node))
+	if !ok {
+		return
+	}
+
+	var modifierSet semanticTokenModifier
+	if parent := node.Parent; parent != nil {
+		declarationType, isDeclarationKind := semanticTokenTypeFromDeclarationKind[parent.Kind]
+		parentIsDeclaration := ast.IsBindingElement(parent) || isDeclarationKind && declarationType == tokenType
+		if parentIsDeclaration && parent.Name() == node {
+			modifierSet = semanticTokenModifierDeclaration
+		}
+	}
+
+	// Accesses of parameter properties, e.g. `this.x` where `x` is declared in the constructor.
+	if tokenType == semanticTokenTypeParameter && ast.IsRightSideOfQualifiedNameOrPropertyAccess(node) {
+		tokenType = semanticTokenTypeProperty
+	}
+
+	tokenType = s.reclassifyByType(node, tokenType)
+
+	if decl := symbol.ValueDeclaration; decl != nil {
+		modifiers := ast.GetCombinedModifierFlags(decl)
+		nodeFlags := ast.GetCombinedNodeFlags(decl)
+		if modifiers&ast.ModifierFlagsStatic != 0 {
+			modifierSet |= semanticTokenModifierStatic
+		}
+		if modifiers&ast.ModifierFlagsAsync != 0 {
+			modifierSet |= semanticTokenModifierAsync
+		}
+		if tokenType != semanticTokenTypeClass && tokenType != semanticTokenTypeInterface {
+			if modifiers&ast.ModifierFlagsReadonly != 0 || nodeFlags&ast.NodeFlagsConst != 0 || symbol.Flags&ast.SymbolFlagsEnumMember != 0 {
+				modifierSet |= semanticTokenModifierReadonly
+			}
+		}
+		if (tokenType == semanticTokenTypeVariable || tokenType == semanticTokenTypeFunction) && isLocalDeclaration(decl, s.sourceFile) {
+			modifierSet |= semanticTokenModifierLocal
+		}
+		if s.isDeclarationInDefaultLibrary(decl) {
+			modifierSet |= semanticTokenModifierDefaultLibrary
+		}
+	} else {
+		for _, decl := range symbol.Declarations {
+			if s.isDeclarationInDefaultLibrary(decl) {
+				modifierSet |= semanticTokenModifierDefaultLibrary
+				break
+			}
+		}
+	}
+
+	s.tokens = append(s.tokens, semanticToken{node: node, tokenType: tokenType, modifierSet: modifierSet})
+}
+
+func (s *semanticTokensState) isDeclarationInDefaultLibrary(decl *ast.Node) bool {
+	file := ast.GetSourceFileOfNode(decl)
+	return file != nil && s.program.IsSourceFileDefaultLibrary(file.Path())
+}
+
+func classifySymbol(symbol *ast.Symbol, meaning ast.SemanticMeaning) (semanticTokenType, bool) {
+	flags := symbol.Flags
+	switch {
+	case flags&ast.SymbolFlagsClass != 0:
+		return semanticTokenTypeClass, true
+	case flags&ast.SymbolFlagsEnum != 0:
+		return semanticTokenTypeEnum, true
+	case flags&ast.SymbolFlagsTypeAlias != 0:
+		return semanticTokenTypeType, true
+	case flags&ast.SymbolFlagsInterface != 0:
+		if meaning&ast.SemanticMeaningType != 0 {
+			return semanticTokenTypeInterface, true
+		}
+	case flags&ast.SymbolFlagsTypeParameter != 0:
+		return semanticTokenTypeTypeParameter, true
+	}
+	decl := symbol.ValueDeclaration
+	if decl == nil && len(symbol.Declarations) > 0 {
+		decl = symbol.Declarations[0]
+	}
+	if decl == nil {
+		return 0, false
+	}
+	if ast.IsBindingElement(decl) {
+		decl = getDeclarationForBindingElement(decl)
+	}
+	tokenType, ok := semanticTokenTypeFromDeclarationKind[decl.Kind]
+	return tokenType, ok
+}
+
+// reclassifyByType refines variable, property and parameter classifications using the type at the location:
+// values with construct signatures are classes, and callable values without properties are functions or methods.
+func (s *semanticTokensState) reclassifyByType(node *ast.Node, tokenType semanticTokenType) semanticTokenType {
+	if tokenType != semanticTokenTypeVariable && tokenType != semanticTokenTypeProperty && tokenType != semanticTokenTypeParameter {
+		return tokenType
+	}
+	t := s.checker.GetTypeAtLocation(node)
+	if t == nil {
+		return tokenType
+	}
+	test := func(condition func(t *checker.Type) bool) bool {
+		if condition(t) {
+			return true
+		}
+		if t.IsUnion() {
+			for _, member := range t.Types() {
+				if condition(member) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	if tokenType != semanticTokenTypeParameter && test(func(t *checker.Type) bool { return len(s.checker.GetConstructSignatures(t)) > 0 }) {
+		return semanticTokenTypeClass
+	}
+	if test(func(t *checker.Type) bool { return len(s.checker.GetCallSignatures(t)) > 0 }) &&
+		!test(func(t *checker.Type) bool { return len(s.checker.GetPropertiesOfType(t)) > 0 }) ||
+		isExpressionInCallExpression(node) {
+		if tokenType == semanticTokenTypeProperty {
+			return semanticTokenTypeMethod
+		}
+		return semanticTokenTypeFunction
+	}
+	return tokenType
+}
+
+func isLocalDeclaration(decl *ast.Node, sourceFile *ast.SourceFile) bool {
+	if ast.IsBindingElement(decl) {
+		decl = getDeclarationForBindingElement(decl)
+	}
+	switch {
+	case ast.IsVariableDeclaration(decl):
+		return (!ast.IsSourceFile(decl.Parent.Parent.Parent) || ast.IsCatchClause(decl.Parent)) && ast.GetSourceFileOfNode(decl) == sourceFile
+	case ast.IsFunctionDeclaration(decl):
+		return !ast.IsSourceFile(decl.Parent) && ast.GetSourceFileOfNode(decl) == sourceFile
+	}
+	return false
+}
+
+// getDeclarationForBindingElement returns the variable or parameter declaration that owns a (possibly nested) binding element.
+func getDeclarationForBindingElement(element *ast.Node) *ast.Node {
+	for ast.IsBindingElement(element.Parent.Parent) {
+		element = element.Parent.Parent
+	}
+	return element.Parent.Parent
+}
+
+func isInImportClause(node *ast.Node) bool {
+	parent := node.Parent
+	return parent != nil && (ast.IsImportClause(parent) || ast.IsImportSpecifier(parent) || ast.IsNamespaceImport(parent))
+}
+
+func isExpressionInCallExpression(node *ast.Node) bool {
+	for ast.IsRightSideOfQualifiedNameOrPropertyAccess(node) {
+		node = node.Parent
+	}
+	return ast.IsCallExpression(node.Parent) && node.Parent.Expression() == node
+}
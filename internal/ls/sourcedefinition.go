@@ -0,0 +1,184 @@
+package ls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/compiler"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/module"
+	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"github.com/microsoft/typescript-go/internal/parser"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// ProvideSourceDefinition is like ProvideDefinition, but resolves declarations in declaration files of packages in
+// node_modules to the JavaScript or TypeScript files that implement them. The implementation file is found by
+// resolving the package again without declaration files, and the declaration in it by name.
+func (l *LanguageService) ProvideSourceDefinition(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (lsproto.DefinitionResponse, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	node := astnav.GetTouchingPropertyName(file, int(l.converters.LineAndCharacterToPosition(file, position)))
+	if node.Kind == ast.KindSourceFile {
+		return lsproto.LocationOrLocationsOrDefinitionLinksOrNull{}, nil
+	}
+
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	resolver := newSourceDefinitionResolver(program)
+	var locations []lsproto.Location
+	for _, declaration := range getDefinitionDeclarations(c, node) {
+		declarationFile := ast.GetSourceFileOfNode(declaration)
+		name := core.OrElse(ast.GetNameOfDeclaration(declaration), declaration)
+		location := l.getMappedLocation(declarationFile.FileName(), createRangeFromNode(name, declarationFile))
+		if !tspath.IsDeclarationFileName(location.Uri.FileName()) {
+			locations = core.AppendIfUnique(locations, location)
+			continue
+		}
+		implementationFile := l.getImplementationFile(program, resolver, c, file, node, declarationFile)
+		if implementationFile == nil {
+			locations = core.AppendIfUnique(locations, location)
+			continue
+		}
+		var implementationDeclarations []*ast.Node
+		if ast.IsIdentifier(name) {
+			implementationDeclarations = getTopMostDeclarationsInFile(implementationFile, name.Text())
+		}
+		if len(implementationDeclarations) == 0 {
+			// Go to the start of the file if the declaration cannot be found by name, e.g. for `export =`.
+			locations = core.AppendIfUnique(locations, l.getMappedLocation(implementationFile.FileName(), core.NewTextRange(0, 0)))
+			continue
+		}
+		for _, implementationDeclaration := range implementationDeclarations {
+			implementationName := core.OrElse(ast.GetNameOfDeclaration(implementationDeclaration), implementationDeclaration)
+			locations = core.AppendIfUnique(locations, l.getMappedLocation(implementationFile.FileName(), createRangeFromNode(implementationName, implementationFile)))
+		}
+	}
+	return lsproto.LocationOrLocationsOrDefinitionLinksOrNull{Locations: &locations}, nil
+}
+
+type sourceDefinitionResolver struct {
+	// resolver resolves modules like the program does.
+	resolver *module.Resolver
+	// implementationResolver resolves modules to JavaScript and TypeScript files only.
+	implementationResolver *module.Resolver
+}
+
+func newSourceDefinitionResolver(program *compiler.Program) *sourceDefinitionResolver {
+	implementationOptions := program.Options().Clone()
+	implementationOptions.NoDtsResolution = core.TSTrue
+	implementationOptions.AllowJs = core.TSTrue
+	return &sourceDefinitionResolver{
+		resolver:               module.NewResolver(program.Host(), program.Options(), "", ""),
+		implementationResolver: module.NewResolver(program.Host(), implementationOptions, "", ""),
+	}
+}
+
+// getImplementationFile returns the implementation file for a declaration file of a package in node_modules. A
+// module specifier that imports the symbol at the node is resolved first; otherwise the declaration file is
+// mapped to the main entry point of its package or to a subpath of the package.
+func (l *LanguageService) getImplementationFile(program *compiler.Program, resolver *sourceDefinitionResolver, c *checker.Checker, file *ast.SourceFile, node *ast.Node, declarationFile *ast.SourceFile) *ast.SourceFile {
+	if moduleSpecifier := getModuleSpecifierOfImportedSymbol(c, file, node); moduleSpecifier != nil && !tspath.PathIsRelative(moduleSpecifier.Text()) {
+		mode := program.GetModeForUsageLocation(file, moduleSpecifier)
+		if resolved, _ := resolver.implementationResolver.ResolveModuleName(moduleSpecifier.Text(), file.FileName(), mode, nil); resolved.IsResolved() && !tspath.IsDeclarationFileName(resolved.ResolvedFileName) {
+			return l.getImplementationSourceFile(program, resolved.ResolvedFileName)
+		}
+	}
+
+	// Give up on files nested in more than one node_modules directory.
+	fileName := declarationFile.FileName()
+	nodeModulesIndex := strings.Index(fileName, "/node_modules/")
+	if nodeModulesIndex == -1 || strings.LastIndex(fileName, "/node_modules/") != nodeModulesIndex {
+		return nil
+	}
+	packageDirectory := module.ParseNodeModuleFromPath(fileName, false /*isFolder*/)
+	packageName := modulespecifiers.GetPackageNameFromTypesPackageName(packageDirectory[nodeModulesIndex+len("/node_modules/"):])
+	mode := program.GetDefaultResolutionModeForFile(file)
+
+	// Resolve from the declaration file as well, for packages the file cannot import directly.
+	for _, containingFile := range []string{file.FileName(), fileName} {
+		specifier := packageName
+		if entryPoint, _ := resolver.resolver.ResolveModuleName(packageName, containingFile, mode, nil); !entryPoint.IsResolved() || entryPoint.ResolvedFileName != fileName {
+			specifier = packageName + "/" + tspath.RemoveFileExtension(fileName[len(packageDirectory)+1:])
+		}
+		if resolved, _ := resolver.implementationResolver.ResolveModuleName(specifier, containingFile, mode, nil); resolved.IsResolved() && !tspath.IsDeclarationFileName(resolved.ResolvedFileName) {
+			return l.getImplementationSourceFile(program, resolved.ResolvedFileName)
+		}
+	}
+	return nil
+}
+
+// getModuleSpecifierOfImportedSymbol returns the module specifier of the import in a file that declares the
+// alias at a node, if any.
+func getModuleSpecifierOfImportedSymbol(c *checker.Checker, file *ast.SourceFile, node *ast.Node) *ast.Node {
+	symbol := c.GetSymbolAtLocation(node)
+	if symbol == nil || symbol.Flags&ast.SymbolFlagsAlias == 0 {
+		return nil
+	}
+	for _, declaration := range symbol.Declarations {
+		if ast.GetSourceFileOfNode(declaration) != file {
+			continue
+		}
+		importOrExport := ast.FindAncestor(declaration, func(node *ast.Node) bool {
+			return ast.IsImportDeclaration(node) || ast.IsExportDeclaration(node) || ast.IsImportEqualsDeclaration(node)
+		})
+		if importOrExport == nil {
+			continue
+		}
+		if moduleSpecifier := ast.GetExternalModuleName(importOrExport); moduleSpecifier != nil && ast.IsStringLiteralLike(moduleSpecifier) {
+			return moduleSpecifier
+		}
+	}
+	return nil
+}
+
+// getImplementationSourceFile returns the source file of an implementation file, parsing it if it is not part of
+// the program.
+func (l *LanguageService) getImplementationSourceFile(program *compiler.Program, fileName string) *ast.SourceFile {
+	if sourceFile := program.GetSourceFile(fileName); sourceFile != nil {
+		return sourceFile
+	}
+	text, ok := l.host.ReadFile(fileName)
+	if !ok {
+		return nil
+	}
+	options := program.Options()
+	return parser.ParseSourceFile(ast.SourceFileParseOptions{
+		FileName:                       fileName,
+		Path:                           tspath.ToPath(fileName, program.GetCurrentDirectory(), program.UseCaseSensitiveFileNames()),
+		CompilerOptions:                ast.GetSourceFileAffectingCompilerOptions(fileName, options),
+		ExternalModuleIndicatorOptions: ast.GetExternalModuleIndicatorOptions(fileName, options, ast.SourceFileMetaData{}),
+	}, text, core.GetScriptKindFromFileName(fileName))
+}
+
+// getTopMostDeclarationsInFile returns the least nested declarations with a name in a file.
+func getTopMostDeclarationsInFile(sourceFile *ast.SourceFile, name string) []*ast.Node {
+	var declarations []*ast.Node
+	minDepth := -1
+	var visit func(node *ast.Node, depth int)
+	visit = func(node *ast.Node, depth int) {
+		if minDepth != -1 && depth > minDepth {
+			return
+		}
+		if ast.IsDeclaration(node) {
+			if declarationName := ast.GetNameOfDeclaration(node); declarationName != nil && ast.IsIdentifier(declarationName) && declarationName.Text() == name {
+				if minDepth == -1 || depth < minDepth {
+					declarations = declarations[:0]
+					minDepth = depth
+				}
+				declarations = append(declarations, node)
+				return
+			}
+		}
+		node.ForEachChild(func(child *ast.Node) bool {
+			visit(child, depth+1)
+			return false
+		})
+	}
+	visit(sourceFile.AsNode(), 0)
+	return declarations
+}
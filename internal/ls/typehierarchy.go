@@ -0,0 +1,180 @@
+package ls
+
+import (
+	"context"
+	"slices"
+
+	"github.com/microsoft/typescript-go/internal/ast"
+	"github.com/microsoft/typescript-go/internal/astnav"
+	"github.com/microsoft/typescript-go/internal/checker"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/scanner"
+)
+
+func (l *LanguageService) ProvidePrepareTypeHierarchy(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (lsproto.TypeHierarchyPrepareResponse, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	c, done := program.GetTypeChecker(ctx)
+	defer done()
+
+	node := astnav.GetTouchingPropertyName(file, int(l.converters.LineAndCharacterToPosition(file, position)))
+	symbol := getTypeHierarchySymbol(c, node)
+	if symbol == nil {
+		return lsproto.TypeHierarchyItemsOrNull{}, nil
+	}
+	items := l.createTypeHierarchyItems(getTypeHierarchyDeclarations(symbol))
+	return lsproto.TypeHierarchyItemsOrNull{TypeHierarchyItems: &items}, nil
+}
+
+// ProvideTypeHierarchySupertypes returns the classes and interfaces the item's type directly extends or implements.
+func (l *LanguageService) ProvideTypeHierarchySupertypes(ctx context.Context, item *lsproto.TypeHierarchyItem) (lsproto.TypeHierarchySupertypesResponse, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	c, done := program.GetTypeChecker(ctx)
+	defer done()
+
+	items := []*lsproto.TypeHierarchyItem{}
+	symbol := l.getTypeHierarchySymbolOfItem(c, file, item)
+	if symbol == nil {
+		return lsproto.TypeHierarchyItemsOrNull{TypeHierarchyItems: &items}, nil
+	}
+
+	var supertypes []*ast.Symbol
+	addSupertype := func(t *checker.Type) {
+		if t == nil || t.Symbol() == nil || t.Symbol().Flags&(ast.SymbolFlagsClass|ast.SymbolFlagsInterface) == 0 {
+			return
+		}
+		if !slices.Contains(supertypes, t.Symbol()) {
+			supertypes = append(supertypes, t.Symbol())
+		}
+	}
+	if declaredType := c.GetDeclaredTypeOfSymbol(symbol); declaredType != nil && declaredType.ObjectFlags()&checker.ObjectFlagsClassOrInterface != 0 {
+		for _, baseType := range c.GetBaseTypes(declaredType) {
+			addSupertype(baseType)
+		}
+	}
+	for _, declaration := range symbol.Declarations {
+		if ast.IsClassLike(declaration) {
+			for _, implemented := range ast.GetImplementsHeritageClauseElements(declaration) {
+				addSupertype(c.GetTypeFromTypeNode(implemented))
+			}
+		}
+	}
+
+	for _, supertype := range supertypes {
+		items = append(items, l.createTypeHierarchyItems(getTypeHierarchyDeclarations(supertype))...)
+	}
+	return lsproto.TypeHierarchyItemsOrNull{TypeHierarchyItems: &items}, nil
+}
+
+// ProvideTypeHierarchySubtypes returns the classes and interfaces that directly extend or implement the item's type,
+// found with the same program-wide search used for go-to-implementation.
+func (l *LanguageService) ProvideTypeHierarchySubtypes(ctx context.Context, item *lsproto.TypeHierarchyItem) (lsproto.TypeHierarchySubtypesResponse, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	c, done := program.GetTypeChecker(ctx)
+	symbol := l.getTypeHierarchySymbolOfItem(c, file, item)
+	// The implementation search below acquires its own checker.
+	done()
+
+	items := []*lsproto.TypeHierarchyItem{}
+	if symbol == nil {
+		return lsproto.TypeHierarchyItemsOrNull{TypeHierarchyItems: &items}, nil
+	}
+	declarations := getTypeHierarchyDeclarations(symbol)
+	name := getTypeHierarchyDeclarationReferenceNode(declarations[0])
+
+	var seen collections.Set[*ast.Node]
+	var subtypes []*ast.Node
+	for _, entry := range l.getImplementationReferenceEntries(ctx, program, name, name.Pos()) {
+		declaration := entry.node
+		if !isTypeHierarchyDeclaration(declaration) {
+			declaration = declaration.Parent
+		}
+		if declaration == nil || !isTypeHierarchyDeclaration(declaration) || getTypeHierarchyDeclarationReferenceNode(declaration) != entry.node ||
+			slices.Contains(declarations, declaration) {
+			continue
+		}
+		if seen.AddIfAbsent(declaration) {
+			subtypes = append(subtypes, declaration)
+		}
+	}
+	if ctx.Err() != nil {
+		return lsproto.TypeHierarchyItemsOrNull{}, ctx.Err()
+	}
+	items = append(items, l.createTypeHierarchyItems(subtypes)...)
+	return lsproto.TypeHierarchyItemsOrNull{TypeHierarchyItems: &items}, nil
+}
+
+// getTypeHierarchySymbolOfItem re-resolves the symbol of an item produced by a previous prepare request
+// from the start of its selection range.
+func (l *LanguageService) getTypeHierarchySymbolOfItem(c *checker.Checker, file *ast.SourceFile, item *lsproto.TypeHierarchyItem) *ast.Symbol {
+	position := int(l.converters.LineAndCharacterToPosition(file, item.SelectionRange.Start))
+	return getTypeHierarchySymbol(c, astnav.GetTouchingPropertyName(file, position))
+}
+
+// getTypeHierarchySymbol returns the class or interface symbol referenced or declared at node.
+func getTypeHierarchySymbol(c *checker.Checker, node *ast.Node) *ast.Symbol {
+	// Keywords of a declaration, like `class` or `default`, select the declaration itself.
+	if !isTypeHierarchyDeclaration(node) && node.Parent != nil && isTypeHierarchyDeclaration(node.Parent) && node.Parent.Name() != node {
+		node = node.Parent
+	}
+	var symbol *ast.Symbol
+	if isTypeHierarchyDeclaration(node) {
+		symbol = c.GetMergedSymbol(node.Symbol())
+	} else {
+		symbol = c.GetSymbolAtLocation(node)
+	}
+	if symbol != nil && symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		symbol = c.GetAliasedSymbol(symbol)
+	}
+	if symbol == nil || symbol.Flags&(ast.SymbolFlagsClass|ast.SymbolFlagsInterface) == 0 || len(getTypeHierarchyDeclarations(symbol)) == 0 {
+		return nil
+	}
+	return symbol
+}
+
+func getTypeHierarchyDeclarations(symbol *ast.Symbol) []*ast.Node {
+	return core.Filter(symbol.Declarations, isTypeHierarchyDeclaration)
+}
+
+func isTypeHierarchyDeclaration(node *ast.Node) bool {
+	return ast.IsClassDeclaration(node) || ast.IsClassExpression(node) || ast.IsInterfaceDeclaration(node)
+}
+
+func getTypeHierarchyDeclarationReferenceNode(node *ast.Node) *ast.Node {
+	if name := node.Name(); name != nil {
+		return name
+	}
+	if defaultModifier := findDefaultModifier(node); defaultModifier != nil {
+		return defaultModifier
+	}
+	return node
+}
+
+func (l *LanguageService) createTypeHierarchyItems(declarations []*ast.Node) []*lsproto.TypeHierarchyItem {
+	return core.Map(declarations, l.createTypeHierarchyItem)
+}
+
+func (l *LanguageService) createTypeHierarchyItem(declaration *ast.Node) *lsproto.TypeHierarchyItem {
+	file := ast.GetSourceFileOfNode(declaration)
+	nameNode := getTypeHierarchyDeclarationReferenceNode(declaration)
+	name := "default"
+	if nameNode == declaration {
+		name = "(anonymous class)"
+	} else if nameNode.Kind != ast.KindDefaultKeyword {
+		name = scanner.GetTextOfNode(nameNode)
+	}
+	start := scanner.SkipTriviaEx(file.Text(), declaration.Pos(), &scanner.SkipTriviaOptions{StopAtComments: true})
+	nameStart := scanner.GetTokenPosOfNode(nameNode, file, false /*includeJSDoc*/)
+	item := &lsproto.TypeHierarchyItem{
+		Name:           name,
+		Kind:           getSymbolKindFromNode(declaration),
+		Uri:            FileNameToDocumentURI(file.FileName()),
+		Range:          *l.createLspRangeFromBounds(start, declaration.End(), file),
+		SelectionRange: *l.createLspRangeFromBounds(nameStart, core.IfElse(nameNode == declaration, nameStart, nameNode.End()), file),
+	}
+	if ast.IsModuleBlock(declaration.Parent) && ast.IsIdentifier(declaration.Parent.Parent.Name()) {
+		item.Detail = ptrTo(declaration.Parent.Parent.Name().Text())
+	}
+	return item
+}
@@ -32,51 +32,51 @@ type UserPreferences struct {
 	// a whole declaration for the member.
 	// E.g., `class A { f| }` could be completed to `class A { foo(): number {} }`, instead of
 	// `class A { foo }`.
-	IncludeCompletionsWithClassMemberSnippets core.Tristate // !!!
+	IncludeCompletionsWithClassMemberSnippets core.Tristate
 	// If enabled, object literal methods will have a method declaration completion entry in addition
 	// to the regular completion entry containing just the method name.
 	// E.g., `const objectLiteral: T = { f| }` could be completed to `const objectLiteral: T = { foo(): void {} }`,
 	// in addition to `const objectLiteral: T = { foo }`.
-	IncludeCompletionsWithObjectLiteralMethodSnippets core.Tristate // !!!
+	IncludeCompletionsWithObjectLiteralMethodSnippets core.Tristate
 	JsxAttributeCompletionStyle                       JsxAttributeCompletionStyle
 
 	// ------- AutoImports --------
 
-	ImportModuleSpecifierPreference modulespecifiers.ImportModuleSpecifierPreference // !!!
+	ImportModuleSpecifierPreference modulespecifiers.ImportModuleSpecifierPreference
 	// Determines whether we import `foo/index.ts` as "foo", "foo/index", or "foo/index.js"
-	ImportModuleSpecifierEnding       modulespecifiers.ImportModuleSpecifierEndingPreference // !!!
-	IncludePackageJsonAutoImports     IncludePackageJsonAutoImports                          // !!!
-	AutoImportSpecifierExcludeRegexes []string                                               // !!!
-	AutoImportFileExcludePatterns     []string                                               // !!!
-	PreferTypeOnlyAutoImports         bool                                                   // !!!
+	ImportModuleSpecifierEnding       modulespecifiers.ImportModuleSpecifierEndingPreference
+	IncludePackageJsonAutoImports     IncludePackageJsonAutoImports // !!!
+	AutoImportSpecifierExcludeRegexes []string
+	AutoImportFileExcludePatterns     []string // !!!
+	PreferTypeOnlyAutoImports         bool
 
 	// ------- OrganizeImports -------
 
 	// Indicates whether imports should be organized in a case-insensitive manner.
 	//
 	// Default: TSUnknown ("auto" in strada), will perform detection
-	OrganizeImportsIgnoreCase core.Tristate // !!!
+	OrganizeImportsIgnoreCase core.Tristate
 	// Indicates whether imports should be organized via an "ordinal" (binary) comparison using the numeric value of their
 	// code points, or via "unicode" collation (via the Unicode Collation Algorithm (https://unicode.org/reports/tr10/#Scope))
 	//
 	// using rules associated with the locale specified in organizeImportsCollationLocale.
 	//
 	// Default: Ordinal
-	OrganizeImportsCollation OrganizeImportsCollation // !!!
+	OrganizeImportsCollation OrganizeImportsCollation
 	// Indicates the locale to use for "unicode" collation. If not specified, the locale `"en"` is used as an invariant
 	// for the sake of consistent sorting. Use `"auto"` to use the detected UI locale.
 	//
 	// This preference is ignored if organizeImportsCollation is not `unicode`.
 	//
 	// Default: `"en"`
-	OrganizeImportsLocale string // !!!
+	OrganizeImportsLocale string
 	// Indicates whether numeric collation should be used for digit sequences in strings. When `true`, will collate
 	// strings such that `a1z < a2z < a100z`. When `false`, will collate strings such that `a1z < a100z < a2z`.
 	//
 	// This preference is ignored if organizeImportsCollation is not `unicode`.
 	//
 	// Default: `false`
-	OrganizeImportsNumericCollation bool // !!!
+	OrganizeImportsNumericCollation bool
 	// Indicates whether accents and other diacritic marks are considered unequal for the purpose of collation. When
 	// `true`, characters with accents and other diacritics will be collated in the order defined by the locale specified
 	// in organizeImportsCollationLocale.
@@ -84,7 +84,7 @@ type UserPreferences struct {
 	// This preference is ignored if organizeImportsCollation is not `unicode`.
 	//
 	// Default: `true`
-	OrganizeImportsAccentCollation OrganizeImportsAccentCollation // !!!
+	OrganizeImportsAccentCollation OrganizeImportsAccentCollation
 	// Indicates whether upper case or lower case should sort first. When `false`, the default order for the locale
 	// specified in organizeImportsCollationLocale is used.
 	//
@@ -94,11 +94,11 @@ type UserPreferences struct {
 	// 		- organizeImportsIgnoreCase is `auto` and the auto-detected case sensitivity is case-insensitive.
 	//
 	// Default: `false`
-	OrganizeImportsCaseFirst OrganizeImportsCaseFirst // !!!
+	OrganizeImportsCaseFirst OrganizeImportsCaseFirst
 	// Indicates where named type-only imports should sort. "inline" sorts named imports without regard to if the import is type-only.
 	//
 	// Default: `last`
-	OrganizeImportsTypeOrder OrganizeImportsTypeOrder // !!!
+	OrganizeImportsTypeOrder OrganizeImportsTypeOrder
 
 	// ------- MoveToFile -------
 
@@ -199,7 +199,154 @@ const (
 	QuotePreferenceSingle  QuotePreference = "single"
 )
 
-func (p *UserPreferences) Parse(config map[string]interface{}) {
+// NewDefaultUserPreferences returns the preferences used for a workspace that has not been configured.
+func NewDefaultUserPreferences() *UserPreferences {
+	return &UserPreferences{
+		IncludeCompletionsForModuleExports:    core.TSTrue,
+		IncludeCompletionsForImportStatements: core.TSTrue,
+	}
+}
+
+// Parse reads preferences from the `typescript` or `javascript` configuration section of an editor, in the
+// shape used by the VS Code settings, e.g. `{ "preferences": { "quoteStyle": "single" } }`. Settings that
+// are absent or have an unexpected type leave the current value unchanged.
+func (p *UserPreferences) Parse(config map[string]any) {
+	if preferences, ok := config["preferences"].(map[string]any); ok {
+		parseString(preferences, "quoteStyle", &p.QuotePreference)
+		parseString(preferences, "importModuleSpecifier", &p.ImportModuleSpecifierPreference)
+		parseString(preferences, "importModuleSpecifierEnding", &p.ImportModuleSpecifierEnding)
+		parseString(preferences, "includePackageJsonAutoImports", &p.IncludePackageJsonAutoImports)
+		parseString(preferences, "jsxAttributeCompletionStyle", &p.JsxAttributeCompletionStyle)
+		parseStrings(preferences, "autoImportFileExcludePatterns", &p.AutoImportFileExcludePatterns)
+		parseStrings(preferences, "autoImportSpecifierExcludeRegexes", &p.AutoImportSpecifierExcludeRegexes)
+		parseBool(preferences, "preferTypeOnlyAutoImports", &p.PreferTypeOnlyAutoImports)
+		parseTristate(preferences, "useAliasesForRenames", &p.UseAliasesForRename)
+		if organizeImports, ok := preferences["organizeImports"].(map[string]any); ok {
+			p.parseOrganizeImports(organizeImports)
+		}
+	}
+
+	if suggest, ok := config["suggest"].(map[string]any); ok {
+		parseTristate(suggest, "autoImports", &p.IncludeCompletionsForModuleExports)
+		parseTristate(suggest, "includeCompletionsForImportStatements", &p.IncludeCompletionsForImportStatements)
+		parseTristate(suggest, "includeAutomaticOptionalChainCompletions", &p.IncludeAutomaticOptionalChainCompletions)
+		if classMemberSnippets, ok := suggest["classMemberSnippets"].(map[string]any); ok {
+			parseTristate(classMemberSnippets, "enabled", &p.IncludeCompletionsWithClassMemberSnippets)
+		}
+		if objectLiteralMethodSnippets, ok := suggest["objectLiteralMethodSnippets"].(map[string]any); ok {
+			parseTristate(objectLiteralMethodSnippets, "enabled", &p.IncludeCompletionsWithObjectLiteralMethodSnippets)
+		}
+	}
+
+	if inlayHints, ok := config["inlayHints"].(map[string]any); ok {
+		p.parseInlayHints(inlayHints)
+	}
+
+	if workspaceSymbols, ok := config["workspaceSymbols"].(map[string]any); ok {
+		parseBool(workspaceSymbols, "excludeLibrarySymbols", &p.ExcludeLibrarySymbolsInNavTo)
+	}
+}
+
+func (p *UserPreferences) parseOrganizeImports(config map[string]any) {
+	switch config["caseSensitivity"] {
+	case "auto":
+		p.OrganizeImportsIgnoreCase = core.TSUnknown
+	case "caseInsensitive":
+		p.OrganizeImportsIgnoreCase = core.TSTrue
+	case "caseSensitive":
+		p.OrganizeImportsIgnoreCase = core.TSFalse
+	}
+	switch config["typeOrder"] {
+	case "auto", "last":
+		p.OrganizeImportsTypeOrder = OrganizeImportsTypeOrderLast
+	case "inline":
+		p.OrganizeImportsTypeOrder = OrganizeImportsTypeOrderInline
+	case "first":
+		p.OrganizeImportsTypeOrder = OrganizeImportsTypeOrderFirst
+	}
+	switch config["unicodeCollation"] {
+	case "ordinal":
+		p.OrganizeImportsCollation = OrganizeImportsCollationOrdinal
+	case "unicode":
+		p.OrganizeImportsCollation = OrganizeImportsCollationUnicode
+	}
+	parseString(config, "locale", &p.OrganizeImportsLocale)
+	parseBool(config, "numericCollation", &p.OrganizeImportsNumericCollation)
+	if accentCollation, ok := config["accentCollation"].(bool); ok {
+		p.OrganizeImportsAccentCollation = core.IfElse(accentCollation, OrganizeImportsAccentCollationTrue, OrganizeImportsAccentCollationFalse)
+	}
+	switch config["caseFirst"] {
+	case "default":
+		p.OrganizeImportsCaseFirst = OrganizeImportsCaseFirstFalse
+	case "lower":
+		p.OrganizeImportsCaseFirst = OrganizeImportsCaseFirstLower
+	case "upper":
+		p.OrganizeImportsCaseFirst = OrganizeImportsCaseFirstUpper
+	}
+}
+
+func (p *UserPreferences) parseInlayHints(config map[string]any) {
+	if parameterNames, ok := config["parameterNames"].(map[string]any); ok {
+		switch parameterNames["enabled"] {
+		case "none":
+			p.IncludeInlayParameterNameHints = IncludeInlayParameterNameHintsNone
+		case "literals":
+			p.IncludeInlayParameterNameHints = IncludeInlayParameterNameHintsLiterals
+		case "all":
+			p.IncludeInlayParameterNameHints = IncludeInlayParameterNameHintsAll
+		}
+		if suppress, ok := parameterNames["suppressWhenArgumentMatchesName"].(bool); ok {
+			p.IncludeInlayParameterNameHintsWhenArgumentMatchesName = !suppress
+		}
+	}
+	if parameterTypes, ok := config["parameterTypes"].(map[string]any); ok {
+		parseBool(parameterTypes, "enabled", &p.IncludeInlayFunctionParameterTypeHints)
+	}
+	if variableTypes, ok := config["variableTypes"].(map[string]any); ok {
+		parseBool(variableTypes, "enabled", &p.IncludeInlayVariableTypeHints)
+		if suppress, ok := variableTypes["suppressWhenTypeMatchesName"].(bool); ok {
+			p.IncludeInlayVariableTypeHintsWhenTypeMatchesName = !suppress
+		}
+	}
+	if propertyDeclarationTypes, ok := config["propertyDeclarationTypes"].(map[string]any); ok {
+		parseBool(propertyDeclarationTypes, "enabled", &p.IncludeInlayPropertyDeclarationTypeHints)
+	}
+	if functionLikeReturnTypes, ok := config["functionLikeReturnTypes"].(map[string]any); ok {
+		parseBool(functionLikeReturnTypes, "enabled", &p.IncludeInlayFunctionLikeReturnTypeHints)
+	}
+	if enumMemberValues, ok := config["enumMemberValues"].(map[string]any); ok {
+		parseBool(enumMemberValues, "enabled", &p.IncludeInlayEnumMemberValueHints)
+	}
+}
+
+func parseString[T ~string](config map[string]any, name string, value *T) {
+	if s, ok := config[name].(string); ok {
+		*value = T(s)
+	}
+}
+
+func parseStrings(config map[string]any, name string, value *[]string) {
+	if values, ok := config[name].([]any); ok {
+		result := make([]string, 0, len(values))
+		for _, v := range values {
+			if s, ok := v.(string); ok {
+				result = append(result, s)
+			}
+		}
+		*value = result
+	}
+}
+
+func parseBool(config map[string]any, name string, value *bool) {
+	if b, ok := config[name].(bool); ok {
+		*value = b
+	}
+}
+
+func parseTristate(config map[string]any, name string, value *core.Tristate) {
+	if b, ok := config[name].(bool); ok {
+		*value = core.BoolToTristate(b)
+	}
 }
 
 func (p *UserPreferences) ModuleSpecifierPreferences() modulespecifiers.UserPreferences {
@@ -0,0 +1,51 @@
+package ls_test
+
+import (
+	"testing"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/modulespecifiers"
+	"gotest.tools/v3/assert"
+)
+
+func TestUserPreferencesParse(t *testing.T) {
+	t.Parallel()
+
+	var config map[string]any
+	assert.NilError(t, json.Unmarshal([]byte(`{
+		"preferences": {
+			"quoteStyle": "single",
+			"importModuleSpecifier": "non-relative",
+			"importModuleSpecifierEnding": "js",
+			"autoImportSpecifierExcludeRegexes": ["^node:"],
+			"useAliasesForRenames": false
+		},
+		"suggest": {
+			"autoImports": false,
+			"classMemberSnippets": { "enabled": true }
+		},
+		"inlayHints": {
+			"parameterNames": { "enabled": "literals", "suppressWhenArgumentMatchesName": true },
+			"variableTypes": { "enabled": true, "suppressWhenTypeMatchesName": false }
+		},
+		"format": { "semicolons": "remove" }
+	}`), &config))
+
+	preferences := ls.NewDefaultUserPreferences()
+	preferences.Parse(config)
+	assert.DeepEqual(t, preferences, &ls.UserPreferences{
+		QuotePreference:                                  ls.QuotePreferenceSingle,
+		ImportModuleSpecifierPreference:                  modulespecifiers.ImportModuleSpecifierPreferenceNonRelative,
+		ImportModuleSpecifierEnding:                      modulespecifiers.ImportModuleSpecifierEndingPreferenceJs,
+		AutoImportSpecifierExcludeRegexes:                []string{"^node:"},
+		UseAliasesForRename:                              core.TSFalse,
+		IncludeCompletionsForModuleExports:               core.TSFalse,
+		IncludeCompletionsForImportStatements:            core.TSTrue,
+		IncludeCompletionsWithClassMemberSnippets:        core.TSTrue,
+		IncludeInlayParameterNameHints:                   ls.IncludeInlayParameterNameHintsLiterals,
+		IncludeInlayVariableTypeHints:                    true,
+		IncludeInlayVariableTypeHintsWhenTypeMatchesName: true,
+	})
+}
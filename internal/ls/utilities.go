@@ -468,11 +468,40 @@ const (
 	quotePreferenceDouble
 )
 
-// !!!
 func getQuotePreference(file *ast.SourceFile, preferences *UserPreferences) quotePreference {
+	if preferences != nil && preferences.QuotePreference != QuotePreferenceUnknown && preferences.QuotePreference != QuotePreferenceAuto {
+		return core.IfElse(preferences.QuotePreference == QuotePreferenceSingle, quotePreferenceSingle, quotePreferenceDouble)
+	}
+	// ignore synthetic import added when importHelpers: true
+	firstModuleSpecifier := core.Find(file.Imports(), func(n *ast.Node) bool {
+		return ast.IsStringLiteral(n) && !ast.NodeIsSynthesized(n.Parent)
+	})
+	if firstModuleSpecifier != nil {
+		return quotePreferenceFromString(firstModuleSpecifier, file)
+	}
+	return quotePreferenceDouble
+}
+
+func quotePreferenceFromString(str *ast.StringLiteralNode, file *ast.SourceFile) quotePreference {
+	if str.AsStringLiteral().TokenFlags&ast.TokenFlagsSingleQuote != 0 {
+		return quotePreferenceSingle
+	}
+	// The parser does not record the quotes of a string literal, so look at the source text.
+	if !ast.NodeIsSynthesized(str) && file.Text()[scanner.GetTokenPosOfNode(str, file, false /*includeJSDoc*/)] == '\'' {
+		return quotePreferenceSingle
+	}
 	return quotePreferenceDouble
 }
 
+// makeStringLiteral creates a string literal that is printed with the preferred quotes.
+func (ct *changeTracker) makeStringLiteral(text string, quotePreference quotePreference) *ast.StringLiteralNode {
+	literal := ct.NodeFactory.NewStringLiteral(text)
+	if quotePreference == quotePreferenceSingle {
+		literal.AsStringLiteral().TokenFlags |= ast.TokenFlagsSingleQuote
+	}
+	return literal
+}
+
 func isNonContextualKeyword(token ast.Kind) bool {
 	return ast.IsKeywordKind(token) && !ast.IsContextualKeyword(token)
 }
@@ -0,0 +1,31 @@
+package lsproto
+
+// Requests the server supports in addition to those of the LSP meta model in lsp_generated.go.
+
+const (
+	// A request to resolve the implementation of a symbol, skipping declaration files of packages in node_modules.
+	MethodCustomTextDocumentSourceDefinition Method = "custom/textDocument/sourceDefinition"
+	// A request for the closing tag to insert after the `>` of an unclosed JSX element or fragment at a position.
+	MethodCustomTextDocumentJsxClosingTag Method = "custom/textDocument/jsxClosingTag"
+)
+
+// JsxClosingTag is the result of a custom/textDocument/jsxClosingTag request.
+type JsxClosingTag struct {
+	// The closing tag, e.g. `</div>`.
+	NewText string `json:"newText"`
+}
+
+var CustomTextDocumentSourceDefinitionInfo = RequestInfo[*TextDocumentPositionParams, DefinitionResponse]{Method: MethodCustomTextDocumentSourceDefinition}
+
+var CustomTextDocumentJsxClosingTagInfo = RequestInfo[*TextDocumentPositionParams, *JsxClosingTag]{Method: MethodCustomTextDocumentJsxClosingTag}
+
+// unmarshalCustomParams is like unmarshalParams for the custom methods. It reports false for other methods.
+func unmarshalCustomParams(method Method, data []byte) (any, bool, error) {
+	switch method {
+	case MethodCustomTextDocumentSourceDefinition, MethodCustomTextDocumentJsxClosingTag:
+		params, err := unmarshalPtrTo[TextDocumentPositionParams](data)
+		return params, true, err
+	default:
+		return nil, false, nil
+	}
+}
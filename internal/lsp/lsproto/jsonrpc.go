@@ -196,7 +196,11 @@ func (r *RequestMessage) UnmarshalJSON(data []byte) error {
 	r.Method = raw.Method
 
 	var err error
-	r.Params, err = unmarshalParams(raw.Method, raw.Params)
+	var ok bool
+	r.Params, ok, err = unmarshalCustomParams(raw.Method, raw.Params)
+	if !ok {
+		r.Params, err = unmarshalParams(raw.Method, raw.Params)
+	}
 	if err != nil {
 		return fmt.Errorf("%w: %w", ErrInvalidRequest, err)
 	}
@@ -149,3 +149,22 @@ func (Null) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
 func (Null) MarshalJSONTo(enc *jsontext.Encoder) error {
 	return enc.WriteToken(jsontext.Null)
 }
+
+// Call hierarchy requests made after `textDocument/prepareCallHierarchy` identify their
+// document through the item returned by the prepare request.
+func (s *CallHierarchyIncomingCallsParams) TextDocumentURI() DocumentUri {
+	return s.Item.Uri
+}
+
+func (s *CallHierarchyOutgoingCallsParams) TextDocumentURI() DocumentUri {
+	return s.Item.Uri
+}
+
+// Like call hierarchy requests, type hierarchy requests identify their document through the item.
+func (s *TypeHierarchySupertypesParams) TextDocumentURI() DocumentUri {
+	return s.Item.Uri
+}
+
+func (s *TypeHierarchySubtypesParams) TextDocumentURI() DocumentUri {
+	return s.Item.Uri
+}
@@ -82,3 +82,32 @@ func TestUnmarshalCompletionItem(t *testing.T) {
 		CommitCharacters: ptrTo([]string{".", ",", ";"}),
 	})
 }
+
+func TestUnmarshalCustomRequest(t *testing.T) {
+	t.Parallel()
+
+	const message = `{
+    "jsonrpc": "2.0",
+    "id": 1,
+    "method": "custom/textDocument/sourceDefinition",
+    "params": {
+        "textDocument": {
+            "uri": "file:///a.ts"
+        },
+        "position": {
+            "line": 1,
+            "character": 2
+        }
+    }
+}`
+
+	var result RequestMessage
+	err := json.Unmarshal([]byte(message), &result)
+	assert.NilError(t, err)
+
+	assert.Equal(t, result.Method, MethodCustomTextDocumentSourceDefinition)
+	assert.DeepEqual(t, result.Params, &TextDocumentPositionParams{
+		TextDocument: TextDocumentIdentifier{Uri: "file:///a.ts"},
+		Position:     Position{Line: 1, Character: 2},
+	})
+}
@@ -18,6 +18,9 @@ import (
 	"github.com/go-json-experiment/json"
 	"github.com/microsoft/typescript-go/internal/collections"
 	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/format"
+	"github.com/microsoft/typescript-go/internal/localization"
 	"github.com/microsoft/typescript-go/internal/ls"
 	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
 	"github.com/microsoft/typescript-go/internal/project"
@@ -153,6 +156,13 @@ type Server struct {
 
 	session *project.Session
 
+	workspaceDiagnostics ls.WorkspaceDiagnosticsCache
+
+	// configurationReady is closed once the settings last asked of the client have been handed to the session,
+	// or the client failed to answer in time.
+	configurationReady   chan struct{}
+	configurationReadyMu sync.Mutex
+
 	// !!! temporary; remove when we have `handleDidChangeConfiguration`/implicit project config support
 	compilerOptionsForInferredProjects *core.CompilerOptions
 	// parseCache can be passed in so separate tests can share ASTs
@@ -218,6 +228,54 @@ func (s *Server) RefreshDiagnostics(ctx context.Context) error {
 	return nil
 }
 
+// RefreshInlayHints implements project.Client.
+func (s *Server) RefreshInlayHints(ctx context.Context) error {
+	if s.initializeParams.Capabilities == nil ||
+		s.initializeParams.Capabilities.Workspace == nil ||
+		s.initializeParams.Capabilities.Workspace.InlayHint == nil ||
+		!ptrIsTrue(s.initializeParams.Capabilities.Workspace.InlayHint.RefreshSupport) {
+		return nil
+	}
+
+	if _, err := s.sendRequest(ctx, lsproto.MethodWorkspaceInlayHintRefresh, nil); err != nil {
+		return fmt.Errorf("failed to refresh inlay hints: %w", err)
+	}
+
+	return nil
+}
+
+// RefreshSemanticTokens implements project.Client.
+func (s *Server) RefreshSemanticTokens(ctx context.Context) error {
+	if s.initializeParams.Capabilities == nil ||
+		s.initializeParams.Capabilities.Workspace == nil ||
+		s.initializeParams.Capabilities.Workspace.SemanticTokens == nil ||
+		!ptrIsTrue(s.initializeParams.Capabilities.Workspace.SemanticTokens.RefreshSupport) {
+		return nil
+	}
+
+	if _, err := s.sendRequest(ctx, lsproto.MethodWorkspaceSemanticTokensRefresh, nil); err != nil {
+		return fmt.Errorf("failed to refresh semantic tokens: %w", err)
+	}
+
+	return nil
+}
+
+// RefreshCodeLens implements project.Client.
+func (s *Server) RefreshCodeLens(ctx context.Context) error {
+	if s.initializeParams.Capabilities == nil ||
+		s.initializeParams.Capabilities.Workspace == nil ||
+		s.initializeParams.Capabilities.Workspace.CodeLens == nil ||
+		!ptrIsTrue(s.initializeParams.Capabilities.Workspace.CodeLens.RefreshSupport) {
+		return nil
+	}
+
+	if _, err := s.sendRequest(ctx, lsproto.MethodWorkspaceCodeLensRefresh, nil); err != nil {
+		return fmt.Errorf("failed to refresh code lenses: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Server) Run() error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -446,12 +504,14 @@ var handlers = sync.OnceValue(func() handlerMap {
 	registerNotificationHandler(handlers, lsproto.TextDocumentDidSaveInfo, (*Server).handleDidSave)
 	registerNotificationHandler(handlers, lsproto.TextDocumentDidCloseInfo, (*Server).handleDidClose)
 	registerNotificationHandler(handlers, lsproto.WorkspaceDidChangeWatchedFilesInfo, (*Server).handleDidChangeWatchedFiles)
+	registerNotificationHandler(handlers, lsproto.WorkspaceDidChangeConfigurationInfo, (*Server).handleDidChangeConfiguration)
 	registerNotificationHandler(handlers, lsproto.SetTraceInfo, (*Server).handleSetTrace)
 
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentDiagnosticInfo, (*Server).handleDocumentDiagnostic)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentHoverInfo, (*Server).handleHover)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentDefinitionInfo, (*Server).handleDefinition)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentTypeDefinitionInfo, (*Server).handleTypeDefinition)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.CustomTextDocumentSourceDefinitionInfo, (*Server).handleSourceDefinition)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentCompletionInfo, (*Server).handleCompletion)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentReferencesInfo, (*Server).handleReferences)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentImplementationInfo, (*Server).handleImplementations)
@@ -462,8 +522,28 @@ var handlers = sync.OnceValue(func() handlerMap {
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentDocumentSymbolInfo, (*Server).handleDocumentSymbol)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentRenameInfo, (*Server).handleRename)
 	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentDocumentHighlightInfo, (*Server).handleDocumentHighlight)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentCodeActionInfo, (*Server).handleCodeAction)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentInlayHintInfo, (*Server).handleInlayHint)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentSemanticTokensFullInfo, (*Server).handleSemanticTokensFull)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentSemanticTokensRangeInfo, (*Server).handleSemanticTokensRange)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentPrepareCallHierarchyInfo, (*Server).handlePrepareCallHierarchy)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.CallHierarchyIncomingCallsInfo, (*Server).handleCallHierarchyIncomingCalls)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.CallHierarchyOutgoingCallsInfo, (*Server).handleCallHierarchyOutgoingCalls)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentPrepareTypeHierarchyInfo, (*Server).handlePrepareTypeHierarchy)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TypeHierarchySupertypesInfo, (*Server).handleTypeHierarchySupertypes)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TypeHierarchySubtypesInfo, (*Server).handleTypeHierarchySubtypes)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentFoldingRangeInfo, (*Server).handleFoldingRange)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentSelectionRangeInfo, (*Server).handleSelectionRange)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentCodeLensInfo, (*Server).handleCodeLens)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentDocumentLinkInfo, (*Server).handleDocumentLink)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.TextDocumentLinkedEditingRangeInfo, (*Server).handleLinkedEditingRange)
+	registerLanguageServiceDocumentRequestHandler(handlers, lsproto.CustomTextDocumentJsxClosingTagInfo, (*Server).handleJsxClosingTag)
 	registerRequestHandler(handlers, lsproto.WorkspaceSymbolInfo, (*Server).handleWorkspaceSymbol)
+	registerRequestHandler(handlers, lsproto.WorkspaceDiagnosticInfo, (*Server).handleWorkspaceDiagnostic)
 	registerRequestHandler(handlers, lsproto.CompletionItemResolveInfo, (*Server).handleCompletionItemResolve)
+	registerRequestHandler(handlers, lsproto.CodeLensResolveInfo, (*Server).handleCodeLensResolve)
+	registerRequestHandler(handlers, lsproto.WorkspaceExecuteCommandInfo, (*Server).handleExecuteCommand)
+	registerRequestHandler(handlers, lsproto.WorkspaceWillRenameFilesInfo, (*Server).handleWillRenameFiles)
 
 	return handlers
 })
@@ -524,6 +604,7 @@ func registerLanguageServiceDocumentRequestHandler[Req lsproto.HasTextDocumentUR
 		if err != nil {
 			return err
 		}
+		ctx = s.withFormatCodeSettings(ctx, params.TextDocumentURI())
 		defer s.recover(req)
 		resp, err := fn(s, ctx, ls, params)
 		if err != nil {
@@ -569,6 +650,9 @@ func (s *Server) handleInitialize(ctx context.Context, params *lsproto.Initializ
 			return nil, err
 		}
 		s.locale = locale
+		for _, diagnostic := range localization.SetLanguage(*s.initializeParams.Locale, s.fs, s.defaultLibraryPath) {
+			s.logger.Log(diagnostic.Message())
+		}
 	}
 
 	if s.initializeParams.Trace != nil && *s.initializeParams.Trace == "verbose" {
@@ -609,6 +693,7 @@ func (s *Server) handleInitialize(ctx context.Context, params *lsproto.Initializ
 			DiagnosticProvider: &lsproto.DiagnosticOptionsOrRegistrationOptions{
 				Options: &lsproto.DiagnosticOptions{
 					InterFileDependencies: true,
+					WorkspaceDiagnostics:  true,
 				},
 			},
 			CompletionProvider: &lsproto.CompletionOptions{
@@ -641,6 +726,69 @@ func (s *Server) handleInitialize(ctx context.Context, params *lsproto.Initializ
 			DocumentHighlightProvider: &lsproto.BooleanOrDocumentHighlightOptions{
 				Boolean: ptrTo(true),
 			},
+			InlayHintProvider: &lsproto.BooleanOrInlayHintOptionsOrInlayHintRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			CodeActionProvider: &lsproto.BooleanOrCodeActionOptions{
+				CodeActionOptions: &lsproto.CodeActionOptions{
+					CodeActionKinds: &ls.SupportedCodeActionKinds,
+				},
+			},
+			SemanticTokensProvider: &lsproto.SemanticTokensOptionsOrRegistrationOptions{
+				Options: &lsproto.SemanticTokensOptions{
+					Legend: &ls.SemanticTokensLegend,
+					Range: &lsproto.BooleanOrEmptyObject{
+						Boolean: ptrTo(true),
+					},
+					Full: &lsproto.BooleanOrSemanticTokensFullDelta{
+						Boolean: ptrTo(true),
+					},
+				},
+			},
+			CallHierarchyProvider: &lsproto.BooleanOrCallHierarchyOptionsOrCallHierarchyRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			TypeHierarchyProvider: &lsproto.BooleanOrTypeHierarchyOptionsOrTypeHierarchyRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			FoldingRangeProvider: &lsproto.BooleanOrFoldingRangeOptionsOrFoldingRangeRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			SelectionRangeProvider: &lsproto.BooleanOrSelectionRangeOptionsOrSelectionRangeRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			CodeLensProvider: &lsproto.CodeLensOptions{
+				ResolveProvider: ptrTo(true),
+			},
+			DocumentLinkProvider: &lsproto.DocumentLinkOptions{},
+			LinkedEditingRangeProvider: &lsproto.BooleanOrLinkedEditingRangeOptionsOrLinkedEditingRangeRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			ExecuteCommandProvider: &lsproto.ExecuteCommandOptions{
+				Commands: []string{ls.MoveToFileCommand},
+			},
+			Workspace: &lsproto.WorkspaceOptions{
+				FileOperations: &lsproto.FileOperationOptions{
+					WillRename: &lsproto.FileOperationRegistrationOptions{
+						Filters: []*lsproto.FileOperationFilter{
+							{
+								Scheme: ptrTo("file"),
+								Pattern: &lsproto.FileOperationPattern{
+									Glob:    "**/*.{ts,tsx,mts,cts,js,jsx,mjs,cjs,json}",
+									Matches: ptrTo(lsproto.FileOperationPatternKindfile),
+								},
+							},
+							{
+								Scheme: ptrTo("file"),
+								Pattern: &lsproto.FileOperationPattern{
+									Glob:    "**/*",
+									Matches: ptrTo(lsproto.FileOperationPatternKindfolder),
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 
@@ -691,6 +839,35 @@ func (s *Server) handleInitialized(ctx context.Context, params *lsproto.Initiali
 		s.session.DidChangeCompilerOptionsForInferredProjects(ctx, s.compilerOptionsForInferredProjects)
 	}
 
+	if s.initializeParams.Capabilities != nil &&
+		s.initializeParams.Capabilities.Workspace != nil &&
+		s.initializeParams.Capabilities.Workspace.DidChangeConfiguration != nil &&
+		ptrIsTrue(s.initializeParams.Capabilities.Workspace.DidChangeConfiguration.DynamicRegistration) {
+		// Without a registration, clients using the pull model do not tell us when settings change.
+		// The client is not waited for, as it may not answer until the notification is handled.
+		go func() {
+			ctx, cancel := context.WithTimeout(ctx, clientRequestTimeout)
+			defer cancel()
+			if _, err := s.sendRequest(ctx, lsproto.MethodClientRegisterCapability, &lsproto.RegistrationParams{
+				Registrations: []*lsproto.Registration{
+					{
+						Id:     string(lsproto.MethodWorkspaceDidChangeConfiguration),
+						Method: string(lsproto.MethodWorkspaceDidChangeConfiguration),
+						RegisterOptions: ptrTo(any(lsproto.DidChangeConfigurationRegistrationOptions{
+							Section: &lsproto.StringOrStrings{Strings: ptrTo([]string{typeScriptConfigurationSection, javaScriptConfigurationSection})},
+						})),
+					},
+				},
+			}); err != nil {
+				s.Log("failed to register for configuration changes:", err)
+			}
+		}()
+	}
+
+	if supportsConfigurationPull(s.initializeParams) {
+		s.requestConfiguration(ctx)
+	}
+
 	return nil
 }
 
@@ -728,6 +905,154 @@ func (s *Server) handleDidChangeWatchedFiles(ctx context.Context, params *lsprot
 	return nil
 }
 
+func (s *Server) handleDidChangeConfiguration(ctx context.Context, params *lsproto.DidChangeConfigurationParams) error {
+	// Clients that support the pull model usually send no settings with the notification, and the settings
+	// they do send may not be scoped to a workspace folder, so ask for them instead.
+	if supportsConfigurationPull(s.initializeParams) {
+		s.requestConfiguration(ctx)
+		return nil
+	}
+	typeScriptSettings, javaScriptSettings := params.Settings, params.Settings
+	if sections, ok := params.Settings.(map[string]any); ok {
+		typeScriptSettings = sections[typeScriptConfigurationSection]
+		javaScriptSettings = sections[javaScriptConfigurationSection]
+	}
+	if configuration := parseWorkspaceConfiguration(typeScriptSettings, javaScriptSettings); configuration != nil {
+		s.session.DidChangeConfiguration(ctx, map[lsproto.DocumentUri]*project.WorkspaceConfiguration{"": configuration})
+	}
+	return nil
+}
+
+// The sections of the editor settings that hold the user preferences and format settings of TypeScript
+// and JavaScript files, e.g. `typescript.preferences.quoteStyle` and `javascript.format.semicolons`.
+const (
+	typeScriptConfigurationSection = "typescript"
+	javaScriptConfigurationSection = "javascript"
+)
+
+// clientRequestTimeout bounds how long the server waits for the client to answer a request made while
+// handling a notification.
+const clientRequestTimeout = 5 * time.Second
+
+// requestConfiguration asks the client for its settings without blocking the dispatch loop. Requests that
+// use the settings wait until they have been received, so that they are not handled with stale settings.
+func (s *Server) requestConfiguration(ctx context.Context) {
+	ready := make(chan struct{})
+	s.configurationReadyMu.Lock()
+	previous := s.configurationReady
+	s.configurationReady = ready
+	s.configurationReadyMu.Unlock()
+	go func() {
+		defer close(ready)
+		// Apply the settings in the order they were asked for.
+		if previous != nil {
+			<-previous
+		}
+		ctx, cancel := context.WithTimeout(ctx, clientRequestTimeout)
+		defer cancel()
+		s.pullConfiguration(ctx)
+	}()
+}
+
+// waitForConfiguration waits for the settings last asked of the client, if any.
+func (s *Server) waitForConfiguration(ctx context.Context) {
+	s.configurationReadyMu.Lock()
+	ready := s.configurationReady
+	s.configurationReadyMu.Unlock()
+	if ready != nil {
+		select {
+		case <-ready:
+		case <-ctx.Done():
+		}
+	}
+}
+
+// pullConfiguration asks the client for the settings of each workspace folder, and of files outside of
+// them, and hands them to the session. The current settings are kept if the client fails to answer.
+func (s *Server) pullConfiguration(ctx context.Context) {
+	scopes := []lsproto.DocumentUri{""}
+	if s.initializeParams.WorkspaceFolders != nil && s.initializeParams.WorkspaceFolders.WorkspaceFolders != nil {
+		for _, folder := range *s.initializeParams.WorkspaceFolders.WorkspaceFolders {
+			scopes = append(scopes, lsproto.DocumentUri(folder.Uri))
+		}
+	}
+	// Each scope asks for the TypeScript section followed by the JavaScript section.
+	items := make([]*lsproto.ConfigurationItem, 0, 2*len(scopes))
+	for _, scope := range scopes {
+		for _, section := range []string{typeScriptConfigurationSection, javaScriptConfigurationSection} {
+			item := &lsproto.ConfigurationItem{Section: ptrTo(section)}
+			if scope != "" {
+				item.ScopeUri = ptrTo(lsproto.URI(scope))
+			}
+			items = append(items, item)
+		}
+	}
+
+	result, err := s.sendRequest(ctx, lsproto.MethodWorkspaceConfiguration, &lsproto.ConfigurationParams{Items: items})
+	if err != nil {
+		s.Log("failed to request configuration:", err)
+		return
+	}
+	settings, ok := result.([]any)
+	if !ok || len(settings) != len(items) {
+		s.Log("unexpected configuration response:", result)
+		return
+	}
+	configurations := make(map[lsproto.DocumentUri]*project.WorkspaceConfiguration, len(scopes))
+	for i, scope := range scopes {
+		if configuration := parseWorkspaceConfiguration(settings[2*i], settings[2*i+1]); configuration != nil {
+			configurations[scope] = configuration
+		}
+	}
+	s.session.DidChangeConfiguration(ctx, configurations)
+}
+
+// parseWorkspaceConfiguration converts the settings of the TypeScript and JavaScript configuration sections
+// into a WorkspaceConfiguration. Returns nil if there are no settings for either language.
+func parseWorkspaceConfiguration(typeScriptSettings any, javaScriptSettings any) *project.WorkspaceConfiguration {
+	configuration := &project.WorkspaceConfiguration{
+		TypeScript: parseLanguageConfiguration(typeScriptSettings),
+		JavaScript: parseLanguageConfiguration(javaScriptSettings),
+	}
+	if configuration.TypeScript == nil && configuration.JavaScript == nil {
+		return nil
+	}
+	return configuration
+}
+
+// parseLanguageConfiguration converts the settings of a configuration section, which are decoded from JSON
+// as a generic value, into a LanguageConfiguration. Returns nil if there are no settings.
+func parseLanguageConfiguration(settings any) *project.LanguageConfiguration {
+	settingsMap, ok := settings.(map[string]any)
+	if !ok {
+		return nil
+	}
+	configuration := project.NewDefaultLanguageConfiguration()
+	configuration.UserPreferences.Parse(settingsMap)
+	if formatSettings, ok := settingsMap["format"].(map[string]any); ok {
+		// The newline is filled in from the program when the settings are used.
+		configuration.FormatCodeSettings = format.GetDefaultFormatCodeSettings("")
+		configuration.FormatCodeSettings.Parse(formatSettings)
+	}
+	return configuration
+}
+
+func (s *Server) getConfiguration(ctx context.Context, uri lsproto.DocumentUri) *project.LanguageConfiguration {
+	s.waitForConfiguration(ctx)
+	return s.session.Configuration(uri)
+}
+
+func (s *Server) withFormatCodeSettings(ctx context.Context, uri lsproto.DocumentUri) context.Context {
+	if settings := s.getConfiguration(ctx, uri).FormatCodeSettings; settings != nil {
+		return format.WithFormatCodeSettings(ctx, settings, settings.NewLineCharacter)
+	}
+	return ctx
+}
+
+func (s *Server) getUserPreferences(ctx context.Context, uri lsproto.DocumentUri) *ls.UserPreferences {
+	return s.getConfiguration(ctx, uri).UserPreferences
+}
+
 func (s *Server) handleSetTrace(ctx context.Context, params *lsproto.SetTraceParams) error {
 	switch params.Value {
 	case "verbose":
@@ -744,7 +1069,7 @@ func (s *Server) handleSetTrace(ctx context.Context, params *lsproto.SetTracePar
 }
 
 func (s *Server) handleDocumentDiagnostic(ctx context.Context, ls *ls.LanguageService, params *lsproto.DocumentDiagnosticParams) (lsproto.DocumentDiagnosticResponse, error) {
-	return ls.ProvideDiagnostics(ctx, params.TextDocument.Uri)
+	return ls.ProvideDiagnostics(ctx, params.TextDocument.Uri, params.PreviousResultId)
 }
 
 func (s *Server) handleHover(ctx context.Context, ls *ls.LanguageService, params *lsproto.HoverParams) (lsproto.HoverResponse, error) {
@@ -758,7 +1083,7 @@ func (s *Server) handleSignatureHelp(ctx context.Context, languageService *ls.La
 		params.Position,
 		params.Context,
 		s.initializeParams.Capabilities.TextDocument.SignatureHelp,
-		&ls.UserPreferences{},
+		s.getUserPreferences(ctx, params.TextDocument.Uri),
 	)
 }
 
@@ -770,6 +1095,10 @@ func (s *Server) handleTypeDefinition(ctx context.Context, ls *ls.LanguageServic
 	return ls.ProvideTypeDefinition(ctx, params.TextDocument.Uri, params.Position)
 }
 
+func (s *Server) handleSourceDefinition(ctx context.Context, ls *ls.LanguageService, params *lsproto.TextDocumentPositionParams) (lsproto.DefinitionResponse, error) {
+	return ls.ProvideSourceDefinition(ctx, params.TextDocument.Uri, params.Position)
+}
+
 func (s *Server) handleReferences(ctx context.Context, ls *ls.LanguageService, params *lsproto.ReferenceParams) (lsproto.ReferencesResponse, error) {
 	// findAllReferences
 	return ls.ProvideReferences(ctx, params)
@@ -781,17 +1110,14 @@ func (s *Server) handleImplementations(ctx context.Context, ls *ls.LanguageServi
 }
 
 func (s *Server) handleCompletion(ctx context.Context, languageService *ls.LanguageService, params *lsproto.CompletionParams) (lsproto.CompletionResponse, error) {
-	// !!! get user preferences
 	return languageService.ProvideCompletion(
 		ctx,
 		params.TextDocument.Uri,
 		params.Position,
 		params.Context,
 		getCompletionClientCapabilities(s.initializeParams),
-		&ls.UserPreferences{
-			IncludeCompletionsForModuleExports:    core.TSTrue,
-			IncludeCompletionsForImportStatements: core.TSTrue,
-		})
+		s.getUserPreferences(ctx, params.TextDocument.Uri),
+	)
 }
 
 func (s *Server) handleCompletionItemResolve(ctx context.Context, params *lsproto.CompletionItem, reqMsg *lsproto.RequestMessage) (lsproto.CompletionResolveResponse, error) {
@@ -799,23 +1125,51 @@ func (s *Server) handleCompletionItemResolve(ctx context.Context, params *lsprot
 	if err != nil {
 		return nil, err
 	}
-	languageService, err := s.session.GetLanguageService(ctx, ls.FileNameToDocumentURI(data.FileName))
+	uri := ls.FileNameToDocumentURI(data.FileName)
+	languageService, err := s.session.GetLanguageService(ctx, uri)
 	if err != nil {
 		return nil, err
 	}
+	ctx = s.withFormatCodeSettings(ctx, uri)
 	defer s.recover(reqMsg)
 	return languageService.ResolveCompletionItem(
 		ctx,
 		params,
 		data,
 		getCompletionClientCapabilities(s.initializeParams),
-		&ls.UserPreferences{
-			IncludeCompletionsForModuleExports:    core.TSTrue,
-			IncludeCompletionsForImportStatements: core.TSTrue,
-		},
+		s.getUserPreferences(ctx, uri),
 	)
 }
 
+func (s *Server) handleCodeLens(ctx context.Context, ls *ls.LanguageService, params *lsproto.CodeLensParams) (lsproto.CodeLensResponse, error) {
+	return ls.ProvideCodeLenses(ctx, params.TextDocument.Uri)
+}
+
+func (s *Server) handleCodeLensResolve(ctx context.Context, params *lsproto.CodeLens, reqMsg *lsproto.RequestMessage) (lsproto.CodeLensResolveResponse, error) {
+	data, err := ls.GetCodeLensData(params)
+	if err != nil {
+		return nil, err
+	}
+	languageService, err := s.session.GetLanguageService(ctx, data.Uri)
+	if err != nil {
+		return nil, err
+	}
+	defer s.recover(reqMsg)
+	return languageService.ResolveCodeLens(ctx, params, data)
+}
+
+func (s *Server) handleDocumentLink(ctx context.Context, ls *ls.LanguageService, params *lsproto.DocumentLinkParams) (lsproto.DocumentLinkResponse, error) {
+	return ls.ProvideDocumentLinks(ctx, params.TextDocument.Uri)
+}
+
+func (s *Server) handleLinkedEditingRange(ctx context.Context, ls *ls.LanguageService, params *lsproto.LinkedEditingRangeParams) (lsproto.LinkedEditingRangeResponse, error) {
+	return ls.ProvideLinkedEditingRange(ctx, params.TextDocument.Uri, params.Position)
+}
+
+func (s *Server) handleJsxClosingTag(ctx context.Context, ls *ls.LanguageService, params *lsproto.TextDocumentPositionParams) (*lsproto.JsxClosingTag, error) {
+	return ls.ProvideJsxClosingTag(ctx, params.TextDocument.Uri, params.Position)
+}
+
 func (s *Server) handleDocumentFormat(ctx context.Context, ls *ls.LanguageService, params *lsproto.DocumentFormattingParams) (lsproto.DocumentFormattingResponse, error) {
 	return ls.ProvideFormatDocument(
 		ctx,
@@ -851,6 +1205,51 @@ func (s *Server) handleWorkspaceSymbol(ctx context.Context, params *lsproto.Work
 	return ls.ProvideWorkspaceSymbols(ctx, programs, snapshot.Converters(), params.Query)
 }
 
+func (s *Server) handleWorkspaceDiagnostic(ctx context.Context, params *lsproto.WorkspaceDiagnosticParams, reqMsg *lsproto.RequestMessage) (lsproto.WorkspaceDiagnosticResponse, error) {
+	snapshot, release := s.session.GetWorkspaceSnapshot(ctx)
+	defer release()
+	defer s.recover(reqMsg)
+	programs := core.Map(snapshot.ProjectCollection.Projects(), (*project.Project).GetProgram)
+	getVersion := func(fileName string) *int32 {
+		if file := snapshot.GetFile(fileName); file != nil && file.IsOverlay() {
+			return ptrTo(file.Version())
+		}
+		return nil
+	}
+	return s.workspaceDiagnostics.ProvideWorkspaceDiagnostics(ctx, programs, snapshot.Converters(), params.PreviousResultIds, getVersion)
+}
+
+func (s *Server) handleWillRenameFiles(ctx context.Context, params *lsproto.RenameFilesParams, reqMsg *lsproto.RequestMessage) (lsproto.WillRenameFilesResponse, error) {
+	if len(params.Files) == 0 {
+		return lsproto.WorkspaceEditOrNull{}, nil
+	}
+	// Renames are done within a single workspace folder, so the settings of any of the files apply.
+	uri := lsproto.DocumentUri(params.Files[0].OldUri)
+	preferences := s.getUserPreferences(ctx, uri)
+	ctx = s.withFormatCodeSettings(ctx, uri)
+	snapshot, release := s.session.Snapshot()
+	defer release()
+	defer s.recover(reqMsg)
+	// A file may be part of several projects, each of which may have files importing it. Edits to files
+	// shared by projects are usually the same in each, so only the first copy of an edit is kept.
+	changes := map[lsproto.DocumentUri][]*lsproto.TextEdit{}
+	for _, project := range snapshot.ProjectCollection.Projects() {
+		languageService := ls.NewLanguageService(project.GetProgram(), snapshot)
+		edit := languageService.GetEditsForFileRename(ctx, params.Files, preferences)
+		for uri, edits := range *edit.Changes {
+			for _, textEdit := range edits {
+				if !slices.ContainsFunc(changes[uri], func(existing *lsproto.TextEdit) bool { return *existing == *textEdit }) {
+					changes[uri] = append(changes[uri], textEdit)
+				}
+			}
+		}
+	}
+	if len(changes) == 0 {
+		return lsproto.WorkspaceEditOrNull{}, nil
+	}
+	return lsproto.WorkspaceEditOrNull{WorkspaceEdit: &lsproto.WorkspaceEdit{Changes: &changes}}, nil
+}
+
 func (s *Server) handleDocumentSymbol(ctx context.Context, ls *ls.LanguageService, params *lsproto.DocumentSymbolParams) (lsproto.DocumentSymbolResponse, error) {
 	return ls.ProvideDocumentSymbols(ctx, params.TextDocument.Uri)
 }
@@ -863,6 +1262,113 @@ func (s *Server) handleDocumentHighlight(ctx context.Context, ls *ls.LanguageSer
 	return ls.ProvideDocumentHighlights(ctx, params.TextDocument.Uri, params.Position)
 }
 
+func (s *Server) handleCodeAction(ctx context.Context, languageService *ls.LanguageService, params *lsproto.CodeActionParams) (lsproto.CodeActionResponse, error) {
+	return languageService.ProvideCodeActions(ctx, params, s.getUserPreferences(ctx, params.TextDocument.Uri))
+}
+
+func (s *Server) handleExecuteCommand(ctx context.Context, params *lsproto.ExecuteCommandParams, _ *lsproto.RequestMessage) (lsproto.ExecuteCommandResponse, error) {
+	switch params.Command {
+	case ls.MoveToFileCommand:
+		if params.Arguments == nil || len(*params.Arguments) != 1 {
+			return lsproto.ExecuteCommandResponse{}, fmt.Errorf("%w: %s expects one argument", lsproto.ErrInvalidParams, params.Command)
+		}
+		arguments, err := decodeMoveToFileArguments((*params.Arguments)[0])
+		if err != nil {
+			return lsproto.ExecuteCommandResponse{}, fmt.Errorf("%w: %w", lsproto.ErrInvalidParams, err)
+		}
+		languageService, err := s.session.GetLanguageService(ctx, arguments.TextDocument.Uri)
+		if err != nil {
+			return lsproto.ExecuteCommandResponse{}, err
+		}
+		edit, err := s.getMoveToFileEdits(ctx, languageService, arguments)
+		if err != nil {
+			return lsproto.ExecuteCommandResponse{}, err
+		}
+		if _, err := s.sendRequest(ctx, lsproto.MethodWorkspaceApplyEdit, &lsproto.ApplyWorkspaceEditParams{
+			Label: ptrTo(diagnostics.Move_to_file.Message()),
+			Edit:  edit,
+		}); err != nil {
+			return lsproto.ExecuteCommandResponse{}, err
+		}
+		return lsproto.ExecuteCommandResponse{}, nil
+	default:
+		return lsproto.ExecuteCommandResponse{}, fmt.Errorf("%w: unknown command %s", lsproto.ErrInvalidParams, params.Command)
+	}
+}
+
+// getMoveToFileEdits returns a panic computing the edits as an error, so that the request is answered once and
+// no edit is applied.
+func (s *Server) getMoveToFileEdits(ctx context.Context, languageService *ls.LanguageService, arguments *ls.MoveToFileArguments) (edit *lsproto.WorkspaceEdit, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.Log("panic computing edits to move to file", r, string(debug.Stack()))
+			err = fmt.Errorf("%w: panic computing edits to move to file: %v", lsproto.ErrInternalError, r)
+		}
+	}()
+	return languageService.GetMoveToFileEdits(ctx, arguments)
+}
+
+// decodeMoveToFileArguments converts a command argument, which is decoded from JSON as a generic value, into
+// MoveToFileArguments.
+func decodeMoveToFileArguments(argument any) (*ls.MoveToFileArguments, error) {
+	if arguments, ok := argument.(*ls.MoveToFileArguments); ok {
+		return arguments, nil
+	}
+	data, err := json.Marshal(argument)
+	if err != nil {
+		return nil, err
+	}
+	var arguments ls.MoveToFileArguments
+	if err := json.Unmarshal(data, &arguments); err != nil {
+		return nil, err
+	}
+	return &arguments, nil
+}
+
+func (s *Server) handleInlayHint(ctx context.Context, languageService *ls.LanguageService, params *lsproto.InlayHintParams) (lsproto.InlayHintResponse, error) {
+	return languageService.ProvideInlayHint(ctx, params, s.getUserPreferences(ctx, params.TextDocument.Uri))
+}
+
+func (s *Server) handleSemanticTokensFull(ctx context.Context, ls *ls.LanguageService, params *lsproto.SemanticTokensParams) (lsproto.SemanticTokensResponse, error) {
+	return ls.ProvideSemanticTokens(ctx, params.TextDocument.Uri)
+}
+
+func (s *Server) handleSemanticTokensRange(ctx context.Context, ls *ls.LanguageService, params *lsproto.SemanticTokensRangeParams) (lsproto.SemanticTokensRangeResponse, error) {
+	return ls.ProvideSemanticTokensRange(ctx, params.TextDocument.Uri, params.Range)
+}
+
+func (s *Server) handlePrepareCallHierarchy(ctx context.Context, ls *ls.LanguageService, params *lsproto.CallHierarchyPrepareParams) (lsproto.CallHierarchyPrepareResponse, error) {
+	return ls.ProvidePrepareCallHierarchy(ctx, params.TextDocument.Uri, params.Position)
+}
+
+func (s *Server) handleCallHierarchyIncomingCalls(ctx context.Context, ls *ls.LanguageService, params *lsproto.CallHierarchyIncomingCallsParams) (lsproto.CallHierarchyIncomingCallsResponse, error) {
+	return ls.ProvideCallHierarchyIncomingCalls(ctx, params.Item)
+}
+
+func (s *Server) handleCallHierarchyOutgoingCalls(ctx context.Context, ls *ls.LanguageService, params *lsproto.CallHierarchyOutgoingCallsParams) (lsproto.CallHierarchyOutgoingCallsResponse, error) {
+	return ls.ProvideCallHierarchyOutgoingCalls(ctx, params.Item)
+}
+
+func (s *Server) handlePrepareTypeHierarchy(ctx context.Context, ls *ls.LanguageService, params *lsproto.TypeHierarchyPrepareParams) (lsproto.TypeHierarchyPrepareResponse, error) {
+	return ls.ProvidePrepareTypeHierarchy(ctx, params.TextDocument.Uri, params.Position)
+}
+
+func (s *Server) handleTypeHierarchySupertypes(ctx context.Context, ls *ls.LanguageService, params *lsproto.TypeHierarchySupertypesParams) (lsproto.TypeHierarchySupertypesResponse, error) {
+	return ls.ProvideTypeHierarchySupertypes(ctx, params.Item)
+}
+
+func (s *Server) handleTypeHierarchySubtypes(ctx context.Context, ls *ls.LanguageService, params *lsproto.TypeHierarchySubtypesParams) (lsproto.TypeHierarchySubtypesResponse, error) {
+	return ls.ProvideTypeHierarchySubtypes(ctx, params.Item)
+}
+
+func (s *Server) handleFoldingRange(ctx context.Context, ls *ls.LanguageService, params *lsproto.FoldingRangeParams) (lsproto.FoldingRangeResponse, error) {
+	return ls.ProvideFoldingRange(ctx, params.TextDocument.Uri)
+}
+
+func (s *Server) handleSelectionRange(ctx context.Context, ls *ls.LanguageService, params *lsproto.SelectionRangeParams) (lsproto.SelectionRangeResponse, error) {
+	return ls.ProvideSelectionRanges(ctx, params)
+}
+
 func (s *Server) Log(msg ...any) {
 	fmt.Fprintln(s.stderr, msg...)
 }
@@ -890,7 +1396,8 @@ func isBlockingMethod(method lsproto.Method) bool {
 		lsproto.MethodTextDocumentDidChange,
 		lsproto.MethodTextDocumentDidSave,
 		lsproto.MethodTextDocumentDidClose,
-		lsproto.MethodWorkspaceDidChangeWatchedFiles:
+		lsproto.MethodWorkspaceDidChangeWatchedFiles,
+		lsproto.MethodWorkspaceDidChangeConfiguration:
 		return true
 	}
 	return false
@@ -915,6 +1422,13 @@ func shouldEnableWatch(params *lsproto.InitializeParams) bool {
 		ptrIsTrue(params.Capabilities.Workspace.DidChangeWatchedFiles.DynamicRegistration)
 }
 
+func supportsConfigurationPull(params *lsproto.InitializeParams) bool {
+	if params == nil || params.Capabilities == nil || params.Capabilities.Workspace == nil {
+		return false
+	}
+	return ptrIsTrue(params.Capabilities.Workspace.Configuration)
+}
+
 func getCompletionClientCapabilities(params *lsproto.InitializeParams) *lsproto.CompletionClientCapabilities {
 	if params == nil || params.Capabilities == nil || params.Capabilities.TextDocument == nil {
 		return nil
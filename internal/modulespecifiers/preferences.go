@@ -144,6 +144,20 @@ type ModuleSpecifierPreferences struct {
 	excludeRegexes                    []string
 }
 
+// getRelativePreference returns the kind of module specifier the user asked for, if any.
+func getRelativePreference(prefs UserPreferences) (RelativePreferenceKind, bool) {
+	switch prefs.ImportModuleSpecifierPreference {
+	case ImportModuleSpecifierPreferenceRelative:
+		return RelativePreferenceRelative, true
+	case ImportModuleSpecifierPreferenceNonRelative:
+		return RelativePreferenceNonRelative, true
+	case ImportModuleSpecifierPreferenceProjectRelative:
+		return RelativePreferenceExternalNonRelative, true
+	}
+	// all others are shortest
+	return RelativePreferenceShortest, false
+}
+
 func getModuleSpecifierPreferences(
 	prefs UserPreferences,
 	host ModuleSpecifierGenerationHost,
@@ -159,16 +173,8 @@ func getModuleSpecifierPreferences(
 		} else {
 			relativePreference = RelativePreferenceNonRelative
 		}
-	} else {
-		switch prefs.ImportModuleSpecifierPreference {
-		case ImportModuleSpecifierPreferenceRelative:
-			relativePreference = RelativePreferenceRelative
-		case ImportModuleSpecifierPreferenceNonRelative:
-			relativePreference = RelativePreferenceNonRelative
-		case ImportModuleSpecifierPreferenceProjectRelative:
-			relativePreference = RelativePreferenceExternalNonRelative
-			// all others are shortest
-		}
+	} else if preference, ok := getRelativePreference(prefs); ok {
+		relativePreference = preference
 	}
 	filePreferredEnding := getPreferredEnding(
 		prefs,
@@ -1266,9 +1266,46 @@ func GetModuleSpecifier(
 	options ModuleSpecifierOptions,
 ) string {
 	userPreferences := UserPreferences{}
+	preferences := getModuleSpecifierPreferences(userPreferences, host, compilerOptions, importingSourceFile, oldImportSpecifier)
+	return getModuleSpecifierWorker(compilerOptions, host, importingSourceFile, importingSourceFileName, toFileName, userPreferences, preferences, options)
+}
+
+// UpdateModuleSpecifier computes a new module specifier for an import in `importingSourceFile` after the
+// importing file moves to `importingSourceFileName` or the imported file moves to `toFileName`. Unless the
+// user prefers a kind of specifier, the new specifier is relative if `oldImportSpecifier` is. Returns ""
+// if no specifier can be computed or it would not change.
+func UpdateModuleSpecifier(
+	compilerOptions *core.CompilerOptions,
+	host ModuleSpecifierGenerationHost,
+	importingSourceFile *ast.SourceFile,
+	importingSourceFileName string,
+	toFileName string,
+	oldImportSpecifier string,
+	userPreferences UserPreferences,
+) string {
+	preferences := getModuleSpecifierPreferences(userPreferences, host, compilerOptions, importingSourceFile, oldImportSpecifier)
+	if relativePreference, ok := getRelativePreference(userPreferences); ok {
+		preferences.relativePreference = relativePreference
+	}
+	result := getModuleSpecifierWorker(compilerOptions, host, importingSourceFile, importingSourceFileName, toFileName, userPreferences, preferences, ModuleSpecifierOptions{})
+	if result == oldImportSpecifier {
+		return ""
+	}
+	return result
+}
+
+func getModuleSpecifierWorker(
+	compilerOptions *core.CompilerOptions,
+	host ModuleSpecifierGenerationHost,
+	importingSourceFile *ast.SourceFile,
+	importingSourceFileName string,
+	toFileName string,
+	userPreferences UserPreferences,
+	preferences ModuleSpecifierPreferences,
+	options ModuleSpecifierOptions,
+) string {
 	info := getInfo(importingSourceFileName, host)
 	modulePaths := getAllModulePaths(info, toFileName, host, compilerOptions, userPreferences, options)
-	preferences := getModuleSpecifierPreferences(userPreferences, host, compilerOptions, importingSourceFile, oldImportSpecifier)
 
 	resolutionMode := options.OverrideImportMode
 	if resolutionMode == core.ResolutionModeNone {
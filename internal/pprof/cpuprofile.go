@@ -0,0 +1,179 @@
+package pprof
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime/pprof"
+	"strconv"
+	"strings"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/vfs"
+)
+
+type cpuProfileSession struct {
+	fs        vfs.FS
+	filePath  string
+	buffer    bytes.Buffer
+	logWriter io.Writer
+}
+
+// BeginCPUProfiling starts CPU profiling for --generateCpuProfile, writing the profile to filePath in fs when stopped.
+// If filePath ends with .cpuprofile, as TypeScript's profiles do, the profile is converted to the format of V8 CPU
+// profiles that Chrome DevTools, VS Code and other JS tooling read. Otherwise it is written in the pprof format.
+// Only one CPU profile can be recorded at a time, so it fails if another one is being recorded.
+func BeginCPUProfiling(fs vfs.FS, filePath string, logWriter io.Writer) (*cpuProfileSession, error) {
+	session := &cpuProfileSession{
+		fs:        fs,
+		filePath:  filePath,
+		logWriter: logWriter,
+	}
+	if err := pprof.StartCPUProfile(&session.buffer); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Stop stops profiling and writes the profile.
+func (p *cpuProfileSession) Stop() error {
+	pprof.StopCPUProfile()
+
+	data := p.buffer.Bytes()
+	if strings.EqualFold(filepath.Ext(p.filePath), ".cpuprofile") {
+		var err error
+		if data, err = ConvertToCPUProfile(data); err != nil {
+			return err
+		}
+	}
+	if err := p.fs.WriteFile(p.filePath, string(data), false); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.logWriter, "CPU profile: %v\n", p.filePath)
+	return nil
+}
+
+type cpuProfile struct {
+	Nodes      []*cpuProfileNode `json:"nodes"`
+	StartTime  int64             `json:"startTime"`
+	EndTime    int64             `json:"endTime"`
+	Samples    []int             `json:"samples"`
+	TimeDeltas []int64           `json:"timeDeltas"`
+}
+
+type cpuProfileNode struct {
+	ID        int       `json:"id"`
+	CallFrame callFrame `json:"callFrame"`
+	HitCount  int64     `json:"hitCount"`
+	Children  []int     `json:"children,omitzero"`
+
+	childrenByCallFrame map[callFrame]*cpuProfileNode
+}
+
+type callFrame struct {
+	FunctionName string `json:"functionName"`
+	ScriptID     string `json:"scriptId"`
+	URL          string `json:"url"`
+	LineNumber   int64  `json:"lineNumber"`
+	ColumnNumber int64  `json:"columnNumber"`
+}
+
+// ConvertToCPUProfile converts a pprof CPU profile to the format of V8 CPU profiles (.cpuprofile). A pprof profile
+// records how often each stack was sampled but not when, so the samples of a stack are laid out one after another,
+// and the samples of all threads share one timeline.
+func ConvertToCPUProfile(pprofData []byte) ([]byte, error) {
+	profile, err := parseProfile(pprofData)
+	if err != nil {
+		return nil, err
+	}
+	countIndex := profile.sampleTypeIndex("samples")
+	timeIndex := profile.sampleTypeIndex("cpu")
+	if countIndex < 0 {
+		return nil, fmt.Errorf("pprof: profile has no sample counts")
+	}
+
+	root := &cpuProfileNode{
+		ID:        1,
+		CallFrame: callFrame{FunctionName: "(root)", ScriptID: "0", LineNumber: -1, ColumnNumber: -1},
+	}
+	result := &cpuProfile{
+		Nodes:      []*cpuProfileNode{root},
+		StartTime:  profile.timeNanos / 1000,
+		Samples:    []int{},
+		TimeDeltas: []int64{},
+	}
+	scriptIDs := map[string]string{}
+	getCallFrame := func(l line) callFrame {
+		fn := profile.functions[l.functionID]
+		if fn == nil {
+			return callFrame{FunctionName: "(unknown)", ScriptID: "0", LineNumber: -1, ColumnNumber: -1}
+		}
+		fileName := profile.string(fn.filename)
+		scriptID, ok := scriptIDs[fileName]
+		if !ok {
+			scriptID = strconv.Itoa(len(scriptIDs) + 1)
+			scriptIDs[fileName] = scriptID
+		}
+		// V8 lines are zero-based.
+		return callFrame{FunctionName: profile.string(fn.name), ScriptID: scriptID, URL: fileURL(fileName), LineNumber: max(l.line-1, 0)}
+	}
+	getChild := func(parent *cpuProfileNode, frame callFrame) *cpuProfileNode {
+		if child, ok := parent.childrenByCallFrame[frame]; ok {
+			return child
+		}
+		child := &cpuProfileNode{ID: len(result.Nodes) + 1, CallFrame: frame}
+		result.Nodes = append(result.Nodes, child)
+		if parent.childrenByCallFrame == nil {
+			parent.childrenByCallFrame = make(map[callFrame]*cpuProfileNode)
+		}
+		parent.childrenByCallFrame[frame] = child
+		parent.Children = append(parent.Children, child.ID)
+		return child
+	}
+
+	elapsed := int64(0)
+	for _, s := range profile.samples {
+		if countIndex >= len(s.values) || s.values[countIndex] <= 0 {
+			continue
+		}
+		// The stack starts at the leaf, and the lines of a location start at the innermost inlined function.
+		node := root
+		for i := len(s.locationIDs) - 1; i >= 0; i-- {
+			location := profile.locations[s.locationIDs[i]]
+			if location == nil {
+				continue
+			}
+			for j := len(location.lines) - 1; j >= 0; j-- {
+				node = getChild(node, getCallFrame(location.lines[j]))
+			}
+		}
+		count := s.values[countIndex]
+		node.HitCount += count
+		delta := profile.period
+		if timeIndex >= 0 && timeIndex < len(s.values) {
+			delta = s.values[timeIndex] / count
+		}
+		delta /= 1000
+		for range count {
+			result.Samples = append(result.Samples, node.ID)
+			result.TimeDeltas = append(result.TimeDeltas, delta)
+			elapsed += delta
+		}
+	}
+	result.EndTime = result.StartTime + max(elapsed, profile.durationNanos/1000)
+	return json.Marshal(result)
+}
+
+// fileURL returns the file URL of a path, which is how V8 identifies scripts.
+func fileURL(path string) string {
+	if path == "" {
+		return ""
+	}
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return "file://" + path
+}
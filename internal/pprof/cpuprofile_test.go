@@ -0,0 +1,92 @@
+package pprof_test
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"testing"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	internalpprof "github.com/microsoft/typescript-go/internal/pprof"
+	"gotest.tools/v3/assert"
+)
+
+// protobuf encodes the fields of a protobuf message.
+type protobuf []byte
+
+func (b protobuf) varint(value uint64) protobuf {
+	for value >= 0x80 {
+		b = append(b, byte(value)|0x80)
+		value >>= 7
+	}
+	return append(b, byte(value))
+}
+
+func (b protobuf) uint64Field(field int, value uint64) protobuf {
+	return b.varint(uint64(field << 3)).varint(value)
+}
+
+func (b protobuf) bytesField(field int, value []byte) protobuf {
+	return append(b.varint(uint64(field<<3|2)).varint(uint64(len(value))), value...)
+}
+
+func TestConvertToCPUProfile(t *testing.T) {
+	t.Parallel()
+
+	var profile protobuf
+	for _, s := range []string{"", "samples", "count", "cpu", "nanoseconds", "main", "/src/main.go", "work"} {
+		profile = profile.bytesField(6, []byte(s))
+	}
+	profile = profile.bytesField(1, protobuf{}.uint64Field(1, 1).uint64Field(2, 2))
+	profile = profile.bytesField(1, protobuf{}.uint64Field(1, 3).uint64Field(2, 4))
+	profile = profile.bytesField(5, protobuf{}.uint64Field(1, 1).uint64Field(2, 5).uint64Field(4, 6))
+	profile = profile.bytesField(5, protobuf{}.uint64Field(1, 2).uint64Field(2, 7).uint64Field(4, 6))
+	profile = profile.bytesField(4, protobuf{}.uint64Field(1, 1).bytesField(4, protobuf{}.uint64Field(1, 2).uint64Field(2, 10)))
+	profile = profile.bytesField(4, protobuf{}.uint64Field(1, 2).bytesField(4, protobuf{}.uint64Field(1, 1).uint64Field(2, 3)))
+	// The first sample is in work, called by main; the second in main. Locations are packed in the first.
+	profile = profile.bytesField(2, protobuf{}.bytesField(1, protobuf{}.varint(1).varint(2)).uint64Field(2, 2).uint64Field(2, 20_000_000))
+	profile = profile.bytesField(2, protobuf{}.uint64Field(1, 2).uint64Field(2, 1).uint64Field(2, 10_000_000))
+	profile = profile.uint64Field(9, 5_000_000_000)
+	profile = profile.uint64Field(10, 30_000_000)
+	profile = profile.uint64Field(12, 10_000_000)
+
+	converted, err := internalpprof.ConvertToCPUProfile(profile)
+	assert.NilError(t, err)
+	assert.Equal(t, string(converted), `{"nodes":[`+
+		`{"id":1,"callFrame":{"functionName":"(root)","scriptId":"0","url":"","lineNumber":-1,"columnNumber":-1},"hitCount":0,"children":[2]},`+
+		`{"id":2,"callFrame":{"functionName":"main","scriptId":"1","url":"file:///src/main.go","lineNumber":2,"columnNumber":0},"hitCount":1,"children":[3]},`+
+		`{"id":3,"callFrame":{"functionName":"work","scriptId":"1","url":"file:///src/main.go","lineNumber":9,"columnNumber":0},"hitCount":2}],`+
+		`"startTime":5000000,"endTime":5030000,"samples":[3,3,2],"timeDeltas":[10000,10000,10000]}`)
+}
+
+func TestConvertRuntimeCPUProfile(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+	assert.NilError(t, pprof.StartCPUProfile(&buffer))
+	for start := time.Now(); time.Since(start) < 50*time.Millisecond; {
+	}
+	pprof.StopCPUProfile()
+
+	converted, err := internalpprof.ConvertToCPUProfile(buffer.Bytes())
+	assert.NilError(t, err)
+	var profile struct {
+		Nodes []struct {
+			ID       int   `json:"id"`
+			Children []int `json:"children"`
+		} `json:"nodes"`
+		Samples    []int   `json:"samples"`
+		TimeDeltas []int64 `json:"timeDeltas"`
+	}
+	assert.NilError(t, json.Unmarshal(converted, &profile))
+	assert.Equal(t, len(profile.Samples), len(profile.TimeDeltas))
+	for i, node := range profile.Nodes {
+		assert.Equal(t, node.ID, i+1)
+		for _, child := range node.Children {
+			assert.Assert(t, child > node.ID && child <= len(profile.Nodes))
+		}
+	}
+	for _, sample := range profile.Samples {
+		assert.Assert(t, sample >= 1 && sample <= len(profile.Nodes))
+	}
+}
@@ -0,0 +1,283 @@
+package pprof
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// profile is the part of a pprof profile that is needed to convert it, as described by
+// https://github.com/google/pprof/blob/main/proto/profile.proto. Strings are indices into stringTable.
+type profile struct {
+	sampleTypes   []valueType
+	samples       []sample
+	locations     map[uint64]*location
+	functions     map[uint64]*function
+	stringTable   []string
+	timeNanos     int64
+	durationNanos int64
+	period        int64
+}
+
+type valueType struct {
+	typ  int64
+	unit int64
+}
+
+type sample struct {
+	// locationIDs are the locations of the stack of the sample, starting with the leaf.
+	locationIDs []uint64
+	values      []int64
+}
+
+type location struct {
+	// lines are the lines of the location; all but the last are of functions inlined into the last.
+	lines []line
+}
+
+type line struct {
+	functionID uint64
+	line       int64
+}
+
+type function struct {
+	name     int64
+	filename int64
+}
+
+func (p *profile) string(index int64) string {
+	if index < 0 || index >= int64(len(p.stringTable)) {
+		return ""
+	}
+	return p.stringTable[index]
+}
+
+// sampleTypeIndex returns the index of the value of a sample of the given type, or -1 if there is none.
+func (p *profile) sampleTypeIndex(typ string) int {
+	for i, sampleType := range p.sampleTypes {
+		if p.string(sampleType.typ) == typ {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseProfile parses a pprof profile, which is gzipped by runtime/pprof.
+func parseProfile(data []byte) (*profile, error) {
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if data, err = io.ReadAll(reader); err != nil {
+			return nil, err
+		}
+	}
+	p := &profile{
+		locations: make(map[uint64]*location),
+		functions: make(map[uint64]*function),
+	}
+	d := &decoder{data: data}
+	for d.next() {
+		switch d.field {
+		case 1:
+			var t valueType
+			m := d.message()
+			for m.next() {
+				switch m.field {
+				case 1:
+					t.typ = int64(m.varint())
+				case 2:
+					t.unit = int64(m.varint())
+				default:
+					m.skip()
+				}
+			}
+			p.sampleTypes = append(p.sampleTypes, t)
+			d.err = m.err
+		case 2:
+			var s sample
+			m := d.message()
+			for m.next() {
+				switch m.field {
+				case 1:
+					s.locationIDs = m.uint64s(s.locationIDs)
+				case 2:
+					for _, value := range m.uint64s(nil) {
+						s.values = append(s.values, int64(value))
+					}
+				default:
+					m.skip()
+				}
+			}
+			p.samples = append(p.samples, s)
+			d.err = m.err
+		case 4:
+			var id uint64
+			l := &location{}
+			m := d.message()
+			for m.next() {
+				switch m.field {
+				case 1:
+					id = m.varint()
+				case 4:
+					var ln line
+					lm := m.message()
+					for lm.next() {
+						switch lm.field {
+						case 1:
+							ln.functionID = lm.varint()
+						case 2:
+							ln.line = int64(lm.varint())
+						default:
+							lm.skip()
+						}
+					}
+					l.lines = append(l.lines, ln)
+					m.err = lm.err
+				default:
+					m.skip()
+				}
+			}
+			p.locations[id] = l
+			d.err = m.err
+		case 5:
+			var id uint64
+			f := &function{}
+			m := d.message()
+			for m.next() {
+				switch m.field {
+				case 1:
+					id = m.varint()
+				case 2:
+					f.name = int64(m.varint())
+				case 4:
+					f.filename = int64(m.varint())
+				default:
+					m.skip()
+				}
+			}
+			p.functions[id] = f
+			d.err = m.err
+		case 6:
+			p.stringTable = append(p.stringTable, string(d.bytes()))
+		case 9:
+			p.timeNanos = int64(d.varint())
+		case 10:
+			p.durationNanos = int64(d.varint())
+		case 12:
+			p.period = int64(d.varint())
+		default:
+			d.skip()
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return p, nil
+}
+
+var errTruncated = errors.New("pprof: truncated profile")
+
+// decoder decodes the fields of a protobuf message. The first error stops decoding and is kept in err.
+type decoder struct {
+	data     []byte
+	field    int
+	wireType int
+	err      error
+}
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// next reads the tag of the next field, and reports whether there is one.
+func (d *decoder) next() bool {
+	if d.err != nil || len(d.data) == 0 {
+		return false
+	}
+	tag := d.varint()
+	d.field = int(tag >> 3)
+	d.wireType = int(tag & 7)
+	return d.err == nil
+}
+
+func (d *decoder) varint() uint64 {
+	var value uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		if len(d.data) == 0 {
+			d.err = errTruncated
+			return 0
+		}
+		b := d.data[0]
+		d.data = d.data[1:]
+		value |= uint64(b&0x7f) << shift
+		if b < 0x80 {
+			return value
+		}
+	}
+	d.err = errors.New("pprof: invalid varint")
+	return 0
+}
+
+func (d *decoder) bytes() []byte {
+	length := d.varint()
+	if d.err != nil {
+		return nil
+	}
+	if length > uint64(len(d.data)) {
+		d.err = errTruncated
+		return nil
+	}
+	value := d.data[:length]
+	d.data = d.data[length:]
+	return value
+}
+
+// message returns a decoder for the field, which is an embedded message.
+func (d *decoder) message() *decoder {
+	return &decoder{data: d.bytes(), err: d.err}
+}
+
+// uint64s appends the values of a repeated integer field, which may be packed or not.
+func (d *decoder) uint64s(values []uint64) []uint64 {
+	if d.wireType != wireBytes {
+		return append(values, d.varint())
+	}
+	packed := &decoder{data: d.bytes(), err: d.err}
+	for d.err == nil && packed.err == nil && len(packed.data) > 0 {
+		values = append(values, packed.varint())
+	}
+	if d.err == nil {
+		d.err = packed.err
+	}
+	return values
+}
+
+func (d *decoder) skip() {
+	switch d.wireType {
+	case wireVarint:
+		d.varint()
+	case wireBytes:
+		d.bytes()
+	case wireFixed64:
+		d.skipBytes(8)
+	case wireFixed32:
+		d.skipBytes(4)
+	default:
+		d.err = fmt.Errorf("pprof: unsupported wire type %d", d.wireType)
+	}
+}
+
+func (d *decoder) skipBytes(size int) {
+	if len(d.data) < size {
+		d.err = errTruncated
+		return
+	}
+	d.data = d.data[size:]
+}
@@ -128,7 +128,7 @@ func (ct *ChangeTrackerWriter) assignPositionsToNodeWorker(
 	}
 	newNode.ForEachChild(func(child *ast.Node) bool {
 		child.Parent = newNode
-		return true
+		return false
 	})
 	newNode.Loc = core.NewTextRange(ct.getPos(node), ct.getEnd(node))
 	return newNode
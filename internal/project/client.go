@@ -10,4 +10,7 @@ type Client interface {
 	WatchFiles(ctx context.Context, id WatcherID, watchers []*lsproto.FileSystemWatcher) error
 	UnwatchFiles(ctx context.Context, id WatcherID) error
 	RefreshDiagnostics(ctx context.Context) error
+	RefreshInlayHints(ctx context.Context) error
+	RefreshSemanticTokens(ctx context.Context) error
+	RefreshCodeLens(ctx context.Context) error
 }
@@ -0,0 +1,117 @@
+package project
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/format"
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// WorkspaceConfiguration holds the editor settings that apply to the files of a workspace folder. Editors
+// configure TypeScript and JavaScript files separately, e.g. `typescript.format` and `javascript.format`.
+type WorkspaceConfiguration struct {
+	TypeScript *LanguageConfiguration
+	JavaScript *LanguageConfiguration
+}
+
+// LanguageConfiguration holds the editor settings that apply to the files of one language.
+type LanguageConfiguration struct {
+	UserPreferences *ls.UserPreferences
+	// FormatCodeSettings are nil if the editor did not configure formatting, in which case the defaults
+	// for the program's newline apply.
+	FormatCodeSettings *format.FormatCodeSettings
+}
+
+// NewDefaultLanguageConfiguration returns the configuration used for files of a language the editor did
+// not configure.
+func NewDefaultLanguageConfiguration() *LanguageConfiguration {
+	return &LanguageConfiguration{
+		UserPreferences: ls.NewDefaultUserPreferences(),
+	}
+}
+
+// DidChangeConfiguration replaces the configuration of all workspace folders. The configuration keyed by ""
+// applies to files outside of every folder. If the configuration changed, results the client may have
+// cached that depend on it are invalidated.
+func (s *Session) DidChangeConfiguration(ctx context.Context, configurations map[lsproto.DocumentUri]*WorkspaceConfiguration) {
+	folders := make(map[tspath.Path]*WorkspaceConfiguration, len(configurations))
+	for folder, configuration := range configurations {
+		if configuration == nil || configuration.TypeScript == nil && configuration.JavaScript == nil {
+			continue
+		}
+		if folder == "" {
+			folders[""] = configuration
+		} else {
+			folders[s.toPath(folder.FileName())] = configuration
+		}
+	}
+
+	s.configurationsMu.Lock()
+	changed := !reflect.DeepEqual(s.configurations, folders)
+	s.configurations = folders
+	s.configurationsMu.Unlock()
+
+	if !changed {
+		return
+	}
+	if s.options.LoggingEnabled {
+		s.logger.Log("Workspace configuration changed")
+	}
+	s.ScheduleDiagnosticsRefresh()
+	s.backgroundQueue.Enqueue(context.Background(), func(ctx context.Context) {
+		if err := s.client.RefreshInlayHints(ctx); err != nil && s.options.LoggingEnabled {
+			s.logger.Logf("Error refreshing inlay hints: %v", err)
+		}
+		if err := s.client.RefreshSemanticTokens(ctx); err != nil && s.options.LoggingEnabled {
+			s.logger.Logf("Error refreshing semantic tokens: %v", err)
+		}
+		if err := s.client.RefreshCodeLens(ctx); err != nil && s.options.LoggingEnabled {
+			s.logger.Logf("Error refreshing code lenses: %v", err)
+		}
+	})
+}
+
+// Configuration returns the configuration of the file's language in the innermost workspace folder
+// containing the file, falling back to the configuration of files outside of every folder.
+func (s *Session) Configuration(uri lsproto.DocumentUri) *LanguageConfiguration {
+	fileName := uri.FileName()
+	path := s.toPath(fileName)
+	language := func(configuration *WorkspaceConfiguration) *LanguageConfiguration {
+		switch core.GetScriptKindFromFileName(fileName) {
+		case core.ScriptKindJS, core.ScriptKindJSX:
+			return configuration.JavaScript
+		default:
+			return configuration.TypeScript
+		}
+	}
+	comparePathsOptions := tspath.ComparePathsOptions{UseCaseSensitiveFileNames: true}
+
+	s.configurationsMu.RLock()
+	defer s.configurationsMu.RUnlock()
+	var result *LanguageConfiguration
+	var resultFolder tspath.Path
+	for folder, configuration := range s.configurations {
+		if folder == "" || len(folder) <= len(resultFolder) {
+			continue
+		}
+		if tspath.ContainsPath(string(folder), string(path), comparePathsOptions) {
+			if languageConfiguration := language(configuration); languageConfiguration != nil {
+				result = languageConfiguration
+				resultFolder = folder
+			}
+		}
+	}
+	if result == nil {
+		if configuration := s.configurations[""]; configuration != nil {
+			result = language(configuration)
+		}
+	}
+	if result == nil {
+		return NewDefaultLanguageConfiguration()
+	}
+	return result
+}
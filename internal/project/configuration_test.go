@@ -0,0 +1,58 @@
+package project_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/microsoft/typescript-go/internal/ls"
+	"github.com/microsoft/typescript-go/internal/lsp/lsproto"
+	"github.com/microsoft/typescript-go/internal/project"
+	"github.com/microsoft/typescript-go/internal/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestWorkspaceConfiguration(t *testing.T) {
+	t.Parallel()
+
+	configuration := func(quotePreference ls.QuotePreference) *project.LanguageConfiguration {
+		return &project.LanguageConfiguration{UserPreferences: &ls.UserPreferences{QuotePreference: quotePreference}}
+	}
+
+	t.Run("chooses the configuration of the file's language", func(t *testing.T) {
+		t.Parallel()
+		session, _ := projecttestutil.Setup(map[string]any{})
+		session.DidChangeConfiguration(context.Background(), map[lsproto.DocumentUri]*project.WorkspaceConfiguration{
+			"": {
+				TypeScript: configuration(ls.QuotePreferenceSingle),
+				JavaScript: configuration(ls.QuotePreferenceDouble),
+			},
+			"file:///home/projects/app": {
+				JavaScript: configuration(ls.QuotePreferenceSingle),
+			},
+		})
+		assert.Equal(t, session.Configuration("file:///home/projects/lib/a.ts").UserPreferences.QuotePreference, ls.QuotePreferenceSingle)
+		assert.Equal(t, session.Configuration("file:///home/projects/lib/a.jsx").UserPreferences.QuotePreference, ls.QuotePreferenceDouble)
+		assert.Equal(t, session.Configuration("file:///home/projects/app/a.js").UserPreferences.QuotePreference, ls.QuotePreferenceSingle)
+		// The folder does not configure TypeScript, so the configuration outside of every folder applies.
+		assert.Equal(t, session.Configuration("file:///home/projects/app/a.ts").UserPreferences.QuotePreference, ls.QuotePreferenceSingle)
+	})
+
+	t.Run("refreshes results that depend on the configuration", func(t *testing.T) {
+		t.Parallel()
+		session, utils := projecttestutil.Setup(map[string]any{})
+		configurations := map[lsproto.DocumentUri]*project.WorkspaceConfiguration{
+			"": {TypeScript: configuration(ls.QuotePreferenceSingle)},
+		}
+		session.DidChangeConfiguration(context.Background(), configurations)
+		session.WaitForBackgroundTasks()
+		assert.Equal(t, len(utils.Client().RefreshInlayHintsCalls()), 1)
+		assert.Equal(t, len(utils.Client().RefreshSemanticTokensCalls()), 1)
+		assert.Equal(t, len(utils.Client().RefreshCodeLensCalls()), 1)
+
+		// Nothing is refreshed if the configuration did not change.
+		session.DidChangeConfiguration(context.Background(), configurations)
+		session.WaitForBackgroundTasks()
+		assert.Equal(t, len(utils.Client().RefreshSemanticTokensCalls()), 1)
+		assert.Equal(t, len(utils.Client().RefreshCodeLensCalls()), 1)
+	})
+}
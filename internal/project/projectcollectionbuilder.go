@@ -294,7 +294,43 @@ func (b *projectCollectionBuilder) DidRequestFile(uri lsproto.DocumentUri, logge
 		hasChanges = b.updateProgram(entry, logger) || hasChanges
 		return true
 	})
-	if hasChanges {
+	b.updateInferredProject(hasChanges, logger)
+
+	// At this point we should be able to find the default project for the file without
+	// creating anything else. Initially, I verified that and panicked if nothing was found,
+	// but that panic was getting triggered by fourslash infrastructure when it told us to
+	// open a package.json file. This is something the VS Code client would never do, but
+	// it seems possible that another client would. There's no point in panicking; we don't
+	// really even have an error condition until it tries to ask us language questions about
+	// a non-TS-handleable file.
+
+	if logger != nil {
+		elapsed := time.Since(startTime)
+		logger.Log(fmt.Sprintf("Completed file request for %s in %v", fileName, elapsed))
+	}
+}
+
+// DidRequestWorkspace ensures the programs of all projects are up to date, for requests that are
+// about every file the client knows of rather than a single one.
+func (b *projectCollectionBuilder) DidRequestWorkspace(logger *logging.LogTree) {
+	startTime := time.Now()
+	hasChanges := b.programStructureChanged
+	b.configuredProjects.Range(func(entry *dirty.SyncMapEntry[tspath.Path, *Project]) bool {
+		hasChanges = b.updateProgram(entry, logger) || hasChanges
+		return true
+	})
+	b.updateInferredProject(hasChanges, logger)
+
+	if logger != nil {
+		elapsed := time.Since(startTime)
+		logger.Log(fmt.Sprintf("Completed workspace request in %v", elapsed))
+	}
+}
+
+// updateInferredProject updates the program of the inferred project after the configured projects have
+// been updated.
+func (b *projectCollectionBuilder) updateInferredProject(configuredProjectsChanged bool, logger *logging.LogTree) {
+	if configuredProjectsChanged {
 		// If the structure of other projects changed, we might need to move files
 		// in/out of the inferred project.
 		var inferredProjectFiles []string
@@ -311,19 +347,6 @@ func (b *projectCollectionBuilder) DidRequestFile(uri lsproto.DocumentUri, logge
 	if b.inferredProject.Value() != nil {
 		b.updateProgram(b.inferredProject, logger)
 	}
-
-	// At this point we should be able to find the default project for the file without
-	// creating anything else. Initially, I verified that and panicked if nothing was found,
-	// but that panic was getting triggered by fourslash infrastructure when it told us to
-	// open a package.json file. This is something the VS Code client would never do, but
-	// it seems possible that another client would. There's no point in panicking; we don't
-	// really even have an error condition until it tries to ask us language questions about
-	// a non-TS-handleable file.
-
-	if logger != nil {
-		elapsed := time.Since(startTime)
-		logger.Log(fmt.Sprintf("Completed file request for %s in %v", fileName, elapsed))
-	}
 }
 
 func (b *projectCollectionBuilder) DidUpdateATAState(ataChanges map[tspath.Path]*ATAStateChange, logger *logging.LogTree) {
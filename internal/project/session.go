@@ -31,6 +31,7 @@ const (
 	UpdateReasonRequestedLanguageServicePendingChanges
 	UpdateReasonRequestedLanguageServiceProjectNotLoaded
 	UpdateReasonRequestedLanguageServiceProjectDirty
+	UpdateReasonRequestedWorkspace
 )
 
 // SessionOptions are the immutable initialization options for a session.
@@ -109,6 +110,11 @@ type Session struct {
 	diagnosticsRefreshCancel context.CancelFunc
 	diagnosticsRefreshMu     sync.Mutex
 
+	// configurations are the editor settings of each workspace folder,
+	// delivered by the LSP server through DidChangeConfiguration().
+	configurations   map[tspath.Path]*WorkspaceConfiguration
+	configurationsMu sync.RWMutex
+
 	// watches tracks the current watch globs and how many individual WatchedFiles
 	// are using each glob.
 	watches   map[fileSystemWatcherKey]*fileSystemWatcherValue
@@ -376,6 +382,27 @@ func (s *Session) GetLanguageService(ctx context.Context, uri lsproto.DocumentUr
 	return ls.NewLanguageService(project.GetProgram(), snapshot), nil
 }
 
+// GetWorkspaceSnapshot returns a snapshot in which the programs of all projects reflect every change the
+// client has made. The snapshot must be released when it is no longer used.
+func (s *Session) GetWorkspaceSnapshot(ctx context.Context) (*Snapshot, func()) {
+	fileChanges, overlays, ataChanges := s.flushChanges(ctx)
+	updateSnapshot := !fileChanges.IsEmpty() || len(ataChanges) > 0
+	if !updateSnapshot {
+		s.snapshotMu.RLock()
+		updateSnapshot = core.Some(s.snapshot.ProjectCollection.Projects(), func(p *Project) bool { return p.dirty })
+		s.snapshotMu.RUnlock()
+	}
+	if updateSnapshot {
+		s.UpdateSnapshot(ctx, overlays, SnapshotChange{
+			reason:             UpdateReasonRequestedWorkspace,
+			fileChanges:        fileChanges,
+			ataChanges:         ataChanges,
+			requestedWorkspace: true,
+		})
+	}
+	return s.Snapshot()
+}
+
 func (s *Session) UpdateSnapshot(ctx context.Context, overlays map[tspath.Path]*overlay, change SnapshotChange) *Snapshot {
 	s.snapshotMu.Lock()
 	oldSnapshot := s.snapshot
@@ -122,6 +122,9 @@ type SnapshotChange struct {
 	// requestedURIs are URIs that were requested by the client.
 	// The new snapshot should ensure projects for these URIs have loaded programs.
 	requestedURIs []lsproto.DocumentUri
+	// requestedWorkspace is set when the client requested information about all projects.
+	// The new snapshot should ensure all projects have up to date programs.
+	requestedWorkspace bool
 	// compilerOptionsForInferredProjects is the compiler options to use for inferred projects.
 	// It should only be set the value in the next snapshot should be changed. If nil, the
 	// value from the previous snapshot will be copied to the new snapshot.
@@ -169,6 +172,8 @@ func (s *Snapshot) Clone(ctx context.Context, change SnapshotChange, overlays ma
 			logger.Logf("Reason: RequestedLanguageService (project not loaded) - %v", change.requestedURIs)
 		case UpdateReasonRequestedLanguageServiceProjectDirty:
 			logger.Logf("Reason: RequestedLanguageService (project dirty) - %v", change.requestedURIs)
+		case UpdateReasonRequestedWorkspace:
+			logger.Logf("Reason: RequestedWorkspace")
 		}
 	}
 
@@ -213,6 +218,10 @@ func (s *Snapshot) Clone(ctx context.Context, change SnapshotChange, overlays ma
 		projectCollectionBuilder.DidRequestFile(uri, logger.Fork("DidRequestFile"))
 	}
 
+	if change.requestedWorkspace {
+		projectCollectionBuilder.DidRequestWorkspace(logger.Fork("DidRequestWorkspace"))
+	}
+
 	projectCollection, configFileRegistry := projectCollectionBuilder.Finalize(logger)
 
 	// Clean cached disk files not touched by any open project. It's not important that we do this on
@@ -21,9 +21,18 @@ var _ project.Client = &ClientMock{}
 //
 //		// make and configure a mocked project.Client
 //		mockedClient := &ClientMock{
+//			RefreshCodeLensFunc: func(ctx context.Context) error {
+//				panic("mock out the RefreshCodeLens method")
+//			},
 //			RefreshDiagnosticsFunc: func(ctx context.Context) error {
 //				panic("mock out the RefreshDiagnostics method")
 //			},
+//			RefreshInlayHintsFunc: func(ctx context.Context) error {
+//				panic("mock out the RefreshInlayHints method")
+//			},
+//			RefreshSemanticTokensFunc: func(ctx context.Context) error {
+//				panic("mock out the RefreshSemanticTokens method")
+//			},
 //			UnwatchFilesFunc: func(ctx context.Context, id project.WatcherID) error {
 //				panic("mock out the UnwatchFiles method")
 //			},
@@ -37,9 +46,18 @@ var _ project.Client = &ClientMock{}
 //
 //	}
 type ClientMock struct {
+	// RefreshCodeLensFunc mocks the RefreshCodeLens method.
+	RefreshCodeLensFunc func(ctx context.Context) error
+
 	// RefreshDiagnosticsFunc mocks the RefreshDiagnostics method.
 	RefreshDiagnosticsFunc func(ctx context.Context) error
 
+	// RefreshInlayHintsFunc mocks the RefreshInlayHints method.
+	RefreshInlayHintsFunc func(ctx context.Context) error
+
+	// RefreshSemanticTokensFunc mocks the RefreshSemanticTokens method.
+	RefreshSemanticTokensFunc func(ctx context.Context) error
+
 	// UnwatchFilesFunc mocks the UnwatchFiles method.
 	UnwatchFilesFunc func(ctx context.Context, id project.WatcherID) error
 
@@ -48,11 +66,26 @@ type ClientMock struct {
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// RefreshCodeLens holds details about calls to the RefreshCodeLens method.
+		RefreshCodeLens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// RefreshDiagnostics holds details about calls to the RefreshDiagnostics method.
 		RefreshDiagnostics []struct {
 			// Ctx is the ctx argument value.
 			Ctx context.Context
 		}
+		// RefreshInlayHints holds details about calls to the RefreshInlayHints method.
+		RefreshInlayHints []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// RefreshSemanticTokens holds details about calls to the RefreshSemanticTokens method.
+		RefreshSemanticTokens []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
 		// UnwatchFiles holds details about calls to the UnwatchFiles method.
 		UnwatchFiles []struct {
 			// Ctx is the ctx argument value.
@@ -70,9 +103,45 @@ type ClientMock struct {
 			Watchers []*lsproto.FileSystemWatcher
 		}
 	}
-	lockRefreshDiagnostics sync.RWMutex
-	lockUnwatchFiles       sync.RWMutex
-	lockWatchFiles         sync.RWMutex
+	lockRefreshCodeLens       sync.RWMutex
+	lockRefreshDiagnostics    sync.RWMutex
+	lockRefreshInlayHints     sync.RWMutex
+	lockRefreshSemanticTokens sync.RWMutex
+	lockUnwatchFiles          sync.RWMutex
+	lockWatchFiles            sync.RWMutex
+}
+
+// RefreshCodeLens calls RefreshCodeLensFunc.
+func (mock *ClientMock) RefreshCodeLens(ctx context.Context) error {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRefreshCodeLens.Lock()
+	mock.calls.RefreshCodeLens = append(mock.calls.RefreshCodeLens, callInfo)
+	mock.lockRefreshCodeLens.Unlock()
+	if mock.RefreshCodeLensFunc == nil {
+		var errOut error
+		return errOut
+	}
+	return mock.RefreshCodeLensFunc(ctx)
+}
+
+// RefreshCodeLensCalls gets all the calls that were made to RefreshCodeLens.
+// Check the length with:
+//
+//	len(mockedClient.RefreshCodeLensCalls())
+func (mock *ClientMock) RefreshCodeLensCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRefreshCodeLens.RLock()
+	calls = mock.calls.RefreshCodeLens
+	mock.lockRefreshCodeLens.RUnlock()
+	return calls
 }
 
 // RefreshDiagnostics calls RefreshDiagnosticsFunc.
@@ -108,6 +177,72 @@ func (mock *ClientMock) RefreshDiagnosticsCalls() []struct {
 	return calls
 }
 
+// RefreshInlayHints calls RefreshInlayHintsFunc.
+func (mock *ClientMock) RefreshInlayHints(ctx context.Context) error {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRefreshInlayHints.Lock()
+	mock.calls.RefreshInlayHints = append(mock.calls.RefreshInlayHints, callInfo)
+	mock.lockRefreshInlayHints.Unlock()
+	if mock.RefreshInlayHintsFunc == nil {
+		var errOut error
+		return errOut
+	}
+	return mock.RefreshInlayHintsFunc(ctx)
+}
+
+// RefreshInlayHintsCalls gets all the calls that were made to RefreshInlayHints.
+// Check the length with:
+//
+//	len(mockedClient.RefreshInlayHintsCalls())
+func (mock *ClientMock) RefreshInlayHintsCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRefreshInlayHints.RLock()
+	calls = mock.calls.RefreshInlayHints
+	mock.lockRefreshInlayHints.RUnlock()
+	return calls
+}
+
+// RefreshSemanticTokens calls RefreshSemanticTokensFunc.
+func (mock *ClientMock) RefreshSemanticTokens(ctx context.Context) error {
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockRefreshSemanticTokens.Lock()
+	mock.calls.RefreshSemanticTokens = append(mock.calls.RefreshSemanticTokens, callInfo)
+	mock.lockRefreshSemanticTokens.Unlock()
+	if mock.RefreshSemanticTokensFunc == nil {
+		var errOut error
+		return errOut
+	}
+	return mock.RefreshSemanticTokensFunc(ctx)
+}
+
+// RefreshSemanticTokensCalls gets all the calls that were made to RefreshSemanticTokens.
+// Check the length with:
+//
+//	len(mockedClient.RefreshSemanticTokensCalls())
+func (mock *ClientMock) RefreshSemanticTokensCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockRefreshSemanticTokens.RLock()
+	calls = mock.calls.RefreshSemanticTokens
+	mock.lockRefreshSemanticTokens.RUnlock()
+	return calls
+}
+
 // UnwatchFiles calls UnwatchFilesFunc.
 func (mock *ClientMock) UnwatchFiles(ctx context.Context, id project.WatcherID) error {
 	callInfo := struct {
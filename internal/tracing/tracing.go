@@ -0,0 +1,221 @@
+// Package tracing records what a compilation spends its time on, for `--generateTrace`. Events are written in the
+// Chrome trace event format to trace.json, which can be opened with chrome://tracing, https://ui.perfetto.dev or
+// @typescript/analyze-trace, and the types the checkers created are written to types.json.
+package tracing
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/tspath"
+	"github.com/microsoft/typescript-go/internal/vfs"
+)
+
+// Phase is the category of an event.
+type Phase string
+
+const (
+	PhaseParse      Phase = "parse"
+	PhaseProgram    Phase = "program"
+	PhaseBind       Phase = "bind"
+	PhaseCheck      Phase = "check"
+	PhaseCheckTypes Phase = "checkTypes"
+	PhaseEmit       Phase = "emit"
+)
+
+// Args are the details of an event.
+type Args map[string]any
+
+// Events that are too frequent to record all of them are only recorded when they span a multiple of the sample
+// interval, which keeps the trace small while still showing where the time goes.
+const sampleInterval = time.Millisecond
+
+const (
+	processID    = 1
+	mainThreadID = 1
+)
+
+type event struct {
+	Pid   int    `json:"pid"`
+	Tid   int    `json:"tid"`
+	Ph    string `json:"ph"`
+	Cat   string `json:"cat"`
+	Ts    int64  `json:"ts"`
+	Dur   *int64 `json:"dur,omitzero"`
+	Scope string `json:"s,omitzero"`
+	Name  string `json:"name"`
+	Args  Args   `json:"args,omitzero"`
+}
+
+// Tracer records the events of a compilation. Events are recorded as complete events with their duration rather
+// than as separate begin and end events, because files are parsed, bound, checked and emitted concurrently unless
+// --singleThreaded is given. All methods can be called on a nil Tracer, which records nothing.
+type Tracer struct {
+	now   func() time.Time
+	start time.Time
+
+	mu           sync.Mutex
+	events       []*event
+	checkerCount int
+	typeDumps    []func() []*TypeDescriptor
+}
+
+// NewTracer starts recording events, with now as the clock.
+func NewTracer(now func() time.Time) *Tracer {
+	t := &Tracer{now: now, start: now()}
+	t.events = append(t.events,
+		&event{Pid: processID, Tid: mainThreadID, Ph: "M", Cat: "__metadata", Name: "process_name", Args: Args{"name": "tsc"}},
+		&event{Pid: processID, Tid: mainThreadID, Ph: "M", Cat: "__metadata", Name: "thread_name", Args: Args{"name": "Main"}},
+		&event{Pid: processID, Tid: mainThreadID, Ph: "M", Cat: "disabled-by-default-devtools.timeline", Name: "TracingStartedInBrowser"},
+	)
+	return t
+}
+
+func (t *Tracer) timestamp(at time.Time) int64 {
+	return at.Sub(t.start).Microseconds()
+}
+
+func (t *Tracer) record(e *event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, e)
+}
+
+// Begin starts an event on the main thread that is recorded when the returned span ends.
+func (t *Tracer) Begin(phase Phase, name string, args Args) *Span {
+	return t.begin(mainThreadID, phase, name, args, false /*sampled*/)
+}
+
+// BeginSampled is like Begin, but the event is only recorded if it spans a multiple of the sample interval.
+func (t *Tracer) BeginSampled(phase Phase, name string, args Args) *Span {
+	return t.begin(mainThreadID, phase, name, args, true /*sampled*/)
+}
+
+// Instant records an event without a duration on the main thread.
+func (t *Tracer) Instant(phase Phase, name string, args Args) {
+	t.instant(mainThreadID, phase, name, args)
+}
+
+func (t *Tracer) begin(tid int, phase Phase, name string, args Args, sampled bool) *Span {
+	if t == nil {
+		return nil
+	}
+	return &Span{tracer: t, tid: tid, phase: phase, name: name, args: args, sampled: sampled, start: t.now()}
+}
+
+func (t *Tracer) instant(tid int, phase Phase, name string, args Args) {
+	if t == nil {
+		return
+	}
+	t.record(&event{Pid: processID, Tid: tid, Ph: "I", Cat: string(phase), Ts: t.timestamp(t.now()), Scope: "g", Name: name, Args: args})
+}
+
+// NewCheckerThread returns the thread the events of a new checker are recorded on, which shows which checker of
+// the pool handled a file. dumpTypes returns the types the checker created, for types.json.
+func (t *Tracer) NewCheckerThread(dumpTypes func() []*TypeDescriptor) *Thread {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.checkerCount++
+	thread := &Thread{tracer: t, tid: mainThreadID + t.checkerCount, Checker: t.checkerCount}
+	t.events = append(t.events, &event{Pid: processID, Tid: thread.tid, Ph: "M", Cat: "__metadata", Name: "thread_name", Args: Args{"name": "Checker " + strconv.Itoa(thread.Checker)}})
+	checker := thread.Checker
+	t.typeDumps = append(t.typeDumps, func() []*TypeDescriptor {
+		types := dumpTypes()
+		for _, descriptor := range types {
+			descriptor.Checker = checker
+		}
+		return types
+	})
+	return thread
+}
+
+// Write writes trace.json and types.json to traceDir.
+func (t *Tracer) Write(fs vfs.FS, traceDir string) error {
+	// Dumping the types formats them with their checker, which may record events itself, so the lock is only held
+	// to copy what has been recorded so far.
+	t.mu.Lock()
+	events := slices.Clone(t.events)
+	typeDumps := slices.Clone(t.typeDumps)
+	t.mu.Unlock()
+
+	trace, err := json.Marshal(events, json.Deterministic(true))
+	if err != nil {
+		return err
+	}
+	if err := fs.WriteFile(tspath.CombinePaths(traceDir, "trace.json"), string(trace), false); err != nil {
+		return err
+	}
+	types := []*TypeDescriptor{}
+	for _, dumpTypes := range typeDumps {
+		types = append(types, dumpTypes()...)
+	}
+	typesJSON, err := json.Marshal(types, json.Deterministic(true))
+	if err != nil {
+		return err
+	}
+	return fs.WriteFile(tspath.CombinePaths(traceDir, "types.json"), string(typesJSON), false)
+}
+
+// Thread records events on a thread of their own, e.g. those of one checker, so that they nest properly.
+type Thread struct {
+	tracer *Tracer
+	tid    int
+	// Checker is the 1-based number of the checker whose events are recorded on the thread.
+	Checker int
+}
+
+// Begin starts an event on the thread that is recorded when the returned span ends.
+func (t *Thread) Begin(phase Phase, name string, args Args) *Span {
+	if t == nil {
+		return nil
+	}
+	return t.tracer.begin(t.tid, phase, name, args, false /*sampled*/)
+}
+
+// BeginSampled is like Begin, but the event is only recorded if it spans a multiple of the sample interval.
+func (t *Thread) BeginSampled(phase Phase, name string, args Args) *Span {
+	if t == nil {
+		return nil
+	}
+	return t.tracer.begin(t.tid, phase, name, args, true /*sampled*/)
+}
+
+// Instant records an event without a duration on the thread.
+func (t *Thread) Instant(phase Phase, name string, args Args) {
+	if t == nil {
+		return
+	}
+	t.tracer.instant(t.tid, phase, name, args)
+}
+
+// Span is an event that has started.
+type Span struct {
+	tracer  *Tracer
+	tid     int
+	phase   Phase
+	name    string
+	args    Args
+	sampled bool
+	start   time.Time
+}
+
+// End records the event of the span with its duration.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	t := s.tracer
+	start := t.timestamp(s.start)
+	duration := t.timestamp(t.now()) - start
+	interval := sampleInterval.Microseconds()
+	if s.sampled && interval-start%interval > duration {
+		return
+	}
+	t.record(&event{Pid: processID, Tid: s.tid, Ph: "X", Cat: string(s.phase), Ts: start, Dur: &duration, Name: s.name, Args: s.args})
+}
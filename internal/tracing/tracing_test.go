@@ -0,0 +1,70 @@
+package tracing_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/microsoft/typescript-go/internal/tracing"
+	"github.com/microsoft/typescript-go/internal/vfs/vfstest"
+	"gotest.tools/v3/assert"
+)
+
+// clock returns a clock that advances by step every time it is read.
+func clock(step time.Duration) func() time.Time {
+	now := time.Unix(0, 0)
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+func TestSampledSpans(t *testing.T) {
+	t.Parallel()
+
+	fs := vfstest.FromMap(map[string]string{}, true /*useCaseSensitiveFileNames*/)
+	tracer := tracing.NewTracer(clock(100 * time.Microsecond))
+	tracer.BeginSampled(tracing.PhaseCheckTypes, "short", nil).End()
+	tracer.Begin(tracing.PhaseCheck, "recorded", nil).End()
+	tracer.NewCheckerThread(func() []*tracing.TypeDescriptor {
+		return []*tracing.TypeDescriptor{{ID: 1, Flags: []string{"Any"}}}
+	}).Instant(tracing.PhaseCheckTypes, "instant", tracing.Args{"sourceId": 1})
+	assert.NilError(t, tracer.Write(fs, "/trace"))
+
+	trace, ok := fs.ReadFile("/trace/trace.json")
+	assert.Assert(t, ok)
+	assert.Assert(t, !strings.Contains(trace, `"short"`))
+	assert.Assert(t, strings.Contains(trace, `"ph":"X","cat":"check","ts":300,"dur":100,"name":"recorded"`))
+	assert.Assert(t, strings.Contains(trace, `"tid":2,"ph":"I","cat":"checkTypes"`))
+	types, ok := fs.ReadFile("/trace/types.json")
+	assert.Assert(t, ok)
+	assert.Equal(t, types, `[{"checker":1,"id":1,"flags":["Any"]}]`)
+}
+
+func TestNilTracer(t *testing.T) {
+	t.Parallel()
+
+	var tracer *tracing.Tracer
+	tracer.Begin(tracing.PhaseParse, "createSourceFile", nil).End()
+	thread := tracer.NewCheckerThread(nil)
+	thread.BeginSampled(tracing.PhaseCheckTypes, "structuredTypeRelatedTo", nil).End()
+	thread.Instant(tracing.PhaseCheckTypes, "checkTypeRelatedTo_DepthLimit", nil)
+}
+
+func TestWriteWhileDumpingTypesRecordsEvents(t *testing.T) {
+	t.Parallel()
+
+	fs := vfstest.FromMap(map[string]string{}, true /*useCaseSensitiveFileNames*/)
+	tracer := tracing.NewTracer(clock(100 * time.Microsecond))
+	var thread *tracing.Thread
+	thread = tracer.NewCheckerThread(func() []*tracing.TypeDescriptor {
+		// Formatting a type for its display can check it, which records events.
+		thread.Begin(tracing.PhaseCheckTypes, "typeToString", nil).End()
+		return []*tracing.TypeDescriptor{{ID: 1, Flags: []string{"Object"}, Display: "{}"}}
+	})
+	assert.NilError(t, tracer.Write(fs, "/trace"))
+
+	types, ok := fs.ReadFile("/trace/types.json")
+	assert.Assert(t, ok)
+	assert.Equal(t, types, `[{"checker":1,"id":1,"flags":["Object"],"display":"{}"}]`)
+}
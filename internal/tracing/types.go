@@ -0,0 +1,35 @@
+package tracing
+
+// TypeDescriptor describes a type in types.json. Type IDs are those of the checker that created the type, which
+// are also the IDs the events of the checker refer to.
+type TypeDescriptor struct {
+	Checker                 int                    `json:"checker"`
+	ID                      uint32                 `json:"id"`
+	IntrinsicName           string                 `json:"intrinsicName,omitzero"`
+	SymbolName              string                 `json:"symbolName,omitzero"`
+	IsTuple                 bool                   `json:"isTuple,omitzero"`
+	UnionTypes              []uint32               `json:"unionTypes,omitzero"`
+	IntersectionTypes       []uint32               `json:"intersectionTypes,omitzero"`
+	AliasTypeArguments      []uint32               `json:"aliasTypeArguments,omitzero"`
+	KeyofType               uint32                 `json:"keyofType,omitzero"`
+	IndexedAccessObjectType uint32                 `json:"indexedAccessObjectType,omitzero"`
+	IndexedAccessIndexType  uint32                 `json:"indexedAccessIndexType,omitzero"`
+	ConditionalCheckType    uint32                 `json:"conditionalCheckType,omitzero"`
+	ConditionalExtendsType  uint32                 `json:"conditionalExtendsType,omitzero"`
+	SubstitutionBaseType    uint32                 `json:"substitutionBaseType,omitzero"`
+	FirstDeclaration        *DeclarationDescriptor `json:"firstDeclaration,omitzero"`
+	Flags                   []string               `json:"flags"`
+	Display                 string                 `json:"display,omitzero"`
+}
+
+// DeclarationDescriptor is the location of a declaration, with one-based lines and characters.
+type DeclarationDescriptor struct {
+	Path  string   `json:"path"`
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
@@ -0,0 +1,197 @@
+package tsoptions
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// ConvertToTSConfig returns the tsconfig `--showConfig` prints for a parsed config: the options that are set to values
+// other than their defaults and the options they imply, with enum values by name and file paths relative to the
+// config file, the files of the project, the include and exclude
+// specs, the project references and the watch options. configFileName is the config file the result is relative to,
+// which need not exist if the config was given on the command line.
+func ConvertToTSConfig(configParseResult *ParsedCommandLine, configFileName string, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	configFilePath := tspath.GetNormalizedAbsolutePath(configFileName, comparePathsOptions.CurrentDirectory)
+	result := &collections.OrderedMap[string, any]{}
+	result.Set("compilerOptions", serializeShowConfigCompilerOptions(configParseResult.CompilerOptions(), configFilePath, comparePathsOptions))
+	if watchOptions := configParseResult.ParsedConfig.WatchOptions; watchOptions != nil {
+		if serialized := serializeWatchOptions(watchOptions); serialized.Size() > 0 {
+			result.Set("watchOptions", serialized)
+		}
+	}
+	if references := configParseResult.ProjectReferences(); len(references) > 0 {
+		result.Set("references", core.Map(references, func(reference *core.ProjectReference) *collections.OrderedMap[string, any] {
+			serialized := &collections.OrderedMap[string, any]{}
+			serialized.Set("path", reference.OriginalPath)
+			if reference.Circular {
+				serialized.Set("circular", true)
+			}
+			return serialized
+		}))
+	}
+	if fileNames := configParseResult.FileNames(); len(fileNames) > 0 {
+		result.Set("files", core.Map(fileNames, func(fileName string) string {
+			return tspath.GetRelativePathFromFile(configFilePath, tspath.GetNormalizedAbsolutePath(fileName, comparePathsOptions.CurrentDirectory), comparePathsOptions)
+		}))
+	}
+	if configParseResult.ConfigFile != nil && configParseResult.ConfigFile.configFileSpecs != nil {
+		specs := configParseResult.ConfigFile.configFileSpecs
+		// The default include is not written.
+		if len(specs.validatedIncludeSpecs) > 0 && !(len(specs.validatedIncludeSpecs) == 1 && specs.validatedIncludeSpecs[0] == defaultIncludeSpec) {
+			result.Set("include", specs.validatedIncludeSpecs)
+		}
+		if len(specs.validatedExcludeSpecs) > 0 {
+			result.Set("exclude", specs.validatedExcludeSpecs)
+		}
+	}
+	if raw, ok := configParseResult.Raw.(*collections.OrderedMap[string, any]); ok && raw.GetOrZero("compileOnSave") == true {
+		result.Set("compileOnSave", true)
+	}
+	return result
+}
+
+// computedOption is an option whose value follows from other options when it is not set.
+type computedOption struct {
+	name         string
+	dependencies []string
+	// computeValue sets the option in result to the value it has with options.
+	computeValue func(options *core.CompilerOptions, result *core.CompilerOptions)
+}
+
+func strictComputedOption(name string, value func(options *core.CompilerOptions) *core.Tristate) computedOption {
+	return computedOption{name, []string{"strict"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		*value(result) = core.BoolToTristate(options.GetStrictOptionValue(*value(options)))
+	}}
+}
+
+var computedOptions = []computedOption{
+	{"target", []string{"module"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.Target = options.GetEmitScriptTarget()
+	}},
+	{"module", []string{"target"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.Module = options.GetEmitModuleKind()
+	}},
+	{"moduleResolution", []string{"module", "target"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.ModuleResolution = options.GetModuleResolutionKind()
+	}},
+	{"moduleDetection", []string{"module", "target"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.ModuleDetection = options.GetEmitModuleDetectionKind()
+	}},
+	{"isolatedModules", []string{"verbatimModuleSyntax"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.IsolatedModules = core.BoolToTristate(options.GetIsolatedModules())
+	}},
+	{"esModuleInterop", []string{"module", "target"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.ESModuleInterop = core.BoolToTristate(options.GetESModuleInterop())
+	}},
+	{"allowSyntheticDefaultImports", []string{"module", "target", "moduleResolution", "esModuleInterop"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.AllowSyntheticDefaultImports = core.BoolToTristate(options.GetAllowSyntheticDefaultImports())
+	}},
+	{"resolvePackageJsonExports", nil, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.ResolvePackageJsonExports = core.BoolToTristate(options.GetResolvePackageJsonExports())
+	}},
+	{"resolvePackageJsonImports", nil, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.ResolvePackageJsonImports = core.BoolToTristate(options.GetResolvePackageJsonImports())
+	}},
+	{"resolveJsonModule", []string{"moduleResolution", "module", "target"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.ResolveJsonModule = core.BoolToTristate(options.GetResolveJsonModule())
+	}},
+	{"allowJs", []string{"checkJs"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.AllowJs = core.BoolToTristate(options.GetAllowJS())
+	}},
+	{"allowImportingTsExtensions", []string{"rewriteRelativeImportExtensions"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.AllowImportingTsExtensions = core.BoolToTristate(options.GetAllowImportingTsExtensions())
+	}},
+	{"declaration", []string{"composite"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.Declaration = core.BoolToTristate(options.GetEmitDeclarations())
+	}},
+	{"declarationMap", []string{"declaration", "composite"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.DeclarationMap = core.BoolToTristate(options.GetAreDeclarationMapsEnabled())
+	}},
+	{"incremental", []string{"composite"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.Incremental = core.BoolToTristate(options.IsIncremental())
+	}},
+	{"preserveConstEnums", []string{"isolatedModules", "verbatimModuleSyntax"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.PreserveConstEnums = core.BoolToTristate(options.ShouldPreserveConstEnums())
+	}},
+	{"useDefineForClassFields", []string{"target", "module"}, func(options *core.CompilerOptions, result *core.CompilerOptions) {
+		result.UseDefineForClassFields = core.BoolToTristate(options.GetEmitStandardClassFields())
+	}},
+	strictComputedOption("noImplicitAny", func(options *core.CompilerOptions) *core.Tristate { return &options.NoImplicitAny }),
+	strictComputedOption("noImplicitThis", func(options *core.CompilerOptions) *core.Tristate { return &options.NoImplicitThis }),
+	strictComputedOption("strictNullChecks", func(options *core.CompilerOptions) *core.Tristate { return &options.StrictNullChecks }),
+	strictComputedOption("strictFunctionTypes", func(options *core.CompilerOptions) *core.Tristate { return &options.StrictFunctionTypes }),
+	strictComputedOption("strictBindCallApply", func(options *core.CompilerOptions) *core.Tristate { return &options.StrictBindCallApply }),
+	strictComputedOption("strictPropertyInitialization", func(options *core.CompilerOptions) *core.Tristate { return &options.StrictPropertyInitialization }),
+	strictComputedOption("strictBuiltinIteratorReturn", func(options *core.CompilerOptions) *core.Tristate { return &options.StrictBuiltinIteratorReturn }),
+	strictComputedOption("alwaysStrict", func(options *core.CompilerOptions) *core.Tristate { return &options.AlwaysStrict }),
+	strictComputedOption("useUnknownInCatchVariables", func(options *core.CompilerOptions) *core.Tristate { return &options.UseUnknownInCatchVariables }),
+}
+
+// serializeShowConfigCompilerOptions serializes the options that are set to values other than their defaults,
+// followed by the computed options that the set options give values other than their defaults.
+func serializeShowConfigCompilerOptions(options *core.CompilerOptions, configFilePath string, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	serialized := SerializeCompilerOptions(options, configFilePath, comparePathsOptions)
+	result := &collections.OrderedMap[string, any]{}
+	for name, value := range serialized.Entries() {
+		if !isDefaultOptionValue(options, name, value) {
+			result.Set(name, value)
+		}
+	}
+
+	implied := &core.CompilerOptions{}
+	for _, computed := range computedOptions {
+		if serialized.Has(computed.name) || !slices.ContainsFunc(computed.dependencies, serialized.Has) {
+			continue
+		}
+		value, defaultValue := &core.CompilerOptions{}, &core.CompilerOptions{}
+		computed.computeValue(options, value)
+		computed.computeValue(&core.CompilerOptions{}, defaultValue)
+		if !reflect.DeepEqual(value, defaultValue) {
+			computed.computeValue(options, implied)
+		}
+	}
+	for name, value := range SerializeCompilerOptions(implied, configFilePath, comparePathsOptions).Entries() {
+		result.Set(name, value)
+	}
+	return result
+}
+
+// isDefaultOptionValue reports whether an option that is set has the value it would have if it was not set.
+func isDefaultOptionValue(options *core.CompilerOptions, name string, serializedValue any) bool {
+	if computed := core.Find(computedOptions, func(computed computedOption) bool { return computed.name == name }); computed.computeValue != nil {
+		unset := options.Clone()
+		ForEachCompilerOptionValue(unset, func(option *CommandLineOption) bool { return option.Name == name }, func(option *CommandLineOption, value reflect.Value, i int) bool {
+			value.SetZero()
+			return true
+		})
+		value, defaultValue := &core.CompilerOptions{}, &core.CompilerOptions{}
+		computed.computeValue(options, value)
+		computed.computeValue(unset, defaultValue)
+		return reflect.DeepEqual(value, defaultValue)
+	}
+	switch defaultValue := CommandLineCompilerOptionsMap.Get(name).DefaultValueDescription.(type) {
+	case bool, int, string:
+		return serializedValue == defaultValue
+	}
+	return false
+}
+
+// serializeWatchOptions returns the values of the set watch options, keyed by option name.
+func serializeWatchOptions(options *core.WatchOptions) *collections.OrderedMap[string, any] {
+	result := &collections.OrderedMap[string, any]{}
+	optionsValue := reflect.ValueOf(options).Elem()
+	optionsType := optionsValue.Type()
+	for i := range optionsValue.NumField() {
+		name, _, _ := strings.Cut(optionsType.Field(i).Tag.Get("json"), ",")
+		option := WatchNameMap.Get(name)
+		if value := optionsValue.Field(i); option != nil && !value.IsZero() {
+			result.Set(option.Name, serializeOptionValue(option, value, func(path string) string { return path }))
+		}
+	}
+	return result
+}
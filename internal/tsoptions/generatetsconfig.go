@@ -0,0 +1,260 @@
+package tsoptions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-json-experiment/json"
+	"github.com/microsoft/typescript-go/internal/collections"
+	"github.com/microsoft/typescript-go/internal/core"
+	"github.com/microsoft/typescript-go/internal/diagnostics"
+	"github.com/microsoft/typescript-go/internal/tspath"
+)
+
+// defaultInitCompilerOptions are the options `tsc --init` sets unless they are given on the command line.
+var defaultInitCompilerOptions = &core.CompilerOptions{
+	Module:                           core.ModuleKindCommonJS,
+	Target:                           core.ScriptTargetES2016,
+	Strict:                           core.TSTrue,
+	ESModuleInterop:                  core.TSTrue,
+	ForceConsistentCasingInFileNames: core.TSTrue,
+	SkipLibCheck:                     core.TSTrue,
+}
+
+// SerializeCompilerOptions returns the values of the set compiler options as they would be written in a tsconfig,
+// keyed by option name. Enum values are written by name. If configFilePath is given, file paths are made relative
+// to the directory of the config file.
+func SerializeCompilerOptions(options *core.CompilerOptions, configFilePath string, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	result := &collections.OrderedMap[string, any]{}
+	relativePath := func(path string) string {
+		if configFilePath == "" {
+			return path
+		}
+		return tspath.GetRelativePathFromFile(configFilePath, tspath.GetNormalizedAbsolutePath(path, tspath.GetDirectoryPath(configFilePath)), comparePathsOptions)
+	}
+	ForEachCompilerOptionValue(options, func(option *CommandLineOption) bool {
+		return option.Category != diagnostics.Command_line_Options && option.Category != diagnostics.Output_Formatting
+	}, func(option *CommandLineOption, value reflect.Value, i int) bool {
+		if !value.IsZero() {
+			result.Set(option.Name, serializeOptionValue(option, value, relativePath))
+		}
+		return false
+	})
+	return result
+}
+
+// serializeOptionValue converts the value of an option to the value it is written with in a tsconfig.
+func serializeOptionValue(option *CommandLineOption, value reflect.Value, relativePath func(path string) string) any {
+	switch v := value.Interface().(type) {
+	case core.Tristate:
+		return v.IsTrue()
+	case *int:
+		return *v
+	case string:
+		if option.IsFilePath {
+			return relativePath(v)
+		}
+		return v
+	case []string:
+		switch elements := option.Elements(); {
+		case elements != nil && elements.Kind == CommandLineOptionTypeEnum:
+			return core.Map(v, func(element string) string {
+				return getNameOfEnumValue(elements, element)
+			})
+		case elements != nil && elements.IsFilePath:
+			return core.Map(v, relativePath)
+		default:
+			return v
+		}
+	default:
+		if option.Kind == CommandLineOptionTypeEnum {
+			return getNameOfEnumValue(option, value.Interface())
+		}
+		return value.Interface()
+	}
+}
+
+// getNameOfEnumValue returns the first name an enum option maps to a value, e.g. "es6" for ScriptTargetES2015.
+func getNameOfEnumValue(option *CommandLineOption, value any) string {
+	for name, enumValue := range option.EnumMap().Entries() {
+		if enumValue == value {
+			return name
+		}
+	}
+	return ""
+}
+
+// getSerializedInitCompilerOptions serializes the options given to `tsc --init` over the default ones.
+func getSerializedInitCompilerOptions(options *core.CompilerOptions, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	initOptions := mergeCompilerOptions(defaultInitCompilerOptions.Clone(), options, nil)
+	configFilePath := tspath.CombinePaths(comparePathsOptions.CurrentDirectory, "tsconfig.json")
+	return SerializeCompilerOptions(initOptions, configFilePath, comparePathsOptions)
+}
+
+// getDefaultValueForOption returns the value a commented out option is shown with in a generated tsconfig.
+func getDefaultValueForOption(option *CommandLineOption) any {
+	switch option.Kind {
+	case CommandLineOptionTypeNumber:
+		return 1
+	case CommandLineOptionTypeBoolean:
+		return true
+	case CommandLineOptionTypeString:
+		if !option.IsFilePath {
+			return ""
+		}
+		defaultValue, _ := option.DefaultValueDescription.(string)
+		return "./" + defaultValue
+	case CommandLineOptionTypeList:
+		return []any{}
+	case CommandLineOptionTypeListOrElement:
+		return getDefaultValueForOption(option.Elements())
+	case CommandLineOptionTypeObject:
+		return map[string]any{}
+	default:
+		for name := range option.EnumMap().Keys() {
+			return name
+		}
+		panic("Expected 'option.type' to have entries.")
+	}
+}
+
+// GenerateTSConfig returns the text of the tsconfig `tsc --init` writes: the given and default options followed by
+// the other common options, commented out, grouped by category and described.
+func GenerateTSConfig(options *core.CompilerOptions, fileNames []string, comparePathsOptions tspath.ComparePathsOptions, newLine string) string {
+	compilerOptionsMap := getSerializedInitCompilerOptions(options, comparePathsOptions)
+
+	// Options in niche categories are only written if they are set.
+	categoriesToSkip := []*diagnostics.Message{
+		diagnostics.Command_line_Options,
+		diagnostics.Editor_Support,
+		diagnostics.Compiler_Diagnostics,
+		diagnostics.Backwards_Compatibility,
+		diagnostics.Watch_and_Build_Modes,
+		diagnostics.Output_Formatting,
+	}
+	categorizedOptions := collections.NewOrderedMapFromList([]collections.MapEntry[*diagnostics.Message, []*CommandLineOption]{
+		{Key: diagnostics.Projects},
+		{Key: diagnostics.Language_and_Environment},
+		{Key: diagnostics.Modules},
+		{Key: diagnostics.JavaScript_Support},
+		{Key: diagnostics.Emit},
+		{Key: diagnostics.Interop_Constraints},
+		{Key: diagnostics.Type_Checking},
+		{Key: diagnostics.Completeness},
+	})
+	for _, option := range OptionsDeclarations {
+		if option.IsCommandLineOnly || option.Category == nil || (core.Some(categoriesToSkip, func(category *diagnostics.Message) bool {
+			return option.Category == category
+		}) && !compilerOptionsMap.Has(option.Name)) {
+			continue
+		}
+		categorizedOptions.Set(option.Category, append(categorizedOptions.GetOrZero(option.Category), option))
+	}
+
+	type entry struct {
+		value       string
+		description string
+		set         bool
+	}
+	var entries []entry
+	lastSetEntry := -1
+	for category, categoryOptions := range categorizedOptions.Entries() {
+		if len(entries) != 0 {
+			entries = append(entries, entry{})
+		}
+		entries = append(entries, entry{value: "/* " + category.Format() + " */"})
+		for _, option := range categoryOptions {
+			description := option.Name
+			if option.Description != nil {
+				description = option.Description.Format()
+			}
+			if value, ok := compilerOptionsMap.Get(option.Name); ok {
+				lastSetEntry = len(entries)
+				entries = append(entries, entry{value: fmt.Sprintf("%q: %s", option.Name, marshalConfigValue(value)), description: "/* " + description + " */", set: true})
+			} else {
+				entries = append(entries, entry{value: fmt.Sprintf("// %q: %s,", option.Name, marshalConfigValue(getDefaultValueForOption(option))), description: "/* " + description + " */"})
+			}
+		}
+	}
+	// Only the options that are printed are separated by commas, so that the last of them has none.
+	marginLength := 0
+	for i := range entries {
+		if entries[i].set && i != lastSetEntry {
+			entries[i].value += ","
+		}
+		if entries[i].description != "" {
+			marginLength = max(marginLength, len(entries[i].value))
+		}
+	}
+
+	const tab = "  "
+	var result []string
+	result = append(result, "{")
+	result = append(result, tab+`"compilerOptions": {`)
+	result = append(result, tab+tab+"/* "+diagnostics.Visit_https_Colon_Slash_Slashaka_ms_Slashtsconfig_to_read_more_about_this_file.Format()+" */")
+	result = append(result, "")
+	// Align all the descriptions on the same column.
+	for _, entry := range entries {
+		if entry.value == "" {
+			result = append(result, "")
+			continue
+		}
+		line := tab + tab + entry.value
+		if entry.description != "" {
+			line += strings.Repeat(" ", marginLength-len(entry.value)+2) + entry.description
+		}
+		result = append(result, line)
+	}
+	if len(fileNames) > 0 {
+		result = append(result, tab+"},")
+		result = append(result, tab+`"files": [`)
+		for i, fileName := range fileNames {
+			result = append(result, tab+tab+marshalConfigValue(fileName)+core.IfElse(i == len(fileNames)-1, "", ","))
+		}
+		result = append(result, tab+"]")
+	} else {
+		result = append(result, tab+"}")
+	}
+	result = append(result, "}")
+	return strings.Join(result, newLine) + newLine
+}
+
+// GetCompilerOptionsDiffValue lists the options `tsc --init` wrote that differ from the values a commented out
+// option is shown with, or that are set by default.
+func GetCompilerOptionsDiffValue(options *core.CompilerOptions, comparePathsOptions tspath.ComparePathsOptions, newLine string) string {
+	compilerOptionsMap := getSerializedInitCompilerOptions(options, comparePathsOptions)
+	defaultOptionsMap := SerializeCompilerOptions(defaultInitCompilerOptions, "", comparePathsOptions)
+	var result []string
+	for _, option := range optionsForCompiler {
+		value, ok := compilerOptionsMap.Get(option.Name)
+		if !ok {
+			continue
+		}
+		isDefaultValue := option.Kind != CommandLineOptionTypeList && option.Kind != CommandLineOptionTypeObject && value == getDefaultValueForOption(option)
+		if !isDefaultValue || defaultOptionsMap.Has(option.Name) {
+			result = append(result, "  "+option.Name+": "+formatConfigValue(value))
+		}
+	}
+	return strings.Join(result, newLine) + newLine
+}
+
+func marshalConfigValue(value any) string {
+	text, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+	return string(text)
+}
+
+// formatConfigValue formats a value the way JavaScript converts it to a string, e.g. lists are comma separated.
+func formatConfigValue(value any) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, ",")
+	case *collections.OrderedMap[string, []string]:
+		return marshalConfigValue(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -512,6 +512,13 @@ func getExtendsConfigPathOrArray(
 	return extendedConfigPathArray, errors
 }
 
+// GetExtendsConfigPath returns the file name of the config file that an `extends` value in a config file in the
+// directory basePath refers to, or "" if it cannot be resolved.
+func GetExtendsConfigPath(extendedConfig string, host ParseConfigHost, basePath string) string {
+	extendedConfigPath, _ := getExtendsConfigPath(extendedConfig, host, basePath, nil /*valueExpression*/, nil /*sourceFile*/)
+	return extendedConfigPath
+}
+
 func getExtendsConfigPath(
 	extendedConfig string,
 	host ParseConfigHost,
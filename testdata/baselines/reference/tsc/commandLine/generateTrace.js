@@ -0,0 +1,53 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/a.ts] *new* 
+export const a: { x: number } = { x: 1 };
+//// [/home/src/workspaces/project/b.ts] *new* 
+import { a } from "./a";
+export const b: { x: number; y?: string } = a;
+
+tsgo --generateTrace trace --singleThreaded b.ts
+ExitStatus:: Success
+Output::
+//// [/home/src/tslibs/TS/Lib/lib.d.ts] *Lib*
+/// <reference no-default-lib="true"/>
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number { toExponential: any; }
+interface Object {}
+interface RegExp {}
+interface String { charAt: any; }
+interface Array<T> { length: number; [n: number]: T; }
+interface ReadonlyArray<T> {}
+interface SymbolConstructor {
+    (desc?: string | number): symbol;
+    for(name: string): symbol;
+    readonly toStringTag: symbol;
+}
+declare var Symbol: SymbolConstructor;
+interface Symbol {
+    readonly [Symbol.toStringTag]: string;
+}
+declare const console: { log(msg: any): void; };
+//// [/home/src/workspaces/project/a.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.a = void 0;
+exports.a = { x: 1 };
+
+//// [/home/src/workspaces/project/b.js] *new* 
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.b = void 0;
+const a_1 = require("./a");
+exports.b = a_1.a;
+
+//// [/home/src/workspaces/project/trace/trace.json] *new* 
+[{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"process_name","args":{"name":"tsc"}},{"pid":1,"tid":1,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Main"}},{"pid":1,"tid":1,"ph":"M","cat":"disabled-by-default-devtools.timeline","ts":0,"name":"TracingStartedInBrowser"},{"pid":1,"tid":1,"ph":"X","cat":"parse","ts":3000000,"dur":1000000,"name":"createSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},{"pid":1,"tid":1,"ph":"X","cat":"parse","ts":5000000,"dur":1000000,"name":"createSourceFile","args":{"path":"/home/src/workspaces/project/b.ts"}},{"pid":1,"tid":1,"ph":"X","cat":"program","ts":7000000,"dur":1000000,"name":"resolveModuleNamesWorker","args":{"containingFileName":"/home/src/workspaces/project/b.ts"}},{"pid":1,"tid":1,"ph":"X","cat":"parse","ts":9000000,"dur":1000000,"name":"createSourceFile","args":{"path":"/home/src/workspaces/project/a.ts"}},{"pid":1,"tid":1,"ph":"X","cat":"program","ts":2000000,"dur":9000000,"name":"createProgram","args":{"configFilePath":"","rootDir":"/home/src/workspaces/project"}},{"pid":1,"tid":1,"ph":"X","cat":"bind","ts":14000000,"dur":1000000,"name":"bindSourceFile","args":{"path":"/home/src/workspaces/project/b.ts"}},{"pid":1,"tid":1,"ph":"X","cat":"bind","ts":16000000,"dur":1000000,"name":"bindSourceFile","args":{"path":"/home/src/workspaces/project/a.ts"}},{"pid":1,"tid":1,"ph":"X","cat":"bind","ts":18000000,"dur":1000000,"name":"bindSourceFile","args":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},{"pid":1,"tid":2,"ph":"M","cat":"__metadata","ts":0,"name":"thread_name","args":{"name":"Checker 1"}},{"pid":1,"tid":2,"ph":"X","cat":"check","ts":22000000,"dur":1000000,"name":"checkSourceFile","args":{"checker":1,"path":"/home/src/tslibs/TS/Lib/lib.d.ts"}},{"pid":1,"tid":2,"ph":"X","cat":"checkTypes","ts":25000000,"dur":1000000,"name":"structuredTypeRelatedTo","args":{"sourceId":93,"targetId":90}},{"pid":1,"tid":2,"ph":"X","cat":"check","ts":24000000,"dur":3000000,"name":"checkSourceFile","args":{"checker":1,"path":"/home/src/workspaces/project/a.ts"}},{"pid":1,"tid":2,"ph":"X","cat":"checkTypes","ts":29000000,"dur":1000000,"name":"structuredTypeRelatedTo","args":{"sourceId":90,"targetId":94}},{"pid":1,"tid":2,"ph":"X","cat":"check","ts":28000000,"dur":3000000,"name":"checkSourceFile","args":{"checker":1,"path":"/home/src/workspaces/project/b.ts"}},{"pid":1,"tid":1,"ph":"X","cat":"emit","ts":34000000,"dur":2000000,"name":"emitJsFileOrBundle","args":{"jsFilePath":"/home/src/workspaces/project/b.js"}},{"pid":1,"tid":1,"ph":"X","cat":"emit","ts":37000000,"dur":2000000,"name":"emitJsFileOrBundle","args":{"jsFilePath":"/home/src/workspaces/project/a.js"}}]
+//// [/home/src/workspaces/project/trace/types.json] *new* 
+[{"checker":1,"id":1,"intrinsicName":"any","flags":["Any"]},{"checker":1,"id":2,"intrinsicName":"any","flags":["Any"]},{"checker":1,"id":3,"intrinsicName":"any","flags":["Any"]},{"checker":1,"id":4,"intrinsicName":"any","flags":["Any"]},{"checker":1,"id":5,"intrinsicName":"any","flags":["Any"]},{"checker":1,"id":6,"intrinsicName":"unresolved","flags":["Any"]},{"checker":1,"id":7,"intrinsicName":"any","flags":["Any"]},{"checker":1,"id":8,"intrinsicName":"intrinsic","flags":["Any"]},{"checker":1,"id":9,"intrinsicName":"unknown","flags":["Unknown"]},{"checker":1,"id":10,"intrinsicName":"undefined","flags":["Undefined"]},{"checker":1,"id":11,"intrinsicName":"undefined","flags":["Undefined"]},{"checker":1,"id":12,"intrinsicName":"undefined","flags":["Undefined"]},{"checker":1,"id":13,"intrinsicName":"undefined","flags":["Undefined"]},{"checker":1,"id":14,"intrinsicName":"null","flags":["Null"]},{"checker":1,"id":15,"intrinsicName":"null","flags":["Null"]},{"checker":1,"id":16,"intrinsicName":"string","flags":["String"]},{"checker":1,"id":17,"intrinsicName":"number","flags":["Number"]},{"checker":1,"id":18,"intrinsicName":"bigint","flags":["BigInt"]},{"checker":1,"id":19,"flags":["BooleanLiteral"],"display":"false"},{"checker":1,"id":20,"flags":["BooleanLiteral"],"display":"false"},{"checker":1,"id":21,"flags":["BooleanLiteral"],"display":"true"},{"checker":1,"id":22,"flags":["BooleanLiteral"],"display":"true"},{"checker":1,"id":23,"unionTypes":[19,21],"flags":["Boolean","Union"]},{"checker":1,"id":24,"intrinsicName":"symbol","flags":["ESSymbol"]},{"checker":1,"id":25,"intrinsicName":"void","flags":["Void"]},{"checker":1,"id":26,"intrinsicName":"never","flags":["Never"]},{"checker":1,"id":27,"intrinsicName":"never","flags":["Never"]},{"checker":1,"id":28,"intrinsicName":"never","flags":["Never"]},{"checker":1,"id":29,"intrinsicName":"never","flags":["Never"]},{"checker":1,"id":30,"intrinsicName":"object","flags":["NonPrimitive"]},{"checker":1,"id":31,"unionTypes":[16,17],"flags":["Union"]},{"checker":1,"id":32,"unionTypes":[16,17,24],"flags":["Union"]},{"checker":1,"id":33,"unionTypes":[17,18],"flags":["Union"]},{"checker":1,"id":34,"flags":["TemplateLiteral"]},{"checker":1,"id":35,"unionTypes":[16,17,18,19,21],"flags":["Union"]},{"checker":1,"id":36,"intrinsicName":"never","flags":["Never"]},{"checker":1,"id":37,"flags":["Object"],"display":"{}"},{"checker":1,"id":38,"flags":["Object"],"display":"{}"},{"checker":1,"id":39,"flags":["Object"],"display":"{}"},{"checker":1,"id":40,"symbolName":"__type","flags":["Object"],"display":"{}"},{"checker":1,"id":41,"flags":["Object"],"display":"{}"},{"checker":1,"id":42,"flags":["Object"],"display":"{}"},{"checker":1,"id":43,"flags":["Object"],"display":"{}"},{"checker":1,"id":44,"flags":["Object"],"display":"{}"},{"checker":1,"id":45,"flags":["Object"],"display":"{}"},{"checker":1,"id":46,"flags":["Object"],"display":"{}"},{"checker":1,"id":47,"flags":["TypeParameter"]},{"checker":1,"id":48,"flags":["TypeParameter"]},{"checker":1,"id":49,"flags":["TypeParameter"]},{"checker":1,"id":50,"flags":["TypeParameter"]},{"checker":1,"id":51,"flags":["TypeParameter"]},{"checker":1,"id":52,"flags":["StringLiteral"],"display":"\"\""},{"checker":1,"id":53,"flags":["NumberLiteral"],"display":"0"},{"checker":1,"id":54,"flags":["BigIntLiteral"],"display":"0n"},{"checker":1,"id":55,"flags":["StringLiteral"],"display":"\"bigint\""},{"checker":1,"id":56,"flags":["StringLiteral"],"display":"\"boolean\""},{"checker":1,"id":57,"flags":["StringLiteral"],"display":"\"function\""},{"checker":1,"id":58,"flags":["StringLiteral"],"display":"\"number\""},{"checker":1,"id":59,"flags":["StringLiteral"],"display":"\"object\""},{"checker":1,"id":60,"flags":["StringLiteral"],"display":"\"string\""},{"checker":1,"id":61,"flags":["StringLiteral"],"display":"\"symbol\""},{"checker":1,"id":62,"flags":["StringLiteral"],"display":"\"undefined\""},{"checker":1,"id":63,"unionTypes":[55,56,57,58,59,60,61,62],"flags":["Union"]},{"checker":1,"id":64,"symbolName":"IArguments","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":5,"character":29},"end":{"line":6,"character":24}},"flags":["Object"]},{"checker":1,"id":65,"symbolName":"globalThis","flags":["Object"],"display":"typeof globalThis"},{"checker":1,"id":66,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},{"checker":1,"id":67,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":17},"end":{"line":11,"character":18}},"flags":["TypeParameter"]},{"checker":1,"id":68,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["TypeParameter"]},{"checker":1,"id":69,"symbolName":"Object","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":7,"character":41},"end":{"line":8,"character":20}},"flags":["Object"]},{"checker":1,"id":70,"symbolName":"Function","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":2,"character":21},"end":{"line":3,"character":22}},"flags":["Object"]},{"checker":1,"id":71,"symbolName":"String","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":9,"character":20},"end":{"line":10,"character":34}},"flags":["Object"]},{"checker":1,"id":72,"symbolName":"Number","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":6,"character":24},"end":{"line":7,"character":41}},"flags":["Object"]},{"checker":1,"id":73,"symbolName":"Boolean","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":1,"character":1},"end":{"line":2,"character":21}},"flags":["Object"]},{"checker":1,"id":74,"symbolName":"RegExp","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":8,"character":20},"end":{"line":9,"character":20}},"flags":["Object"]},{"checker":1,"id":75,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},{"checker":1,"id":76,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},{"checker":1,"id":77,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},{"checker":1,"id":78,"symbolName":"T","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":25},"end":{"line":12,"character":26}},"flags":["TypeParameter"]},{"checker":1,"id":79,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["TypeParameter"]},{"checker":1,"id":80,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},{"checker":1,"id":81,"symbolName":"CallableFunction","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":3,"character":22},"end":{"line":4,"character":30}},"flags":["Object"]},{"checker":1,"id":82,"symbolName":"NewableFunction","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":4,"character":30},"end":{"line":5,"character":29}},"flags":["Object"]},{"checker":1,"id":83,"symbolName":"Array","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":10,"character":34},"end":{"line":11,"character":55}},"flags":["Object"]},{"checker":1,"id":84,"flags":["StringLiteral"],"display":"\"length\""},{"checker":1,"id":85,"symbolName":"ReadonlyArray","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":11,"character":55},"end":{"line":12,"character":30}},"flags":["Object"]},{"checker":1,"id":86,"symbolName":"SymbolConstructor","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":12,"character":30},"end":{"line":17,"character":2}},"flags":["Object"]},{"checker":1,"id":87,"symbolName":"toStringTag","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":15,"character":31},"end":{"line":16,"character":34}},"flags":["UniqueESSymbol"]},{"checker":1,"id":88,"symbolName":"Symbol","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":18,"character":12},"end":{"line":18,"character":38}},"flags":["Object"]},{"checker":1,"id":89,"symbolName":"__type","firstDeclaration":{"path":"/home/src/tslibs/TS/Lib/lib.d.ts","start":{"line":22,"character":23},"end":{"line":22,"character":48}},"flags":["Object"],"display":"{ log(msg: any): void; }"},{"checker":1,"id":90,"symbolName":"__type","firstDeclaration":{"path":"/home/src/workspaces/project/a.ts","start":{"line":1,"character":16},"end":{"line":1,"character":30}},"flags":["Object"],"display":"{ x: number; }"},{"checker":1,"id":91,"flags":["NumberLiteral"],"display":"1"},{"checker":1,"id":92,"flags":["NumberLiteral"],"display":"1"},{"checker":1,"id":93,"symbolName":"__object","firstDeclaration":{"path":"/home/src/workspaces/project/a.ts","start":{"line":1,"character":32},"end":{"line":1,"character":41}},"flags":["Object"],"display":"{ x: number; }"},{"checker":1,"id":94,"symbolName":"__type","firstDeclaration":{"path":"/home/src/workspaces/project/b.ts","start":{"line":2,"character":16},"end":{"line":2,"character":42}},"flags":["Object"],"display":"{ x: number; y?: string; }"}]
+
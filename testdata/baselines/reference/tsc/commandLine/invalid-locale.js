@@ -0,0 +1,11 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/index.ts] *new* 
+const a: number = 1;
+
+tsgo --locale 12-34 index.ts
+ExitStatus:: DiagnosticsPresent_OutputsSkipped
+Output::
+[91merror[0m[90m TS6048: [0mLocale must be of the form <language> or <language>-<territory>. For example 'en' or 'ja-jp'.
+
@@ -0,0 +1,13 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/tslibs/TS/Lib/de/diagnosticMessages.generated.json] *new* 
+{ "Type_0_is_not_assignable_to_type_1_2322": 
+//// [/home/src/workspaces/project/index.ts] *new* 
+const a: number = 1;
+
+tsgo --locale de index.ts
+ExitStatus:: DiagnosticsPresent_OutputsSkipped
+Output::
+[91merror[0m[90m TS6051: [0mCorrupted locale file /home/src/tslibs/TS/Lib/de/diagnosticMessages.generated.json.
+
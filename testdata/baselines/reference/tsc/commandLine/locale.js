@@ -0,0 +1,49 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/tslibs/TS/Lib/ja/diagnosticMessages.generated.json] *new* 
+{
+    "Type_0_is_not_assignable_to_type_1_2322": "型 '{0}' を型 '{1}' に割り当てることはできません。",
+    "Found_1_error_in_0_6259": "{0} に 1 件のエラーが見つかりました"
+}
+//// [/home/src/workspaces/project/index.ts] *new* 
+const a: number = "hello";
+
+tsgo --locale ja-jp --pretty index.ts
+ExitStatus:: DiagnosticsPresent_OutputsGenerated
+Output::
+[96mindex.ts[0m:[93m1[0m:[93m7[0m - [91merror[0m[90m TS2322: [0m型 'string' を型 'number' に割り当てることはできません。
+
+[7m1[0m const a: number = "hello";
+[7m [0m [91m      ~[0m
+
+
+index.ts[90m:1[0m に 1 件のエラーが見つかりました
+
+//// [/home/src/tslibs/TS/Lib/lib.d.ts] *Lib*
+/// <reference no-default-lib="true"/>
+interface Boolean {}
+interface Function {}
+interface CallableFunction {}
+interface NewableFunction {}
+interface IArguments {}
+interface Number { toExponential: any; }
+interface Object {}
+interface RegExp {}
+interface String { charAt: any; }
+interface Array<T> { length: number; [n: number]: T; }
+interface ReadonlyArray<T> {}
+interface SymbolConstructor {
+    (desc?: string | number): symbol;
+    for(name: string): symbol;
+    readonly toStringTag: symbol;
+}
+declare var Symbol: SymbolConstructor;
+interface Symbol {
+    readonly [Symbol.toStringTag]: string;
+}
+declare const console: { log(msg: any): void; };
+//// [/home/src/workspaces/project/index.js] *new* 
+const a = "hello";
+
+
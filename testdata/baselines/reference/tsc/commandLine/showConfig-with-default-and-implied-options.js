@@ -0,0 +1,39 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/index.ts] *new* 
+export const a = 1;
+//// [/home/src/workspaces/project/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "strict": false,
+        "noEmit": false,
+        "strictNullChecks": true,
+        "composite": true,
+        "module": "nodenext"
+    },
+    "files": ["index.ts"]
+}
+
+tsgo --showConfig
+ExitStatus:: Success
+Output::
+{
+    "compilerOptions": {
+        "composite": true,
+        "module": "nodenext",
+        "strictNullChecks": true,
+        "declaration": true,
+        "esModuleInterop": true,
+        "incremental": true,
+        "moduleResolution": "nodenext",
+        "moduleDetection": "force",
+        "resolveJsonModule": false,
+        "target": "esnext",
+        "useDefineForClassFields": true
+    },
+    "files": [
+        "./index.ts"
+    ]
+}
+
@@ -0,0 +1,23 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/first.ts] *new* 
+export const a = 1;
+
+tsgo --showConfig --target es2015 --outDir out --watchFile usefsevents first.ts
+ExitStatus:: Success
+Output::
+{
+    "compilerOptions": {
+        "outDir": "./out",
+        "target": "es6",
+        "module": "es6"
+    },
+    "watchOptions": {
+        "watchFile": "usefsevents"
+    },
+    "files": [
+        "./first.ts"
+    ]
+}
+
@@ -0,0 +1,89 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/lib/index.ts] *new* 
+export const c = 3;
+//// [/home/src/workspaces/project/lib/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "composite": true
+    }
+}
+//// [/home/src/workspaces/project/src/index.ts] *new* 
+export const a = 1;
+//// [/home/src/workspaces/project/src/util.ts] *new* 
+export const b = 2;
+//// [/home/src/workspaces/project/tsconfig.json] *new* 
+{
+    "compilerOptions": {
+        "target": "es2022",
+        "module": "nodenext",
+        "lib": ["es2022", "dom"],
+        "strict": true,
+        "outDir": "./dist",
+        "rootDirs": ["./src", "./generated"],
+        "paths": {
+            "@lib/*": ["./lib/*"]
+        }
+    },
+    "include": ["src"],
+    "exclude": ["src/**/*.test.ts"],
+    "references": [{ "path": "./lib" }],
+    "compileOnSave": true
+}
+
+tsgo --showConfig
+ExitStatus:: Success
+Output::
+{
+    "compilerOptions": {
+        "lib": [
+            "es2022",
+            "dom"
+        ],
+        "module": "nodenext",
+        "outDir": "./dist",
+        "paths": {
+            "@lib/*": [
+                "./lib/*"
+            ]
+        },
+        "rootDirs": [
+            "./src",
+            "./generated"
+        ],
+        "strict": true,
+        "target": "es2022",
+        "alwaysStrict": true,
+        "esModuleInterop": true,
+        "moduleResolution": "nodenext",
+        "moduleDetection": "force",
+        "noImplicitAny": true,
+        "noImplicitThis": true,
+        "resolveJsonModule": false,
+        "strictBindCallApply": true,
+        "strictBuiltinIteratorReturn": true,
+        "strictFunctionTypes": true,
+        "strictNullChecks": true,
+        "strictPropertyInitialization": true,
+        "useDefineForClassFields": true,
+        "useUnknownInCatchVariables": true
+    },
+    "references": [
+        {
+            "path": "./lib"
+        }
+    ],
+    "files": [
+        "./src/index.ts",
+        "./src/util.ts"
+    ],
+    "include": [
+        "src"
+    ],
+    "exclude": [
+        "src/**/*.test.ts"
+    ],
+    "compileOnSave": true
+}
+
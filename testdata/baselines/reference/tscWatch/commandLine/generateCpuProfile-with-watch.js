@@ -0,0 +1,11 @@
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/index.ts] *new* 
+const a: number = 1;
+
+tsgo --generateCpuProfile profiles/tsc.cpuprofile --watch index.ts
+ExitStatus:: DiagnosticsPresent_OutputsSkipped
+Output::
+[91merror[0m[90m TS6370: [0mOptions 'watch' and 'generateCpuProfile' cannot be combined.
+